@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	mf "github.com/manifestival/manifestival"
+	"go.uber.org/zap"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/system"
+	"sigs.k8s.io/yaml"
+
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1beta1"
+	"knative.dev/eventmesh-operator/pkg/manifests"
+	"knative.dev/eventmesh-operator/pkg/reconciler/common"
+	"knative.dev/eventmesh-operator/pkg/reconciler/eventmesh"
+	"knative.dev/eventmesh-operator/pkg/scaler"
+)
+
+// render prints the manifests the operator would apply for a given EventMesh. It runs the same stages as the
+// reconciler up to the install, but simulates the cluster state via flags instead of reading it from a cluster.
+func main() {
+	var (
+		file             = flag.String("f", "", "Path to the EventMesh YAML (v1alpha1 or v1beta1). Reads from stdin if empty or -")
+		kodata           = flag.String("kodata", envOrDefault("KO_DATA_PATH", "cmd/operator/kodata"), "Path to the manifest bundles")
+		namespace        = flag.String("namespace", "knative-eventing", "System namespace of the operator")
+		certManager      = flag.Bool("cert-manager", false, "Simulate an installed cert-manager")
+		inMemoryChannels = flag.Int("in-memory-channels", 0, "Simulated number of InMemoryChannels")
+		mtBrokers        = flag.Int("mt-brokers", 0, "Simulated number of MTChannelBasedBrokers")
+		kafkaBrokers     = flag.Int("kafka-brokers", 0, "Simulated number of Brokers of the Kafka class")
+		kafkaChannels    = flag.Int("kafka-channels", 0, "Simulated number of KafkaChannels")
+		kafkaSinks       = flag.Int("kafka-sinks", 0, "Simulated number of KafkaSinks")
+		kafkaSources     = flag.Int("kafka-sources", 0, "Simulated number of KafkaSources")
+		installedVersion = flag.String("installed-version", "", "Simulated version of the installed components. Empty simulates a fresh installation")
+		verbose          = flag.Bool("v", false, "Log the pipeline to stderr")
+	)
+	flag.Parse()
+
+	// the system namespace is always set from the flag, as imported test packages default SYSTEM_NAMESPACE on init
+	_ = os.Setenv("KO_DATA_PATH", *kodata)
+	_ = os.Setenv(system.NamespaceEnvKey, *namespace)
+
+	logger := zap.NewNop()
+	if *verbose {
+		var err error
+		if logger, err = zap.NewDevelopment(); err != nil {
+			log.Fatal("Failed to create logger: ", err)
+		}
+	}
+	ctx := logging.WithLogger(context.Background(), logger.Sugar())
+
+	em, err := readEventMesh(ctx, *file)
+	if err != nil {
+		log.Fatal("Failed to read EventMesh: ", err)
+	}
+
+	em.SetDefaults(ctx)
+	if err := em.Validate(ctx); err != nil {
+		log.Fatal("Invalid EventMesh: ", err)
+	}
+
+	// the reconciler records the installed versions in the status, which is also used to detect upgrades
+	em.Status.EventingVersion = *installedVersion
+	em.Status.EventingKafkaBrokerVersion = *installedVersion
+	if *installedVersion != "" {
+		// the simulated installation is available, so step-by-step upgrades continue with the next release
+		em.Status.MarkDeploymentsAvailable()
+	}
+
+	rendered, err := render(ctx, em, *certManager, staticScaleTargets{
+		scaler.ComponentIMC:          *inMemoryChannels,
+		scaler.ComponentMTBroker:     *mtBrokers,
+		scaler.ComponentKafkaBroker:  *kafkaBrokers,
+		scaler.ComponentKafkaChannel: *kafkaChannels,
+		scaler.ComponentKafkaSink:    *kafkaSinks,
+		scaler.ComponentKafkaSource:  *kafkaSources,
+	})
+	if err != nil {
+		log.Fatal("Failed to render manifests: ", err)
+	}
+
+	if err := printManifests(os.Stdout, rendered); err != nil {
+		log.Fatal("Failed to print manifests: ", err)
+	}
+}
+
+// render runs the stages of the reconciler up to the install for the given EventMesh and returns the sorted manifests.
+// The cluster state is simulated by the given scale targets and whether cert-manager is installed.
+func render(ctx context.Context, em *v1alpha1.EventMesh, certManager bool, scaleTargets staticScaleTargets) (*manifests.Manifests, error) {
+	crdLister := fakeCRDLister(certManager)
+	deploymentLister := appsv1listers.NewDeploymentLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}))
+	statefulSetLister := appsv1listers.NewStatefulSetLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}))
+
+	var rendered *manifests.Manifests
+	stages := common.Stages{
+		// load manifests
+		manifests.AppendFromParser(ctx, manifests.NewEventingParser(crdLister, deploymentLister)),
+		manifests.AppendFromParser(ctx, manifests.NewKafkaBrokerParser(crdLister, deploymentLister)),
+
+		// apply scaling
+		eventmesh.ApplyScaling(scaleTargets, eventmesh.WorkloadListers{Deployments: deploymentLister, StatefulSets: statefulSetLister}, func(interface{}, time.Duration) {}),
+
+		// add owner reference transformer
+		eventmesh.AddOwnerReference,
+
+		// run transformers
+		manifests.Transform,
+
+		// keep the result instead of installing it
+		func(_ context.Context, m *manifests.Manifests, _ *v1alpha1.EventMesh) error {
+			rendered = m
+			return nil
+		},
+	}
+
+	if err := stages.Execute(ctx, em); err != nil {
+		return nil, err
+	}
+
+	rendered.Sort()
+	return rendered, nil
+}
+
+// readEventMesh reads the EventMesh from the given file. v1beta1 EventMeshes are converted to v1alpha1, which is the
+// version the reconciler works with.
+func readEventMesh(ctx context.Context, file string) (*v1alpha1.EventMesh, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if file == "" || file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(data, &typeMeta); err != nil {
+		return nil, fmt.Errorf("failed to parse type: %w", err)
+	}
+
+	em := &v1alpha1.EventMesh{}
+	switch typeMeta.APIVersion {
+	case v1alpha1.SchemeGroupVersion.String():
+		if err := yaml.UnmarshalStrict(data, em); err != nil {
+			return nil, fmt.Errorf("failed to parse %s EventMesh: %w", typeMeta.APIVersion, err)
+		}
+	case v1beta1.SchemeGroupVersion.String():
+		source := &v1beta1.EventMesh{}
+		if err := yaml.UnmarshalStrict(data, source); err != nil {
+			return nil, fmt.Errorf("failed to parse %s EventMesh: %w", typeMeta.APIVersion, err)
+		}
+		if err := em.ConvertFrom(ctx, source); err != nil {
+			return nil, fmt.Errorf("failed to convert %s EventMesh: %w", typeMeta.APIVersion, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported apiVersion %q", typeMeta.APIVersion)
+	}
+
+	if typeMeta.Kind != "EventMesh" {
+		return nil, fmt.Errorf("unsupported kind %q", typeMeta.Kind)
+	}
+
+	return em, nil
+}
+
+// fakeCRDLister returns a CRD lister, which contains the cert-manager CRD if requested
+func fakeCRDLister(certManager bool) apiextensionsv1listers.CustomResourceDefinitionLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if certManager {
+		_ = indexer.Add(&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "certificates.cert-manager.io",
+			},
+		})
+	}
+
+	return apiextensionsv1listers.NewCustomResourceDefinitionLister(indexer)
+}
+
+// staticScaleTargets computes the scale targets from the simulated number of objects per component. Scale downs are
+// never delayed, as there is no previous scale target.
+type staticScaleTargets map[string]int
+
+func (s staticScaleTargets) ScaleTarget(em *v1alpha1.EventMesh, rule *scaler.Rule, _ func() (int64, bool)) (int64, time.Duration, error) {
+	return rule.ScaleTargetFor(em.Spec.Scaling, s[rule.Component]), 0, nil
+}
+
+func (staticScaleTargets) ScaleApplied(*v1alpha1.EventMesh) {}
+
+// printManifests prints the manifests to apply, to delete and the post-install manifests as YAML documents
+func printManifests(w io.Writer, m *manifests.Manifests) error {
+	sets := []struct {
+		name     string
+		manifest mf.Manifest
+	}{
+		{name: "ToApply", manifest: m.ToApply},
+		{name: "ToDelete", manifest: m.ToDelete},
+		{name: "PostInstall", manifest: m.PostInstall},
+	}
+
+	for _, set := range sets {
+		for _, u := range set.manifest.Resources() {
+			data, err := yaml.Marshal(u.Object)
+			if err != nil {
+				return fmt.Errorf("failed to marshal %s %s/%s: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+			}
+
+			if _, err := fmt.Fprintf(w, "---\n# %s\n%s", set.name, data); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func envOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+)
+
+var update = flag.Bool("update", false, "Update the golden files")
+
+func TestRenderDefaultEventMesh(t *testing.T) {
+	t.Setenv("KO_DATA_PATH", "../operator/kodata")
+	t.Setenv("SYSTEM_NAMESPACE", "knative-eventing")
+	ctx := logging.WithLogger(context.Background(), zap.NewNop().Sugar())
+
+	em, err := readEventMesh(ctx, "testdata/eventmesh.yaml")
+	if err != nil {
+		t.Fatalf("readEventMesh() = %v", err)
+	}
+	em.SetDefaults(ctx)
+
+	rendered, err := render(ctx, em, false, staticScaleTargets{})
+	if err != nil {
+		t.Fatalf("render() = %v", err)
+	}
+
+	var got bytes.Buffer
+	if err := printManifests(&got, rendered); err != nil {
+		t.Fatalf("printManifests() = %v", err)
+	}
+
+	const golden = "testdata/eventmesh.golden.yaml"
+	if *update {
+		if err := os.WriteFile(golden, got.Bytes(), 0o644); err != nil {
+			t.Fatalf("failed to update %s: %v", golden, err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", golden, err)
+	}
+	if diff := cmp.Diff(string(want), got.String()); diff != "" {
+		t.Errorf("rendered manifests (-want, +got) = %v\nRun the test with -update to update the golden file", diff)
+	}
+}
@@ -2,17 +2,27 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"knative.dev/hack/schema/commands"
 	"knative.dev/hack/schema/registry"
 
 	operatorv1alpha1 "knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	operatorv1beta1 "knative.dev/eventmesh-operator/pkg/apis/operator/v1beta1"
 )
 
 // schema is a tool to dump the schema for Eventing resources.
+// The registry is keyed by kind, so the API version to dump is selected via the SCHEMA_VERSION env var.
 func main() {
 	// Eventing
-	registry.Register(&operatorv1alpha1.EventMesh{})
+	switch version := os.Getenv("SCHEMA_VERSION"); version {
+	case "", operatorv1alpha1.SchemeGroupVersion.Version:
+		registry.Register(&operatorv1alpha1.EventMesh{})
+	case operatorv1beta1.SchemeGroupVersion.Version:
+		registry.Register(&operatorv1beta1.EventMesh{})
+	default:
+		log.Fatalf("Unknown SCHEMA_VERSION %q", version)
+	}
 
 	if err := commands.New("knative.dev/eventmesh-operator").Execute(); err != nil {
 		log.Fatal("Error during command execution: ", err)
@@ -3,20 +3,28 @@ package main
 import (
 	"context"
 
+	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/scheme"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection/sharedmain"
+	"knative.dev/pkg/logging"
 	"knative.dev/pkg/signals"
 	"knative.dev/pkg/webhook"
 	"knative.dev/pkg/webhook/certificates"
 	"knative.dev/pkg/webhook/resourcesemantics"
+	"knative.dev/pkg/webhook/resourcesemantics/conversion"
 	"knative.dev/pkg/webhook/resourcesemantics/defaulting"
 	"knative.dev/pkg/webhook/resourcesemantics/validation"
 
+	"knative.dev/eventmesh-operator/pkg/apis/operator"
 	eventmeshv1alpha1 "knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	eventmeshv1beta1 "knative.dev/eventmesh-operator/pkg/apis/operator/v1beta1"
 	versionedscheme "knative.dev/eventmesh-operator/pkg/client/clientset/versioned/scheme"
+	eventmeshclient "knative.dev/eventmesh-operator/pkg/client/injection/client"
+	"knative.dev/eventmesh-operator/pkg/manifests"
+	eventmeshwebhook "knative.dev/eventmesh-operator/pkg/webhook"
 )
 
 func init() {
@@ -25,10 +33,9 @@ func init() {
 
 var ourTypes = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	eventmeshv1alpha1.SchemeGroupVersion.WithKind("EventMesh"): &eventmeshv1alpha1.EventMesh{},
+	eventmeshv1beta1.SchemeGroupVersion.WithKind("EventMesh"):  &eventmeshv1beta1.EventMesh{},
 }
 
-var callbacks = map[schema.GroupVersionKind]validation.Callback{}
-
 func NewDefaultingAdmissionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 	ctxFunc := func(ctx context.Context) context.Context {
 		return ctx
@@ -54,8 +61,20 @@ func NewDefaultingAdmissionController(ctx context.Context, cmw configmap.Watcher
 }
 
 func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	// the versions are bundled with the webhook, so it is sufficient to load them once
+	versions, err := manifests.AvailableVersions()
+	if err != nil {
+		logging.FromContext(ctx).Fatalw("Failed to get available versions", zap.Error(err))
+	}
+
 	ctxFunc := func(ctx context.Context) context.Context {
-		return ctx
+		return operator.WithAvailableVersions(ctx, versions)
+	}
+
+	singleEventMeshCallback := validation.NewCallback(eventmeshwebhook.SingleEventMesh(eventmeshclient.Get(ctx)), webhook.Create)
+	callbacks := map[schema.GroupVersionKind]validation.Callback{
+		eventmeshv1alpha1.SchemeGroupVersion.WithKind("EventMesh"): singleEventMeshCallback,
+		eventmeshv1beta1.SchemeGroupVersion.WithKind("EventMesh"):  singleEventMeshCallback,
 	}
 
 	return validation.NewAdmissionController(ctx,
@@ -80,6 +99,33 @@ func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 	)
 }
 
+func NewConversionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	ctxFunc := func(ctx context.Context) context.Context {
+		return ctx
+	}
+
+	return conversion.NewConversionController(ctx,
+
+		// The path on which to serve the webhook.
+		"/resource-conversion",
+
+		// Specify the types of custom resource definitions that should be converted.
+		map[schema.GroupKind]conversion.GroupKindConversion{
+			eventmeshv1beta1.Kind("EventMesh"): {
+				DefinitionName: "eventmeshes.operator.knative.dev",
+				HubVersion:     eventmeshv1beta1.SchemeGroupVersion.Version,
+				Zygotes: map[string]conversion.ConvertibleObject{
+					eventmeshv1alpha1.SchemeGroupVersion.Version: &eventmeshv1alpha1.EventMesh{},
+					eventmeshv1beta1.SchemeGroupVersion.Version:  &eventmeshv1beta1.EventMesh{},
+				},
+			},
+		},
+
+		// A function that infuses the context passed to ConvertTo/ConvertFrom/SetDefaults with custom metadata.
+		ctxFunc,
+	)
+}
+
 func main() {
 	// Set up a signal context with our webhook options
 	ctx := webhook.WithOptions(signals.NewContext(), webhook.Options{
@@ -93,5 +139,6 @@ func main() {
 		certificates.NewController,
 		NewValidationAdmissionController,
 		NewDefaultingAdmissionController,
+		NewConversionController,
 	)
 }
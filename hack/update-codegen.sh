@@ -30,12 +30,14 @@ group "Knative Codegen"
 # Knative Injection
 ${KNATIVE_CODEGEN_PKG}/hack/generate-knative.sh "injection" \
   knative.dev/eventmesh-operator/pkg/client knative.dev/eventmesh-operator/pkg/apis \
-  "operator:v1alpha1" \
+  "operator:v1alpha1,v1beta1" \
   --go-header-file ${REPO_ROOT_DIR}/hack/boilerplate/boilerplate.go.txt
 
 group "Update CRDs"
 # EventMesh CRD
-yq w -i config/core/resources/eventmesh.yaml 'spec.versions.(name==v1alpha1).schema.openAPIV3Schema' "$(go run cmd/schema/main.go dump EventMesh)"
+for version in v1alpha1 v1beta1; do
+  yq w -i config/core/resources/eventmesh.yaml "spec.versions.(name==${version}).schema.openAPIV3Schema" "$(SCHEMA_VERSION=${version} go run cmd/schema/main.go dump EventMesh)"
+done
 sed -i 's/ openAPIV3Schema: |-/ openAPIV3Schema:/' config/core/resources/eventmesh.yaml
 
 group "Update deps post-codegen"
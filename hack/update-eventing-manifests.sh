@@ -26,19 +26,31 @@ while IFS= read -r nightly; do
   target_dir=$(echo $nightly | jq -r '.directory')
   bucket=$(echo $nightly | jq -r '.bucket')
 
-  rm -rf "${REPO_ROOT_DIR}/${target_dir}"
-  mkdir -p "${REPO_ROOT_DIR}/${target_dir}"
+  # manifests are downloaded into a temporary directory first, as the version of the latest release is only known
+  # after the download
+  download_dir=$(mktemp -d)
+  chmod 755 "${download_dir}"
 
   for file in $(echo $nightly | jq -r '.files[]'); do
     if [[ -n "$RELEASE_VERSION" ]]; then
       curl -s "https://storage.googleapis.com/knative-releases/${bucket}/previous/v${RELEASE_VERSION}/${file}" \
         --create-dirs \
-        -o "${REPO_ROOT_DIR}/${target_dir}/${file}"
+        -o "${download_dir}/${file}"
     else
       curl -s "https://storage.googleapis.com/knative-releases/${bucket}/latest/${file}" \
         --create-dirs \
-        -o "${REPO_ROOT_DIR}/${target_dir}/${file}"
+        -o "${download_dir}/${file}"
     fi
   done
 
-done <<< "$(yq read --tojson "${REPO_ROOT_DIR}/hack/releases.yaml" '[*]')"
\ No newline at end of file
+  version="${RELEASE_VERSION}"
+  if [[ -z "$version" ]]; then
+    version=$(grep -h -m1 -o 'app.kubernetes.io/version: "[^"]*"' "${download_dir}"/* | head -n1 | cut -d'"' -f2)
+  fi
+
+  # every release gets its own directory, so that multiple releases can be bundled
+  rm -rf "${REPO_ROOT_DIR}/${target_dir}/${version}"
+  mkdir -p "${REPO_ROOT_DIR}/${target_dir}"
+  mv "${download_dir}" "${REPO_ROOT_DIR}/${target_dir}/${version}"
+
+done <<< "$(yq read --tojson "${REPO_ROOT_DIR}/hack/releases.yaml" '[*]')"
@@ -0,0 +1,19 @@
+package operator
+
+import "context"
+
+type availableVersionsKey struct{}
+
+// WithAvailableVersions notes on the context, which versions are bundled with the operator and can be used in
+// spec.version.
+func WithAvailableVersions(ctx context.Context, versions []string) context.Context {
+	return context.WithValue(ctx, availableVersionsKey{}, versions)
+}
+
+// GetAvailableVersions returns the versions bundled with the operator or nil if they were not set on the context.
+func GetAvailableVersions(ctx context.Context) []string {
+	if versions, ok := ctx.Value(availableVersionsKey{}).([]string); ok {
+		return versions
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+package operator
+
+const (
+	// GroupName is the name of the API group
+	GroupName = "operator.knative.dev"
+)
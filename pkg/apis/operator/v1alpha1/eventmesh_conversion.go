@@ -2,17 +2,468 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"knative.dev/pkg/apis"
+
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1beta1"
+)
+
+const (
+	// EventingFeaturesAnnotation holds the eventing feature flags, which have no typed field in v1beta1, to keep the
+	// conversion lossless.
+	EventingFeaturesAnnotation = "operator.knative.dev/v1alpha1-eventing-features"
+
+	// EventingKafkaBrokerFeaturesAnnotation holds the eventing-kafka-broker feature flags, which have no typed field
+	// in v1beta1, to keep the conversion lossless.
+	EventingKafkaBrokerFeaturesAnnotation = "operator.knative.dev/v1alpha1-eventing-kafka-broker-features"
 )
 
 // ConvertTo implements apis.Convertible
 func (em *EventMesh) ConvertTo(ctx context.Context, obj apis.Convertible) error {
-	return fmt.Errorf("v1alpha1 is the highest known version, got: %T", obj)
+	switch sink := obj.(type) {
+	case *v1beta1.EventMesh:
+		source := em.DeepCopy()
+
+		sink.ObjectMeta = source.ObjectMeta
+		if err := source.Spec.convertTo(sink); err != nil {
+			return err
+		}
+		source.Status.convertTo(&sink.Status)
+
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", sink)
+	}
 }
 
 // ConvertFrom implements apis.Convertible
 func (em *EventMesh) ConvertFrom(ctx context.Context, obj apis.Convertible) error {
-	return fmt.Errorf("v1alpha1 is the highest known version, got: %T", obj)
+	switch source := obj.(type) {
+	case *v1beta1.EventMesh:
+		source = source.DeepCopy()
+
+		em.ObjectMeta = source.ObjectMeta
+		if err := em.Spec.convertFrom(em, &source.Spec); err != nil {
+			return err
+		}
+		em.Status.convertFrom(&source.Status)
+
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", source)
+	}
+}
+
+func (spec *EventMeshSpec) convertTo(sink *v1beta1.EventMesh) error {
+	sink.Spec = v1beta1.EventMeshSpec{
+		Version:              spec.Version,
+		Kafka:                spec.Kafka.convertTo(),
+		DefaultBroker:        spec.DefaultBroker,
+		DefaultChannel:       spec.DefaultChannel,
+		Defaults:             spec.Defaults.convertTo(),
+		Overrides:            spec.Overrides.convertTo(),
+		Components:           spec.Components.convertTo(),
+		DeletionPolicy:       spec.DeletionPolicy,
+		Apply:                (*v1beta1.EventMeshSpecApply)(spec.Apply),
+		RevisionHistoryLimit: spec.RevisionHistoryLimit,
+		Scaling:              (*v1beta1.EventMeshSpecScaling)(spec.Scaling),
+		Observability:        (*v1beta1.EventMeshSpecObservability)(spec.Observability),
+	}
+
+	if spec.LogLevel != "" || spec.Logging != nil {
+		sink.Spec.Logging = &v1beta1.EventMeshSpecLogging{
+			Level: spec.LogLevel,
+		}
+		if spec.Logging != nil {
+			sink.Spec.Logging.Format = spec.Logging.Format
+			sink.Spec.Logging.Components = convertMap(spec.Logging.Components, func(c EventMeshSpecComponentLogging) v1beta1.EventMeshSpecComponentLogging {
+				return v1beta1.EventMeshSpecComponentLogging(c)
+			})
+		}
+	}
+
+	if spec.Features == nil {
+		return nil
+	}
+
+	sink.Spec.Features = &v1beta1.EventMeshSpecFeatures{}
+	delete(sink.Annotations, EventingFeaturesAnnotation)
+	delete(sink.Annotations, EventingKafkaBrokerFeaturesAnnotation)
+
+	if spec.Features.Eventing != nil {
+		sink.Spec.Features.Eventing = &v1beta1.EventingFeatures{}
+		unknown := sink.Spec.Features.Eventing.FromConfigMapData(spec.Features.Eventing)
+		if err := setFeaturesAnnotation(sink, EventingFeaturesAnnotation, unknown); err != nil {
+			return err
+		}
+	}
+
+	if spec.Features.EventingKafkaBroker != nil {
+		sink.Spec.Features.EventingKafkaBroker = &v1beta1.EventingKafkaBrokerFeatures{}
+		unknown := sink.Spec.Features.EventingKafkaBroker.FromConfigMapData(spec.Features.EventingKafkaBroker)
+		if err := setFeaturesAnnotation(sink, EventingKafkaBrokerFeaturesAnnotation, unknown); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (spec *EventMeshSpec) convertFrom(em *EventMesh, source *v1beta1.EventMeshSpec) error {
+	*spec = EventMeshSpec{
+		Version:              source.Version,
+		Kafka:                kafkaFrom(&source.Kafka),
+		DefaultBroker:        source.DefaultBroker,
+		DefaultChannel:       source.DefaultChannel,
+		Defaults:             defaultsFrom(source.Defaults),
+		Overrides:            overridesFrom(source.Overrides),
+		Components:           componentsFrom(source.Components),
+		DeletionPolicy:       source.DeletionPolicy,
+		Apply:                (*EventMeshSpecApply)(source.Apply),
+		RevisionHistoryLimit: source.RevisionHistoryLimit,
+		Scaling:              (*EventMeshSpecScaling)(source.Scaling),
+		Observability:        (*EventMeshSpecObservability)(source.Observability),
+	}
+
+	if source.Logging != nil {
+		spec.LogLevel = source.Logging.Level
+		if source.Logging.Format != "" || source.Logging.Components != nil {
+			spec.Logging = &EventMeshSpecLogging{
+				Format: source.Logging.Format,
+				Components: convertMap(source.Logging.Components, func(c v1beta1.EventMeshSpecComponentLogging) EventMeshSpecComponentLogging {
+					return EventMeshSpecComponentLogging(c)
+				}),
+			}
+		}
+	}
+
+	eventingUnknown, err := popFeaturesAnnotation(em, EventingFeaturesAnnotation)
+	if err != nil {
+		return err
+	}
+
+	ekbUnknown, err := popFeaturesAnnotation(em, EventingKafkaBrokerFeaturesAnnotation)
+	if err != nil {
+		return err
+	}
+
+	if source.Features == nil {
+		return nil
+	}
+
+	spec.Features = &EventMeshSpecFeatures{}
+	if source.Features.Eventing != nil {
+		spec.Features.Eventing = mergeFeatures(source.Features.Eventing.ToConfigMapData(), eventingUnknown)
+	}
+
+	if source.Features.EventingKafkaBroker != nil {
+		spec.Features.EventingKafkaBroker = mergeFeatures(source.Features.EventingKafkaBroker.ToConfigMapData(), ekbUnknown)
+	}
+
+	return nil
+}
+
+func (d *EventMeshSpecDefaults) convertTo() *v1beta1.EventMeshSpecDefaults {
+	if d == nil {
+		return nil
+	}
+
+	return &v1beta1.EventMeshSpecDefaults{
+		Namespaces: convertSlice(d.Namespaces, func(ns NamespaceDefaults) v1beta1.NamespaceDefaults {
+			return v1beta1.NamespaceDefaults{
+				Namespace:    ns.Namespace,
+				BrokerClass:  ns.BrokerClass,
+				BrokerConfig: (*v1beta1.BrokerConfigReference)(ns.BrokerConfig),
+				Channel:      ns.Channel,
+			}
+		}),
+	}
+}
+
+func defaultsFrom(source *v1beta1.EventMeshSpecDefaults) *EventMeshSpecDefaults {
+	if source == nil {
+		return nil
+	}
+
+	return &EventMeshSpecDefaults{
+		Namespaces: convertSlice(source.Namespaces, func(ns v1beta1.NamespaceDefaults) NamespaceDefaults {
+			return NamespaceDefaults{
+				Namespace:    ns.Namespace,
+				BrokerClass:  ns.BrokerClass,
+				BrokerConfig: (*BrokerConfigReference)(ns.BrokerConfig),
+				Channel:      ns.Channel,
+			}
+		}),
+	}
+}
+
+func (kafka *EventMeshSpecKafka) convertTo() v1beta1.EventMeshSpecKafka {
+	sink := v1beta1.EventMeshSpecKafka{
+		BootstrapServers:   kafka.BootstrapServers,
+		AuthSecretRef:      kafka.AuthSecretRef,
+		NumPartitions:      kafka.NumPartitions,
+		ReplicationFactor:  kafka.ReplicationFactor,
+		TopicConfigOptions: kafka.TopicConfigOptions,
+		Autoscaling:        (*v1beta1.KafkaAutoscalingSpec)(kafka.Autoscaling),
+	}
+
+	if kafka.NamespacedBroker != nil {
+		sink.NamespacedBroker = &v1beta1.NamespacedBrokerSpec{
+			Receiver:   (*v1beta1.DataPlaneSpec)(kafka.NamespacedBroker.Receiver),
+			Dispatcher: (*v1beta1.DataPlaneSpec)(kafka.NamespacedBroker.Dispatcher),
+		}
+	}
+
+	return sink
+}
+
+func kafkaFrom(source *v1beta1.EventMeshSpecKafka) EventMeshSpecKafka {
+	kafka := EventMeshSpecKafka{
+		BootstrapServers:   source.BootstrapServers,
+		AuthSecretRef:      source.AuthSecretRef,
+		NumPartitions:      source.NumPartitions,
+		ReplicationFactor:  source.ReplicationFactor,
+		TopicConfigOptions: source.TopicConfigOptions,
+		Autoscaling:        (*KafkaAutoscalingSpec)(source.Autoscaling),
+	}
+
+	if source.NamespacedBroker != nil {
+		kafka.NamespacedBroker = &NamespacedBrokerSpec{
+			Receiver:   (*DataPlaneSpec)(source.NamespacedBroker.Receiver),
+			Dispatcher: (*DataPlaneSpec)(source.NamespacedBroker.Dispatcher),
+		}
+	}
+
+	return kafka
+}
+
+func (o *EventMeshSpecOverrides) convertTo() *v1beta1.EventMeshSpecOverrides {
+	if o == nil {
+		return nil
+	}
+
+	overrides := &v1beta1.EventMeshSpecOverrides{
+		Config: o.Config,
+	}
+
+	if o.Workloads != nil {
+		overrides.Workloads = make(v1beta1.WorkloadOverrides, 0, len(o.Workloads))
+		for _, wlo := range o.Workloads {
+			overrides.Workloads = append(overrides.Workloads, v1beta1.WorkloadOverride{
+				Name:                      wlo.Name,
+				Labels:                    wlo.Labels,
+				Annotations:               wlo.Annotations,
+				Replicas:                  wlo.Replicas,
+				NodeSelector:              wlo.NodeSelector,
+				TopologySpreadConstraints: wlo.TopologySpreadConstraints,
+				Tolerations:               wlo.Tolerations,
+				Affinity:                  wlo.Affinity,
+				Resources: convertSlice(wlo.Resources, func(r ResourceRequirementsOverride) v1beta1.ResourceRequirementsOverride {
+					return v1beta1.ResourceRequirementsOverride(r)
+				}),
+				Env: convertSlice(wlo.Env, func(e EnvRequirementsOverride) v1beta1.EnvRequirementsOverride {
+					return v1beta1.EnvRequirementsOverride(e)
+				}),
+				ReadinessProbes: convertSlice(wlo.ReadinessProbes, func(p ProbesRequirementsOverride) v1beta1.ProbesRequirementsOverride {
+					return v1beta1.ProbesRequirementsOverride(p)
+				}),
+				LivenessProbes: convertSlice(wlo.LivenessProbes, func(p ProbesRequirementsOverride) v1beta1.ProbesRequirementsOverride {
+					return v1beta1.ProbesRequirementsOverride(p)
+				}),
+				HostNetwork: wlo.HostNetwork,
+			})
+		}
+	}
+
+	return overrides
+}
+
+func overridesFrom(source *v1beta1.EventMeshSpecOverrides) *EventMeshSpecOverrides {
+	if source == nil {
+		return nil
+	}
+
+	overrides := &EventMeshSpecOverrides{
+		Config: source.Config,
+	}
+
+	if source.Workloads != nil {
+		overrides.Workloads = make(WorkloadOverrides, 0, len(source.Workloads))
+		for _, wlo := range source.Workloads {
+			overrides.Workloads = append(overrides.Workloads, WorkloadOverride{
+				Name:                      wlo.Name,
+				Labels:                    wlo.Labels,
+				Annotations:               wlo.Annotations,
+				Replicas:                  wlo.Replicas,
+				NodeSelector:              wlo.NodeSelector,
+				TopologySpreadConstraints: wlo.TopologySpreadConstraints,
+				Tolerations:               wlo.Tolerations,
+				Affinity:                  wlo.Affinity,
+				Resources: convertSlice(wlo.Resources, func(r v1beta1.ResourceRequirementsOverride) ResourceRequirementsOverride {
+					return ResourceRequirementsOverride(r)
+				}),
+				Env: convertSlice(wlo.Env, func(e v1beta1.EnvRequirementsOverride) EnvRequirementsOverride { return EnvRequirementsOverride(e) }),
+				ReadinessProbes: convertSlice(wlo.ReadinessProbes, func(p v1beta1.ProbesRequirementsOverride) ProbesRequirementsOverride {
+					return ProbesRequirementsOverride(p)
+				}),
+				LivenessProbes: convertSlice(wlo.LivenessProbes, func(p v1beta1.ProbesRequirementsOverride) ProbesRequirementsOverride {
+					return ProbesRequirementsOverride(p)
+				}),
+				HostNetwork: wlo.HostNetwork,
+			})
+		}
+	}
+
+	return overrides
+}
+
+func (c *EventMeshSpecComponents) convertTo() *v1beta1.EventMeshSpecComponents {
+	if c == nil {
+		return nil
+	}
+
+	components := &v1beta1.EventMeshSpecComponents{
+		InMemoryChannel: (*v1beta1.ComponentSpec)(c.InMemoryChannel),
+		MTChannelBroker: (*v1beta1.ComponentSpec)(c.MTChannelBroker),
+		PingSource:      (*v1beta1.ComponentSpec)(c.PingSource),
+		JobSink:         (*v1beta1.ComponentSpec)(c.JobSink),
+	}
+
+	if c.Kafka != nil {
+		components.Kafka = &v1beta1.KafkaComponentsSpec{
+			Enabled: c.Kafka.Enabled,
+			Broker:  (*v1beta1.ComponentSpec)(c.Kafka.Broker),
+			Channel: (*v1beta1.ComponentSpec)(c.Kafka.Channel),
+			Sink:    (*v1beta1.ComponentSpec)(c.Kafka.Sink),
+			Source:  (*v1beta1.ComponentSpec)(c.Kafka.Source),
+		}
+	}
+
+	return components
+}
+
+func componentsFrom(source *v1beta1.EventMeshSpecComponents) *EventMeshSpecComponents {
+	if source == nil {
+		return nil
+	}
+
+	components := &EventMeshSpecComponents{
+		InMemoryChannel: (*ComponentSpec)(source.InMemoryChannel),
+		MTChannelBroker: (*ComponentSpec)(source.MTChannelBroker),
+		PingSource:      (*ComponentSpec)(source.PingSource),
+		JobSink:         (*ComponentSpec)(source.JobSink),
+	}
+
+	if source.Kafka != nil {
+		components.Kafka = &KafkaComponentsSpec{
+			Enabled: source.Kafka.Enabled,
+			Broker:  (*ComponentSpec)(source.Kafka.Broker),
+			Channel: (*ComponentSpec)(source.Kafka.Channel),
+			Sink:    (*ComponentSpec)(source.Kafka.Sink),
+			Source:  (*ComponentSpec)(source.Kafka.Source),
+		}
+	}
+
+	return components
+}
+
+func (status *EventMeshStatus) convertTo(sink *v1beta1.EventMeshStatus) {
+	*sink = v1beta1.EventMeshStatus{
+		Status:                     status.Status,
+		Version:                    status.Version,
+		EventingVersion:            status.EventingVersion,
+		EventingKafkaBrokerVersion: status.EventingKafkaBrokerVersion,
+		Components:                 convertSlice(status.Components, func(c ComponentStatus) v1beta1.ComponentStatus { return v1beta1.ComponentStatus(c) }),
+		DriftedResources:           convertSlice(status.DriftedResources, func(r DriftedResource) v1beta1.DriftedResource { return v1beta1.DriftedResource(r) }),
+		Revisions:                  convertSlice(status.Revisions, func(r Revision) v1beta1.Revision { return v1beta1.Revision(r) }),
+	}
+}
+
+func (status *EventMeshStatus) convertFrom(source *v1beta1.EventMeshStatus) {
+	*status = EventMeshStatus{
+		Status:                     source.Status,
+		Version:                    source.Version,
+		EventingVersion:            source.EventingVersion,
+		EventingKafkaBrokerVersion: source.EventingKafkaBrokerVersion,
+		Components:                 convertSlice(source.Components, func(c v1beta1.ComponentStatus) ComponentStatus { return ComponentStatus(c) }),
+		DriftedResources:           convertSlice(source.DriftedResources, func(r v1beta1.DriftedResource) DriftedResource { return DriftedResource(r) }),
+		Revisions:                  convertSlice(source.Revisions, func(r v1beta1.Revision) Revision { return Revision(r) }),
+	}
+}
+
+// setFeaturesAnnotation stores the given feature flags as JSON in the given annotation, if there are any
+func setFeaturesAnnotation(em *v1beta1.EventMesh, annotation string, features map[string]string) error {
+	if len(features) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(features)
+	if err != nil {
+		return fmt.Errorf("failed to marshal feature flags for annotation %s: %w", annotation, err)
+	}
+
+	if em.Annotations == nil {
+		em.Annotations = map[string]string{}
+	}
+	em.Annotations[annotation] = string(data)
+
+	return nil
+}
+
+// popFeaturesAnnotation returns the feature flags stored in the given annotation and removes the annotation
+func popFeaturesAnnotation(em *EventMesh, annotation string) (map[string]string, error) {
+	data, ok := em.Annotations[annotation]
+	if !ok {
+		return nil, nil
+	}
+
+	delete(em.Annotations, annotation)
+	if len(em.Annotations) == 0 {
+		em.Annotations = nil
+	}
+
+	var features map[string]string
+	if err := json.Unmarshal([]byte(data), &features); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal feature flags from annotation %s: %w", annotation, err)
+	}
+
+	return features, nil
+}
+
+func mergeFeatures(typed, unknown map[string]string) map[string]string {
+	for key, value := range unknown {
+		if _, ok := typed[key]; !ok {
+			typed[key] = value
+		}
+	}
+
+	return typed
+}
+
+func convertSlice[S, T any](in []S, convert func(S) T) []T {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]T, 0, len(in))
+	for _, s := range in {
+		out = append(out, convert(s))
+	}
+
+	return out
+}
+
+func convertMap[K comparable, S, T any](in map[K]S, convert func(S) T) map[K]T {
+	if in == nil {
+		return nil
+	}
+
+	out := make(map[K]T, len(in))
+	for key, s := range in {
+		out[key] = convert(s)
+	}
+
+	return out
 }
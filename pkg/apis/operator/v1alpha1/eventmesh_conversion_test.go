@@ -0,0 +1,224 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1beta1"
+)
+
+func TestEventMeshConversionRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		em   *EventMesh
+	}{
+		{
+			name: "empty",
+			em:   &EventMesh{},
+		},
+		{
+			name: "full",
+			em: &EventMesh{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "eventmesh",
+					Namespace:   "knative-eventing",
+					Annotations: map[string]string{"foo": "bar"},
+				},
+				Spec: EventMeshSpec{
+					Version: "1.19",
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers:   []string{"server-1", "server-2"},
+						AuthSecretRef:      &corev1.LocalObjectReference{Name: "kafka-auth"},
+						NumPartitions:      5,
+						ReplicationFactor:  2,
+						TopicConfigOptions: map[string]string{"retention.ms": "1000"},
+						NamespacedBroker: &NamespacedBrokerSpec{
+							Receiver: &DataPlaneSpec{
+								Replicas:     ptr.To[int32](2),
+								NodeSelector: map[string]string{"tenant": "a"},
+							},
+						},
+						Autoscaling: &KafkaAutoscalingSpec{
+							Enabled:  true,
+							MaxScale: ptr.To[int32](10),
+						},
+					},
+					LogLevel:       LogLevelDebug,
+					DefaultBroker:  BrokerClassKafka,
+					DefaultChannel: ChannelImplementationKafka,
+					Defaults: &EventMeshSpecDefaults{
+						Namespaces: []NamespaceDefaults{{
+							Namespace:    "tenant-a",
+							BrokerClass:  BrokerClassMTChannelBased,
+							BrokerConfig: &BrokerConfigReference{Name: "broker-config"},
+							Channel:      ChannelImplementationIMC,
+						}},
+					},
+					Features: &EventMeshSpecFeatures{
+						Eventing: map[string]string{
+							"transport-encryption": "strict",
+							"kreference-group":     "enabled",
+							"unknown-feature":      "enabled",
+							"empty-feature":        "",
+						},
+						EventingKafkaBroker: map[string]string{
+							"controller-autoscaler-keda": "enabled",
+							"some-future-flag":           "disabled",
+						},
+					},
+					Overrides: &EventMeshSpecOverrides{
+						Config: map[string]map[string]string{
+							"config-logging": {"loglevel.controller": "debug"},
+						},
+						Workloads: WorkloadOverrides{{
+							Name:     "eventing-controller",
+							Labels:   map[string]string{"a": "b"},
+							Replicas: ptr.To[int32](2),
+							Resources: []ResourceRequirementsOverride{{
+								Container: "eventing-controller",
+								ResourceRequirements: corev1.ResourceRequirements{
+									Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+								},
+							}},
+							Env: []EnvRequirementsOverride{{
+								Container: "eventing-controller",
+								EnvVars:   []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+							}},
+							ReadinessProbes: []ProbesRequirementsOverride{{
+								Container:      "eventing-controller",
+								TimeoutSeconds: 5,
+							}},
+							HostNetwork: ptr.To(true),
+						}},
+					},
+					Components: &EventMeshSpecComponents{
+						InMemoryChannel: &ComponentSpec{Enabled: ptr.To(false)},
+						PingSource:      &ComponentSpec{},
+						Kafka: &KafkaComponentsSpec{
+							Enabled: ptr.To(true),
+							Sink:    &ComponentSpec{Enabled: ptr.To(false)},
+						},
+					},
+					DeletionPolicy: DeletionPolicyBlockIfInUse,
+					Apply: &EventMeshSpecApply{
+						ForceConflicts:       ptr.To(false),
+						ForceConflictsByKind: map[string]bool{"ConfigMap": true},
+					},
+					RevisionHistoryLimit: ptr.To[int32](5),
+					Scaling: &EventMeshSpecScaling{
+						ObjectsPerReplica: ptr.To[int32](50),
+						MinReplicas:       ptr.To[int32](1),
+						MaxReplicas:       ptr.To[int32](5),
+						ScaleDownDelay:    &metav1.Duration{Duration: 5 * time.Minute},
+					},
+					Observability: &EventMeshSpecObservability{
+						MetricsProtocol:     ObservabilityProtocolGRPC,
+						MetricsEndpoint:     "http://otel-collector.observability:4317",
+						RequestMetrics:      ptr.To(true),
+						TracingProtocol:     ObservabilityProtocolHTTPProtobuf,
+						TracingEndpoint:     "http://otel-collector.observability:4318/v1/traces",
+						TracingSamplingRate: "0.1",
+					},
+					Logging: &EventMeshSpecLogging{
+						Format: LogFormatConsole,
+						Components: map[string]EventMeshSpecComponentLogging{
+							"controller": {Level: LogLevelDebug},
+							"kafka-broker-dispatcher": {
+								Level:   LogLevelTrace,
+								Loggers: map[string]string{"org.apache.kafka": LogLevelWarn},
+							},
+						},
+					},
+				},
+				Status: EventMeshStatus{
+					Status: duckv1.Status{
+						ObservedGeneration: 3,
+						Conditions: duckv1.Conditions{{
+							Type:   apis.ConditionReady,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+					Version:                    "1.19.2",
+					EventingVersion:            "1.19.2",
+					EventingKafkaBrokerVersion: "1.19.3",
+					Components: []ComponentStatus{{
+						Name:      "eventing-core",
+						Resources: 42,
+					}},
+					DriftedResources: []DriftedResource{{
+						Kind:      "ConfigMap",
+						Namespace: "knative-eventing",
+						Name:      "config-features",
+					}},
+					Revisions: []Revision{{
+						Revision:  2,
+						SpecHash:  "0123456789abcdef",
+						Version:   "1.19.2",
+						Timestamp: metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+						Outcome:   RevisionOutcomeAvailable,
+					}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			hub := &v1beta1.EventMesh{}
+			if err := test.em.ConvertTo(ctx, hub); err != nil {
+				t.Fatalf("ConvertTo() = %v", err)
+			}
+
+			got := &EventMesh{}
+			if err := got.ConvertFrom(ctx, hub); err != nil {
+				t.Fatalf("ConvertFrom() = %v", err)
+			}
+
+			if diff := cmp.Diff(test.em, got); diff != "" {
+				t.Errorf("roundtrip (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestEventMeshConversionTyped(t *testing.T) {
+	em := &EventMesh{
+		Spec: EventMeshSpec{
+			LogLevel: LogLevelWarn,
+			Features: &EventMeshSpecFeatures{
+				Eventing: map[string]string{
+					"authentication-oidc": "enabled",
+					"unknown-feature":     "enabled",
+				},
+			},
+		},
+	}
+
+	hub := &v1beta1.EventMesh{}
+	if err := em.ConvertTo(context.Background(), hub); err != nil {
+		t.Fatalf("ConvertTo() = %v", err)
+	}
+
+	if diff := cmp.Diff(&v1beta1.EventMeshSpecLogging{Level: LogLevelWarn}, hub.Spec.Logging); diff != "" {
+		t.Errorf("logging (-want, +got) = %v", diff)
+	}
+
+	if diff := cmp.Diff(&v1beta1.EventingFeatures{AuthenticationOIDC: v1beta1.FeatureEnabled}, hub.Spec.Features.Eventing); diff != "" {
+		t.Errorf("eventing features (-want, +got) = %v", diff)
+	}
+
+	if got, want := hub.Annotations[EventingFeaturesAnnotation], `{"unknown-feature":"enabled"}`; got != want {
+		t.Errorf("annotation %s = %q, want %q", EventingFeaturesAnnotation, got, want)
+	}
+}
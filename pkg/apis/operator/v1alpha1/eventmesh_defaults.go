@@ -15,10 +15,30 @@ func (em *EventMesh) SetDefaults(ctx context.Context) {
 func (spec *EventMeshSpec) SetDefaults(ctx context.Context) {
 	spec.Kafka.SetDefaults(ctx)
 
+	if spec.DeletionPolicy == "" {
+		spec.DeletionPolicy = DeletionPolicyKeepCRDs
+	}
+
 	spec.LogLevel = strings.ToLower(spec.LogLevel)
 	if spec.LogLevel == "" {
 		spec.LogLevel = "info"
 	}
+
+	if spec.Logging != nil {
+		spec.Logging.SetDefaults(ctx)
+	}
+}
+
+func (l *EventMeshSpecLogging) SetDefaults(ctx context.Context) {
+	l.Format = strings.ToLower(l.Format)
+
+	for name, component := range l.Components {
+		component.Level = strings.ToLower(component.Level)
+		for logger, level := range component.Loggers {
+			component.Loggers[logger] = strings.ToLower(level)
+		}
+		l.Components[name] = component
+	}
 }
 
 func (kafka *EventMeshSpecKafka) SetDefaults(ctx context.Context) {
@@ -6,9 +6,20 @@ import (
 	"knative.dev/pkg/apis"
 )
 
-var EventMeshCondSet = apis.NewLivingConditionSet(EventMeshConditionInstallSucceeded, EventMeshConditionDeploymentsAvailable)
+var EventMeshCondSet = apis.NewLivingConditionSet(EventMeshConditionPrimary, EventMeshConditionInstallSucceeded, EventMeshConditionDeploymentsAvailable, EventMeshConditionAuthConfigured, EventMeshConditionPostInstallSucceeded, EventMeshConditionDeletionAllowed)
+
+// DuplicateReason is the reason of the Primary condition of EventMeshes, which are not the primary EventMesh of the
+// cluster
+const DuplicateReason = "Duplicate"
+
+// PostInstallRunningReason is the reason of the PostInstallSucceeded condition while the post-install jobs are running
+const PostInstallRunningReason = "PostInstallRunning"
 
 const (
+	// EventMeshConditionPrimary is a Condition indicating whether the EventMesh is the primary EventMesh of the
+	// cluster, which installs the components. Other EventMeshes are marked false with the reason Duplicate.
+	EventMeshConditionPrimary apis.ConditionType = "Primary"
+
 	// EventMeshConditionInstallSucceeded is a Condition indicating that the installation of all components
 	// has been successful.
 	EventMeshConditionInstallSucceeded apis.ConditionType = "InstallSucceeded"
@@ -16,6 +27,22 @@ const (
 	// EventMeshConditionDeploymentsAvailable is a Condition indicating whether the Deployments of
 	// the components have come up successfully.
 	EventMeshConditionDeploymentsAvailable apis.ConditionType = "DeploymentsAvailable"
+
+	// EventMeshConditionAuthConfigured is a Condition indicating whether the authentication against the
+	// Kafka cluster is configured correctly.
+	EventMeshConditionAuthConfigured apis.ConditionType = "AuthConfigured"
+
+	// EventMeshConditionPostInstallSucceeded is a Condition indicating whether the post-install jobs of an upgrade
+	// (e.g. the storage version migration) have completed successfully.
+	EventMeshConditionPostInstallSucceeded apis.ConditionType = "PostInstallSucceeded"
+
+	// EventMeshConditionDeletionAllowed is a Condition indicating whether the deletion of the EventMesh can proceed.
+	// It is false, while the deletion is blocked by resources, which are still in use.
+	EventMeshConditionDeletionAllowed apis.ConditionType = "DeletionAllowed"
+
+	// EventMeshConditionFieldsOwned is a Condition indicating whether the operator owns all fields of the applied
+	// manifests. Conflicts with other field managers don't affect the readiness of the EventMesh.
+	EventMeshConditionFieldsOwned apis.ConditionType = "FieldsOwned"
 )
 
 // GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
@@ -43,6 +70,19 @@ func (em *EventMeshStatus) InitializeConditions() {
 	EventMeshCondSet.Manage(em).InitializeConditions()
 }
 
+// MarkPrimary marks the Primary status as true.
+func (em *EventMeshStatus) MarkPrimary() {
+	EventMeshCondSet.Manage(em).MarkTrue(EventMeshConditionPrimary)
+}
+
+// MarkDuplicate marks the Primary status as false and calls out the primary EventMesh of the cluster.
+func (em *EventMeshStatus) MarkDuplicate(primary string) {
+	EventMeshCondSet.Manage(em).MarkFalse(
+		EventMeshConditionPrimary,
+		DuplicateReason,
+		"Only one EventMesh is supported per cluster and EventMesh %s exists already", primary)
+}
+
 // MarkInstallSucceeded marks the InstallSucceeded status as true.
 func (em *EventMeshStatus) MarkInstallSucceeded() {
 	EventMeshCondSet.Manage(em).MarkTrue(EventMeshConditionInstallSucceeded)
@@ -54,6 +94,17 @@ func (em *EventMeshStatus) MarkInstallFailed(reason, messageFormat string, messa
 	EventMeshCondSet.Manage(em).MarkFalse(EventMeshConditionInstallSucceeded, reason, messageFormat, messageA...)
 }
 
+// MarkDeletionAllowed marks the DeletionAllowed status as true.
+func (em *EventMeshStatus) MarkDeletionAllowed() {
+	EventMeshCondSet.Manage(em).MarkTrue(EventMeshConditionDeletionAllowed)
+}
+
+// MarkDeletionBlocked marks the DeletionAllowed status as false, as the deletion is blocked by resources, which are
+// still in use.
+func (em *EventMeshStatus) MarkDeletionBlocked(messageFormat string, messageA ...interface{}) {
+	EventMeshCondSet.Manage(em).MarkFalse(EventMeshConditionDeletionAllowed, "DeletionBlocked", messageFormat, messageA...)
+}
+
 // MarkDeploymentsAvailable marks the DeploymentsAvailable status as true.
 func (em *EventMeshStatus) MarkDeploymentsAvailable() {
 	EventMeshCondSet.Manage(em).MarkTrue(EventMeshConditionDeploymentsAvailable)
@@ -67,3 +118,80 @@ func (em *EventMeshStatus) MarkDeploymentsNotReady(deployments []string) {
 		"NotReady",
 		"Waiting on deployments: %s", strings.Join(deployments, ", "))
 }
+
+// MarkPostInstallSucceeded marks the PostInstallSucceeded status as true.
+func (em *EventMeshStatus) MarkPostInstallSucceeded() {
+	EventMeshCondSet.Manage(em).MarkTrue(EventMeshConditionPostInstallSucceeded)
+}
+
+// MarkPostInstallNotRequired marks the PostInstallSucceeded status as true and calls out
+// that no post-install jobs are required, as no upgrade is ongoing.
+func (em *EventMeshStatus) MarkPostInstallNotRequired() {
+	EventMeshCondSet.Manage(em).MarkTrueWithReason(
+		EventMeshConditionPostInstallSucceeded,
+		"PostInstallNotRequired",
+		"No upgrade with post-install jobs ongoing")
+}
+
+// MarkPostInstallRunning marks the PostInstallSucceeded status as unknown and calls out
+// it's waiting for the given jobs.
+func (em *EventMeshStatus) MarkPostInstallRunning(jobs []string) {
+	EventMeshCondSet.Manage(em).MarkUnknown(
+		EventMeshConditionPostInstallSucceeded,
+		PostInstallRunningReason,
+		"Waiting on jobs: %s", strings.Join(jobs, ", "))
+}
+
+// MarkPostInstallFailed marks the PostInstallSucceeded status as false with the given
+// reason and message.
+func (em *EventMeshStatus) MarkPostInstallFailed(reason, messageFormat string, messageA ...interface{}) {
+	EventMeshCondSet.Manage(em).MarkFalse(EventMeshConditionPostInstallSucceeded, reason, messageFormat, messageA...)
+}
+
+// IsPostInstallPending returns true while the post-install jobs of an upgrade are running or failed.
+func (em *EventMeshStatus) IsPostInstallPending() bool {
+	c := em.GetCondition(EventMeshConditionPostInstallSucceeded)
+	return c != nil && (c.IsFalse() || c.Reason == PostInstallRunningReason)
+}
+
+// MarkAuthConfigured marks the AuthConfigured status as true.
+func (em *EventMeshStatus) MarkAuthConfigured() {
+	EventMeshCondSet.Manage(em).MarkTrue(EventMeshConditionAuthConfigured)
+}
+
+// MarkAuthNotRequired marks the AuthConfigured status as true and calls out
+// that no authentication secret is referenced.
+func (em *EventMeshStatus) MarkAuthNotRequired() {
+	EventMeshCondSet.Manage(em).MarkTrueWithReason(
+		EventMeshConditionAuthConfigured,
+		"AuthNotRequired",
+		"No Kafka authentication secret referenced")
+}
+
+// MarkAuthFailed marks the AuthConfigured status as false with the given
+// reason and message.
+func (em *EventMeshStatus) MarkAuthFailed(reason, messageFormat string, messageA ...interface{}) {
+	EventMeshCondSet.Manage(em).MarkFalse(EventMeshConditionAuthConfigured, reason, messageFormat, messageA...)
+}
+
+// MarkFieldsOwned marks the FieldsOwned status as true.
+func (em *EventMeshStatus) MarkFieldsOwned() {
+	EventMeshCondSet.Manage(em).MarkTrue(EventMeshConditionFieldsOwned)
+}
+
+// MarkFieldOwnershipTakenOver marks the FieldsOwned status as true and calls out the resources, of which fields
+// were taken over from other managers.
+func (em *EventMeshStatus) MarkFieldOwnershipTakenOver(resources []string) {
+	EventMeshCondSet.Manage(em).MarkTrueWithReason(
+		EventMeshConditionFieldsOwned,
+		"FieldOwnershipTakenOver",
+		"Fields taken over from other managers: %s", strings.Join(resources, "; "))
+}
+
+// MarkFieldOwnershipConflicts marks the FieldsOwned status as false and calls out the conflicting resources.
+func (em *EventMeshStatus) MarkFieldOwnershipConflicts(resources []string) {
+	EventMeshCondSet.Manage(em).MarkFalse(
+		EventMeshConditionFieldsOwned,
+		"FieldOwnershipConflict",
+		"Fields owned by other managers: %s", strings.Join(resources, "; "))
+}
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"knative.dev/eventmesh-operator/pkg/apis/operator"
 	"knative.dev/pkg/apis"
 )
 
@@ -48,6 +52,85 @@ func TestEventMeshSpecValidationLogLevel(t *testing.T) {
 				return apis.ErrInvalidValue("ultra", "spec.logLevel", fmt.Sprintf("must be one of %q", strings.Join(LogLevels, ", ")))
 			}(),
 		},
+		{
+			name: "valid, per component logging",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					LogLevel: LogLevelInfo,
+					Logging: &EventMeshSpecLogging{
+						Format: LogFormatConsole,
+						Components: map[string]EventMeshSpecComponentLogging{
+							"controller": {Level: LogLevelDebug},
+							"kafka-broker-dispatcher": {
+								Level:   LogLevelTrace,
+								Loggers: map[string]string{"org.apache.kafka": LogLevelWarn},
+							},
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+		{
+			name: "invalid, logging format and per component levels",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					Logging: &EventMeshSpecLogging{
+						Format: "xml",
+						Components: map[string]EventMeshSpecComponentLogging{
+							"controller": {
+								Level:   "ultra",
+								Loggers: map[string]string{"knative.dev": LogLevelDebug},
+							},
+							"kafka-broker-dispatcher": {
+								Loggers: map[string]string{"org.apache.kafka": "ultra"},
+							},
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue("xml", "spec.logging.format", `must be one of "json, console"`).
+					Also(apis.ErrInvalidValue("ultra", "spec.logging.components[controller].level", fmt.Sprintf("must be one of %q", strings.Join(LogLevels, ", ")))).
+					Also(apis.ErrDisallowedFields("spec.logging.components[controller].loggers")).
+					Also(apis.ErrInvalidValue("ultra", "spec.logging.components[kafka-broker-dispatcher].loggers[org.apache.kafka]", fmt.Sprintf("must be one of %q", strings.Join(LogLevels, ", "))))
+			}(),
+		},
+		{
+			name: "invalid, unknown component",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					Logging: &EventMeshSpecLogging{
+						Components: map[string]EventMeshSpecComponentLogging{
+							"mt_broker_filter":      {Level: LogLevelDebug},
+							"eventing-controller":   {Level: LogLevelDebug},
+							"kafka-sink-dispatcher": {Loggers: map[string]string{"org.apache.kafka": LogLevelDebug}},
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidKeyName("eventing-controller", "spec.logging.components", "must be a Go component or a Kafka data plane").
+					Also(apis.ErrInvalidKeyName("kafka-sink-dispatcher", "spec.logging.components", "must be a Go component or a Kafka data plane"))
+			}(),
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -175,3 +258,617 @@ func TestEventMeshSpecValidationChannelImplementation(t *testing.T) {
 		})
 	}
 }
+
+func TestEventMeshSpecValidationComponents(t *testing.T) {
+	disabled := &ComponentSpec{Enabled: ptr.To(false)}
+
+	tests := []struct {
+		name string
+		em   *EventMesh
+		want *apis.FieldError
+	}{
+		{
+			name: "invalid, bootstrap servers missing",
+			em: &EventMesh{
+				Spec: EventMeshSpec{},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrMissingField("spec.kafka.bootstrapServers")
+			}(),
+		},
+		{
+			name: "valid, Kafka disabled without bootstrap servers",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Components: &EventMeshSpecComponents{
+						Kafka: &KafkaComponentsSpec{
+							Enabled: ptr.To(false),
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+		{
+			name: "valid, only Kafka sink and source enabled without bootstrap servers",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Components: &EventMeshSpecComponents{
+						Kafka: &KafkaComponentsSpec{
+							Broker:  disabled,
+							Channel: disabled,
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+		{
+			name: "invalid, default broker class disabled",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					DefaultBroker: BrokerClassKafka,
+					Components: &EventMeshSpecComponents{
+						Kafka: &KafkaComponentsSpec{
+							Enabled: ptr.To(false),
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrGeneric("broker class Kafka is disabled in components", "spec.defaultBroker")
+			}(),
+		},
+		{
+			name: "invalid, scaling min replicas greater than max replicas",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					Scaling: &EventMeshSpecScaling{
+						ObjectsPerReplica: ptr.To[int32](0),
+						MinReplicas:       ptr.To[int32](3),
+						MaxReplicas:       ptr.To[int32](2),
+						ScaleDownDelay:    &metav1.Duration{Duration: -time.Minute},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue(0, "spec.scaling.objectsPerReplica").
+					Also(apis.ErrGeneric("minReplicas must not be greater than maxReplicas", "spec.scaling.minReplicas", "spec.scaling.maxReplicas")).
+					Also(apis.ErrInvalidValue("-1m0s", "spec.scaling.scaleDownDelay", "must not be negative"))
+			}(),
+		},
+		{
+			name: "valid, observability",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					Observability: &EventMeshSpecObservability{
+						MetricsProtocol:     ObservabilityProtocolPrometheus,
+						MetricsEndpoint:     "0.0.0.0:9090",
+						RequestMetrics:      ptr.To(true),
+						TracingProtocol:     ObservabilityProtocolGRPC,
+						TracingEndpoint:     "https://otel-collector.observability:4317",
+						TracingSamplingRate: "0.05",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid, observability endpoints and sampling rate",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					Observability: &EventMeshSpecObservability{
+						MetricsProtocol:     ObservabilityProtocolHTTPProtobuf,
+						MetricsEndpoint:     "otel-collector:4318",
+						TracingEndpoint:     "http://otel-collector:4318/v1/traces",
+						TracingSamplingRate: "1.5",
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue("otel-collector:4318", "spec.observability.metricsEndpoint", "must be an absolute http or https URL").
+					Also(apis.ErrGeneric("tracingEndpoint must not be set without a tracingProtocol exporting to it", "spec.observability.tracingEndpoint")).
+					Also(apis.ErrInvalidValue("1.5", "spec.observability.tracingSamplingRate", "must be a number between 0 and 1"))
+			}(),
+		},
+		{
+			name: "invalid, observability protocols",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					Observability: &EventMeshSpecObservability{
+						MetricsProtocol: ObservabilityProtocolStdout,
+						TracingProtocol: ObservabilityProtocolHTTPProtobuf,
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue("stdout", "spec.observability.metricsProtocol", `must be one of "none, prometheus, http/protobuf, grpc"`).
+					Also(apis.ErrMissingField("spec.observability.tracingEndpoint"))
+			}(),
+		},
+		{
+			name: "invalid, unknown deletion policy",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					DeletionPolicy: "Orphan",
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue("Orphan", "spec.deletionPolicy", fmt.Sprintf("must be one of %q", strings.Join(DeletionPolicies, ", ")))
+			}(),
+		},
+		{
+			name: "invalid, default channel implementation disabled",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					DefaultChannel: ChannelImplementationIMC,
+					Components: &EventMeshSpecComponents{
+						InMemoryChannel: disabled,
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrGeneric("channel implementation InMemoryChannel is disabled in components", "spec.defaultChannel")
+			}(),
+		},
+		{
+			name: "invalid, MT channel based broker without channel implementation",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Components: &EventMeshSpecComponents{
+						InMemoryChannel: disabled,
+						Kafka: &KafkaComponentsSpec{
+							Enabled: ptr.To(false),
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrGeneric("the MTChannelBasedBroker requires at least one enabled channel implementation", "spec.components")
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := apis.WithinCreate(context.TODO())
+			got := test.em.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: Validate EventMeshSpec (-want, +got) = %v", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestEventMeshSpecValidationRevisionHistoryLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		em   *EventMesh
+		want *apis.FieldError
+	}{
+		{
+			name: "valid, revision history disabled",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					RevisionHistoryLimit: ptr.To[int32](0),
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+		{
+			name: "invalid, negative revision history limit",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					RevisionHistoryLimit: ptr.To[int32](-1),
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue(-1, "spec.revisionHistoryLimit", "must not be negative")
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := apis.WithinCreate(context.TODO())
+			got := test.em.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: Validate EventMeshSpec (-want, +got) = %v", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestEventMeshSpecValidationNamespaceDefaults(t *testing.T) {
+	disabled := &ComponentSpec{Enabled: ptr.To(false)}
+
+	tests := []struct {
+		name string
+		em   *EventMesh
+		want *apis.FieldError
+	}{
+		{
+			name: "valid namespace defaults",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					Defaults: &EventMeshSpecDefaults{
+						Namespaces: []NamespaceDefaults{
+							{
+								Namespace:   "tenant-a",
+								BrokerClass: BrokerClassMTChannelBased,
+								Channel:     ChannelImplementationIMC,
+							},
+							{
+								Namespace:    "tenant-b",
+								BrokerConfig: &BrokerConfigReference{Name: "tenant-b-config"},
+							},
+							{
+								Namespace: "tenant-c",
+								Channel:   ChannelImplementationKafka,
+							},
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+		{
+			name: "invalid, namespace defaults without namespace, duplicate namespace and broker config name",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					Defaults: &EventMeshSpecDefaults{
+						Namespaces: []NamespaceDefaults{
+							{
+								BrokerClass: BrokerClassKafka,
+							},
+							{
+								Namespace: "tenant-a",
+								Channel:   ChannelImplementationKafka,
+							},
+							{
+								Namespace:    "tenant-a",
+								BrokerConfig: &BrokerConfigReference{Namespace: "tenant-a"},
+							},
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrMissingField("spec.defaults.namespaces[0].namespace").
+					Also(apis.ErrGeneric("duplicate defaults for namespace tenant-a", "spec.defaults.namespaces[2].namespace")).
+					Also(apis.ErrMissingField("spec.defaults.namespaces[2].brokerConfig.name"))
+			}(),
+		},
+		{
+			name: "invalid, namespace defaults with unknown broker class and disabled channel",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					Components: &EventMeshSpecComponents{
+						InMemoryChannel: disabled,
+					},
+					Defaults: &EventMeshSpecDefaults{
+						Namespaces: []NamespaceDefaults{{
+							Namespace:   "tenant-a",
+							BrokerClass: "foobar",
+							Channel:     ChannelImplementationIMC,
+						}},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue("foobar", "spec.defaults.namespaces[0].brokerClass", fmt.Sprintf("must be one of %q", strings.Join(BrokerClasses, ", "))).
+					Also(apis.ErrGeneric("channel implementation InMemoryChannel is disabled in components", "spec.defaults.namespaces[0].channel"))
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := apis.WithinCreate(context.TODO())
+			got := test.em.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: Validate EventMeshSpec (-want, +got) = %v", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestEventMeshSpecValidationAutoscaling(t *testing.T) {
+	tests := []struct {
+		name string
+		em   *EventMesh
+		want *apis.FieldError
+	}{
+		{
+			name: "valid autoscaling",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+						Autoscaling: &KafkaAutoscalingSpec{
+							Enabled:         true,
+							MinScale:        ptr.To[int32](0),
+							MaxScale:        ptr.To[int32](10),
+							PollingInterval: ptr.To[int32](10),
+							CooldownPeriod:  ptr.To[int32](0),
+							LagThreshold:    ptr.To[int32](100),
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+		{
+			name: "invalid, autoscaling values out of range",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+						Autoscaling: &KafkaAutoscalingSpec{
+							Enabled:         true,
+							MinScale:        ptr.To[int32](-1),
+							MaxScale:        ptr.To[int32](0),
+							PollingInterval: ptr.To[int32](0),
+							CooldownPeriod:  ptr.To[int32](-1),
+							LagThreshold:    ptr.To[int32](0),
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue(-1, "spec.kafka.autoscaling.minScale").
+					Also(apis.ErrInvalidValue(0, "spec.kafka.autoscaling.maxScale")).
+					Also(apis.ErrInvalidValue(0, "spec.kafka.autoscaling.pollingInterval")).
+					Also(apis.ErrInvalidValue(-1, "spec.kafka.autoscaling.cooldownPeriod")).
+					Also(apis.ErrInvalidValue(0, "spec.kafka.autoscaling.lagThreshold"))
+			}(),
+		},
+		{
+			name: "invalid, autoscaling min scale greater than max scale",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+						Autoscaling: &KafkaAutoscalingSpec{
+							Enabled:  true,
+							MinScale: ptr.To[int32](5),
+							MaxScale: ptr.To[int32](2),
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrGeneric("minScale must not be greater than maxScale", "spec.kafka.autoscaling.minScale", "spec.kafka.autoscaling.maxScale")
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := apis.WithinCreate(context.TODO())
+			got := test.em.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: Validate EventMeshSpec (-want, +got) = %v", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestEventMeshSpecValidationNamespacedBroker(t *testing.T) {
+	disabled := &ComponentSpec{Enabled: ptr.To(false)}
+
+	tests := []struct {
+		name string
+		em   *EventMesh
+		want *apis.FieldError
+	}{
+		{
+			name: "valid namespaced broker data plane",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+						NamespacedBroker: &NamespacedBrokerSpec{
+							Receiver: &DataPlaneSpec{
+								Replicas: ptr.To[int32](2),
+							},
+							Dispatcher: &DataPlaneSpec{
+								Replicas: ptr.To[int32](0),
+							},
+						},
+					},
+					DefaultBroker: BrokerClassKafkaNamespaced,
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+		{
+			name: "invalid, namespaced broker class disabled with the Kafka broker",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					DefaultBroker: BrokerClassKafkaNamespaced,
+					Components: &EventMeshSpecComponents{
+						Kafka: &KafkaComponentsSpec{
+							Broker:  disabled,
+							Channel: disabled,
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrGeneric("broker class KafkaNamespaced is disabled in components", "spec.defaultBroker")
+			}(),
+		},
+		{
+			name: "invalid, negative namespaced broker replicas",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+						NamespacedBroker: &NamespacedBrokerSpec{
+							Dispatcher: &DataPlaneSpec{
+								Replicas: ptr.To[int32](-1),
+							},
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue(-1, "spec.kafka.namespacedBroker.dispatcher.replicas")
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := apis.WithinCreate(context.TODO())
+			got := test.em.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: Validate EventMeshSpec (-want, +got) = %v", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestEventMeshSpecValidationVersion(t *testing.T) {
+	versions := []string{"1.19", "1.19.2"}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		em   *EventMesh
+		want *apis.FieldError
+	}{
+		{
+			name: "valid version",
+			ctx:  operator.WithAvailableVersions(context.TODO(), versions),
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Version: "1.19",
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+		{
+			name: "invalid, unknown version",
+			ctx:  operator.WithAvailableVersions(context.TODO(), versions),
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Version: "1.18",
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue("1.18", "spec.version", fmt.Sprintf("must be one of %q", strings.Join(versions, ", ")))
+			}(),
+		},
+		{
+			name: "valid, versions not known",
+			ctx:  context.TODO(),
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Version: "1.18",
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := apis.WithinCreate(test.ctx)
+			got := test.em.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: Validate EventMeshSpec (-want, +got) = %v", test.name, diff)
+			}
+		})
+	}
+}
@@ -23,9 +23,131 @@ package v1alpha1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerConfigReference) DeepCopyInto(out *BrokerConfigReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerConfigReference.
+func (in *BrokerConfigReference) DeepCopy() *BrokerConfigReference {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerConfigReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSpec.
+func (in *ComponentSpec) DeepCopy() *ComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataPlaneSpec) DeepCopyInto(out *DataPlaneSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneSpec.
+func (in *DataPlaneSpec) DeepCopy() *DataPlaneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataPlaneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftedResource) DeepCopyInto(out *DriftedResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftedResource.
+func (in *DriftedResource) DeepCopy() *DriftedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvRequirementsOverride) DeepCopyInto(out *EnvRequirementsOverride) {
 	*out = *in
@@ -114,6 +236,16 @@ func (in *EventMeshList) DeepCopyObject() runtime.Object {
 func (in *EventMeshSpec) DeepCopyInto(out *EventMeshSpec) {
 	*out = *in
 	in.Kafka.DeepCopyInto(&out.Kafka)
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(EventMeshSpecLogging)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(EventMeshSpecDefaults)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Features != nil {
 		in, out := &in.Features, &out.Features
 		*out = new(EventMeshSpecFeatures)
@@ -124,6 +256,31 @@ func (in *EventMeshSpec) DeepCopyInto(out *EventMeshSpec) {
 		*out = new(EventMeshSpecOverrides)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = new(EventMeshSpecComponents)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Apply != nil {
+		in, out := &in.Apply, &out.Apply
+		*out = new(EventMeshSpecApply)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Scaling != nil {
+		in, out := &in.Scaling, &out.Scaling
+		*out = new(EventMeshSpecScaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Observability != nil {
+		in, out := &in.Observability, &out.Observability
+		*out = new(EventMeshSpecObservability)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -137,6 +294,121 @@ func (in *EventMeshSpec) DeepCopy() *EventMeshSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventMeshSpecApply) DeepCopyInto(out *EventMeshSpecApply) {
+	*out = *in
+	if in.ForceConflicts != nil {
+		in, out := &in.ForceConflicts, &out.ForceConflicts
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ForceConflictsByKind != nil {
+		in, out := &in.ForceConflictsByKind, &out.ForceConflictsByKind
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventMeshSpecApply.
+func (in *EventMeshSpecApply) DeepCopy() *EventMeshSpecApply {
+	if in == nil {
+		return nil
+	}
+	out := new(EventMeshSpecApply)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventMeshSpecComponentLogging) DeepCopyInto(out *EventMeshSpecComponentLogging) {
+	*out = *in
+	if in.Loggers != nil {
+		in, out := &in.Loggers, &out.Loggers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventMeshSpecComponentLogging.
+func (in *EventMeshSpecComponentLogging) DeepCopy() *EventMeshSpecComponentLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(EventMeshSpecComponentLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventMeshSpecComponents) DeepCopyInto(out *EventMeshSpecComponents) {
+	*out = *in
+	if in.InMemoryChannel != nil {
+		in, out := &in.InMemoryChannel, &out.InMemoryChannel
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MTChannelBroker != nil {
+		in, out := &in.MTChannelBroker, &out.MTChannelBroker
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(KafkaComponentsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PingSource != nil {
+		in, out := &in.PingSource, &out.PingSource
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobSink != nil {
+		in, out := &in.JobSink, &out.JobSink
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventMeshSpecComponents.
+func (in *EventMeshSpecComponents) DeepCopy() *EventMeshSpecComponents {
+	if in == nil {
+		return nil
+	}
+	out := new(EventMeshSpecComponents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventMeshSpecDefaults) DeepCopyInto(out *EventMeshSpecDefaults) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]NamespaceDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventMeshSpecDefaults.
+func (in *EventMeshSpecDefaults) DeepCopy() *EventMeshSpecDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(EventMeshSpecDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EventMeshSpecFeatures) DeepCopyInto(out *EventMeshSpecFeatures) {
 	*out = *in
@@ -187,6 +459,16 @@ func (in *EventMeshSpecKafka) DeepCopyInto(out *EventMeshSpecKafka) {
 			(*out)[key] = val
 		}
 	}
+	if in.NamespacedBroker != nil {
+		in, out := &in.NamespacedBroker, &out.NamespacedBroker
+		*out = new(NamespacedBrokerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(KafkaAutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -200,6 +482,50 @@ func (in *EventMeshSpecKafka) DeepCopy() *EventMeshSpecKafka {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventMeshSpecLogging) DeepCopyInto(out *EventMeshSpecLogging) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make(map[string]EventMeshSpecComponentLogging, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventMeshSpecLogging.
+func (in *EventMeshSpecLogging) DeepCopy() *EventMeshSpecLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(EventMeshSpecLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventMeshSpecObservability) DeepCopyInto(out *EventMeshSpecObservability) {
+	*out = *in
+	if in.RequestMetrics != nil {
+		in, out := &in.RequestMetrics, &out.RequestMetrics
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventMeshSpecObservability.
+func (in *EventMeshSpecObservability) DeepCopy() *EventMeshSpecObservability {
+	if in == nil {
+		return nil
+	}
+	out := new(EventMeshSpecObservability)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EventMeshSpecOverrides) DeepCopyInto(out *EventMeshSpecOverrides) {
 	*out = *in
@@ -240,10 +566,63 @@ func (in *EventMeshSpecOverrides) DeepCopy() *EventMeshSpecOverrides {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventMeshSpecScaling) DeepCopyInto(out *EventMeshSpecScaling) {
+	*out = *in
+	if in.ObjectsPerReplica != nil {
+		in, out := &in.ObjectsPerReplica, &out.ObjectsPerReplica
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleDownDelay != nil {
+		in, out := &in.ScaleDownDelay, &out.ScaleDownDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventMeshSpecScaling.
+func (in *EventMeshSpecScaling) DeepCopy() *EventMeshSpecScaling {
+	if in == nil {
+		return nil
+	}
+	out := new(EventMeshSpecScaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EventMeshStatus) DeepCopyInto(out *EventMeshStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.DriftedResources != nil {
+		in, out := &in.DriftedResources, &out.DriftedResources
+		*out = make([]DriftedResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]Revision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -257,6 +636,135 @@ func (in *EventMeshStatus) DeepCopy() *EventMeshStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaAutoscalingSpec) DeepCopyInto(out *KafkaAutoscalingSpec) {
+	*out = *in
+	if in.MinScale != nil {
+		in, out := &in.MinScale, &out.MinScale
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxScale != nil {
+		in, out := &in.MaxScale, &out.MaxScale
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CooldownPeriod != nil {
+		in, out := &in.CooldownPeriod, &out.CooldownPeriod
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LagThreshold != nil {
+		in, out := &in.LagThreshold, &out.LagThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaAutoscalingSpec.
+func (in *KafkaAutoscalingSpec) DeepCopy() *KafkaAutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaAutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaComponentsSpec) DeepCopyInto(out *KafkaComponentsSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Broker != nil {
+		in, out := &in.Broker, &out.Broker
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Channel != nil {
+		in, out := &in.Channel, &out.Channel
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sink != nil {
+		in, out := &in.Sink, &out.Sink
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaComponentsSpec.
+func (in *KafkaComponentsSpec) DeepCopy() *KafkaComponentsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaComponentsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceDefaults) DeepCopyInto(out *NamespaceDefaults) {
+	*out = *in
+	if in.BrokerConfig != nil {
+		in, out := &in.BrokerConfig, &out.BrokerConfig
+		*out = new(BrokerConfigReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceDefaults.
+func (in *NamespaceDefaults) DeepCopy() *NamespaceDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedBrokerSpec) DeepCopyInto(out *NamespacedBrokerSpec) {
+	*out = *in
+	if in.Receiver != nil {
+		in, out := &in.Receiver, &out.Receiver
+		*out = new(DataPlaneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Dispatcher != nil {
+		in, out := &in.Dispatcher, &out.Dispatcher
+		*out = new(DataPlaneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedBrokerSpec.
+func (in *NamespacedBrokerSpec) DeepCopy() *NamespacedBrokerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedBrokerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProbesRequirementsOverride) DeepCopyInto(out *ProbesRequirementsOverride) {
 	*out = *in
@@ -295,6 +803,23 @@ func (in *ResourceRequirementsOverride) DeepCopy() *ResourceRequirementsOverride
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Revision) DeepCopyInto(out *Revision) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Revision.
+func (in *Revision) DeepCopy() *Revision {
+	if in == nil {
+		return nil
+	}
+	out := new(Revision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadOverride) DeepCopyInto(out *WorkloadOverride) {
 	*out = *in
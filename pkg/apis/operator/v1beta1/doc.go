@@ -0,0 +1,4 @@
+// Package v1beta1 is the v1beta1 version of the API.
+// +k8s:deepcopy-gen=package
+// +groupName=operator.knative.dev
+package v1beta1
@@ -0,0 +1,52 @@
+package v1beta1
+
+import (
+	"context"
+	"strings"
+
+	"knative.dev/pkg/apis"
+)
+
+func (em *EventMesh) SetDefaults(ctx context.Context) {
+	ctx = apis.WithinParent(ctx, em.ObjectMeta)
+	em.Spec.SetDefaults(ctx)
+}
+
+func (spec *EventMeshSpec) SetDefaults(ctx context.Context) {
+	spec.Kafka.SetDefaults(ctx)
+
+	if spec.DeletionPolicy == "" {
+		spec.DeletionPolicy = DeletionPolicyKeepCRDs
+	}
+
+	if spec.Logging == nil {
+		spec.Logging = &EventMeshSpecLogging{}
+	}
+	spec.Logging.Level = strings.ToLower(spec.Logging.Level)
+	if spec.Logging.Level == "" {
+		spec.Logging.Level = LogLevelInfo
+	}
+	spec.Logging.SetDefaults(ctx)
+}
+
+func (l *EventMeshSpecLogging) SetDefaults(ctx context.Context) {
+	l.Format = strings.ToLower(l.Format)
+
+	for name, component := range l.Components {
+		component.Level = strings.ToLower(component.Level)
+		for logger, level := range component.Loggers {
+			component.Loggers[logger] = strings.ToLower(level)
+		}
+		l.Components[name] = component
+	}
+}
+
+func (kafka *EventMeshSpecKafka) SetDefaults(ctx context.Context) {
+	if kafka.NumPartitions <= 0 {
+		kafka.NumPartitions = 3
+	}
+
+	if kafka.ReplicationFactor <= 0 {
+		kafka.ReplicationFactor = 1
+	}
+}
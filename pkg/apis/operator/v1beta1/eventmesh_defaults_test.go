@@ -0,0 +1,82 @@
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEventMeshSetDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		em   *EventMesh
+		want *EventMesh
+	}{
+		{
+			name: "empty spec",
+			em:   &EventMesh{},
+			want: &EventMesh{
+				Spec: EventMeshSpec{
+					DeletionPolicy: DeletionPolicyKeepCRDs,
+					Logging: &EventMeshSpecLogging{
+						Level: LogLevelInfo,
+					},
+					Kafka: EventMeshSpecKafka{
+						NumPartitions:     3,
+						ReplicationFactor: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "set values are kept and log settings lower cased",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					DeletionPolicy: DeletionPolicyDeleteAll,
+					Logging: &EventMeshSpecLogging{
+						Level:  "DEBUG",
+						Format: "Console",
+						Components: map[string]EventMeshSpecComponentLogging{
+							"kafka-broker-dispatcher": {
+								Level:   "Warn",
+								Loggers: map[string]string{"org.apache.kafka": "ERROR"},
+							},
+						},
+					},
+					Kafka: EventMeshSpecKafka{
+						NumPartitions:     10,
+						ReplicationFactor: 3,
+					},
+				},
+			},
+			want: &EventMesh{
+				Spec: EventMeshSpec{
+					DeletionPolicy: DeletionPolicyDeleteAll,
+					Logging: &EventMeshSpecLogging{
+						Level:  LogLevelDebug,
+						Format: LogFormatConsole,
+						Components: map[string]EventMeshSpecComponentLogging{
+							"kafka-broker-dispatcher": {
+								Level:   LogLevelWarn,
+								Loggers: map[string]string{"org.apache.kafka": LogLevelError},
+							},
+						},
+					},
+					Kafka: EventMeshSpecKafka{
+						NumPartitions:     10,
+						ReplicationFactor: 3,
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.em.SetDefaults(context.TODO())
+			if diff := cmp.Diff(test.want, test.em); diff != "" {
+				t.Errorf("SetDefaults (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package v1beta1
+
+import (
+	"knative.dev/eventing/pkg/apis/feature"
+)
+
+// FeatureFlag is the state of a feature
+type FeatureFlag string
+
+const (
+	FeatureEnabled  FeatureFlag = "enabled"
+	FeatureDisabled FeatureFlag = "disabled"
+	FeatureAllowed  FeatureFlag = "allowed"
+
+	TransportEncryptionDisabled   = "disabled"
+	TransportEncryptionPermissive = "permissive"
+	TransportEncryptionStrict     = "strict"
+
+	AuthorizationModeAllowAll           = "allow-all"
+	AuthorizationModeDenyAll            = "deny-all"
+	AuthorizationModeAllowSameNamespace = "allow-same-namespace"
+)
+
+var (
+	FeatureFlags = []string{
+		string(FeatureEnabled),
+		string(FeatureDisabled),
+		string(FeatureAllowed),
+	}
+
+	TransportEncryptionModes = []string{
+		TransportEncryptionDisabled,
+		TransportEncryptionPermissive,
+		TransportEncryptionStrict,
+	}
+
+	AuthorizationModes = []string{
+		AuthorizationModeAllowAll,
+		AuthorizationModeDenyAll,
+		AuthorizationModeAllowSameNamespace,
+	}
+)
+
+// EventingFeatures are the feature flags of eventing (config-features ConfigMap)
+type EventingFeatures struct {
+	// +optional
+	KReferenceGroup FeatureFlag `json:"kreferenceGroup,omitempty"`
+
+	// +optional
+	DeliveryRetryAfter FeatureFlag `json:"deliveryRetryAfter,omitempty"`
+
+	// +optional
+	DeliveryTimeout FeatureFlag `json:"deliveryTimeout,omitempty"`
+
+	// +optional
+	KReferenceMapping FeatureFlag `json:"kreferenceMapping,omitempty"`
+
+	// TransportEncryption is one of disabled, permissive or strict.
+	// +optional
+	TransportEncryption string `json:"transportEncryption,omitempty"`
+
+	// +optional
+	EventTypeAutoCreate FeatureFlag `json:"eventTypeAutoCreate,omitempty"`
+
+	// +optional
+	AuthenticationOIDC FeatureFlag `json:"authenticationOIDC,omitempty"`
+
+	// DefaultAuthorizationMode is one of allow-all, deny-all or allow-same-namespace.
+	// +optional
+	DefaultAuthorizationMode string `json:"defaultAuthorizationMode,omitempty"`
+
+	// +optional
+	CrossNamespaceEventLinks FeatureFlag `json:"crossNamespaceEventLinks,omitempty"`
+
+	// +optional
+	NewAPIServerSourceFilters FeatureFlag `json:"newAPIServerSourceFilters,omitempty"`
+}
+
+// EventingKafkaBrokerFeatures are the feature flags of eventing-kafka-broker (config-kafka-features ConfigMap)
+type EventingKafkaBrokerFeatures struct {
+	// +optional
+	DispatcherRateLimiter FeatureFlag `json:"dispatcherRateLimiter,omitempty"`
+
+	// +optional
+	DispatcherOrderedExecutorMetrics FeatureFlag `json:"dispatcherOrderedExecutorMetrics,omitempty"`
+
+	// +optional
+	ControllerAutoscalerKeda FeatureFlag `json:"controllerAutoscalerKeda,omitempty"`
+
+	// +optional
+	TriggersConsumerGroupTemplate string `json:"triggersConsumerGroupTemplate,omitempty"`
+
+	// +optional
+	BrokersTopicTemplate string `json:"brokersTopicTemplate,omitempty"`
+
+	// +optional
+	ChannelsTopicTemplate string `json:"channelsTopicTemplate,omitempty"`
+}
+
+// keys returns the typed fields by their key in the config-features ConfigMap
+func (f *EventingFeatures) keys() map[string]*string {
+	return map[string]*string{
+		feature.KReferenceGroup:          (*string)(&f.KReferenceGroup),
+		feature.DeliveryRetryAfter:       (*string)(&f.DeliveryRetryAfter),
+		feature.DeliveryTimeout:          (*string)(&f.DeliveryTimeout),
+		feature.KReferenceMapping:        (*string)(&f.KReferenceMapping),
+		feature.TransportEncryption:      &f.TransportEncryption,
+		feature.EvenTypeAutoCreate:       (*string)(&f.EventTypeAutoCreate),
+		feature.OIDCAuthentication:       (*string)(&f.AuthenticationOIDC),
+		feature.AuthorizationDefaultMode: &f.DefaultAuthorizationMode,
+		feature.CrossNamespaceEventLinks: (*string)(&f.CrossNamespaceEventLinks),
+		feature.NewAPIServerFilters:      (*string)(&f.NewAPIServerSourceFilters),
+	}
+}
+
+// ToConfigMapData returns the set feature flags as config-features ConfigMap data
+func (f *EventingFeatures) ToConfigMapData() map[string]string {
+	return toConfigMapData(f.keys())
+}
+
+// FromConfigMapData sets the feature flags from the given config-features ConfigMap data. It returns the entries,
+// which have no typed field.
+func (f *EventingFeatures) FromConfigMapData(data map[string]string) map[string]string {
+	return fromConfigMapData(f.keys(), data)
+}
+
+// keys returns the typed fields by their key in the config-kafka-features ConfigMap
+func (f *EventingKafkaBrokerFeatures) keys() map[string]*string {
+	return map[string]*string{
+		"dispatcher-rate-limiter":             (*string)(&f.DispatcherRateLimiter),
+		"dispatcher-ordered-executor-metrics": (*string)(&f.DispatcherOrderedExecutorMetrics),
+		"controller-autoscaler-keda":          (*string)(&f.ControllerAutoscalerKeda),
+		"triggers-consumergroup-template":     &f.TriggersConsumerGroupTemplate,
+		"brokers-topic-template":              &f.BrokersTopicTemplate,
+		"channels-topic-template":             &f.ChannelsTopicTemplate,
+	}
+}
+
+// ToConfigMapData returns the set feature flags as config-kafka-features ConfigMap data
+func (f *EventingKafkaBrokerFeatures) ToConfigMapData() map[string]string {
+	return toConfigMapData(f.keys())
+}
+
+// FromConfigMapData sets the feature flags from the given config-kafka-features ConfigMap data. It returns the
+// entries, which have no typed field.
+func (f *EventingKafkaBrokerFeatures) FromConfigMapData(data map[string]string) map[string]string {
+	return fromConfigMapData(f.keys(), data)
+}
+
+func toConfigMapData(keys map[string]*string) map[string]string {
+	data := map[string]string{}
+	for key, value := range keys {
+		if *value != "" {
+			data[key] = *value
+		}
+	}
+
+	return data
+}
+
+func fromConfigMapData(keys map[string]*string, data map[string]string) map[string]string {
+	unknown := map[string]string{}
+	for key, value := range data {
+		// empty values can't be distinguished from unset fields and are therefore returned as unknown
+		if field, ok := keys[key]; ok && value != "" {
+			*field = value
+		} else {
+			unknown[key] = value
+		}
+	}
+
+	return unknown
+}
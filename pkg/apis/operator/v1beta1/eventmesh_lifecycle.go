@@ -0,0 +1,62 @@
+package v1beta1
+
+import (
+	"knative.dev/pkg/apis"
+)
+
+var EventMeshCondSet = apis.NewLivingConditionSet(EventMeshConditionPrimary, EventMeshConditionInstallSucceeded, EventMeshConditionDeploymentsAvailable, EventMeshConditionAuthConfigured, EventMeshConditionPostInstallSucceeded, EventMeshConditionDeletionAllowed)
+
+const (
+	// EventMeshConditionPrimary is a Condition indicating whether the EventMesh is the primary EventMesh of the
+	// cluster, which installs the components. Other EventMeshes are marked false with the reason Duplicate.
+	EventMeshConditionPrimary apis.ConditionType = "Primary"
+
+	// EventMeshConditionInstallSucceeded is a Condition indicating that the installation of all components
+	// has been successful.
+	EventMeshConditionInstallSucceeded apis.ConditionType = "InstallSucceeded"
+
+	// EventMeshConditionDeploymentsAvailable is a Condition indicating whether the Deployments of
+	// the components have come up successfully.
+	EventMeshConditionDeploymentsAvailable apis.ConditionType = "DeploymentsAvailable"
+
+	// EventMeshConditionAuthConfigured is a Condition indicating whether the authentication against the
+	// Kafka cluster is configured correctly.
+	EventMeshConditionAuthConfigured apis.ConditionType = "AuthConfigured"
+
+	// EventMeshConditionPostInstallSucceeded is a Condition indicating whether the post-install jobs of an upgrade
+	// (e.g. the storage version migration) have completed successfully.
+	EventMeshConditionPostInstallSucceeded apis.ConditionType = "PostInstallSucceeded"
+
+	// EventMeshConditionDeletionAllowed is a Condition indicating whether the deletion of the EventMesh can proceed.
+	// It is false, while the deletion is blocked by resources, which are still in use.
+	EventMeshConditionDeletionAllowed apis.ConditionType = "DeletionAllowed"
+
+	// EventMeshConditionFieldsOwned is a Condition indicating whether the operator owns all fields of the applied
+	// manifests. Conflicts with other field managers don't affect the readiness of the EventMesh.
+	EventMeshConditionFieldsOwned apis.ConditionType = "FieldsOwned"
+)
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*EventMesh) GetConditionSet() apis.ConditionSet {
+	return EventMeshCondSet
+}
+
+// GetCondition returns the condition currently associated with the given type, or nil.
+func (em *EventMeshStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return EventMeshCondSet.Manage(em).GetCondition(t)
+}
+
+// IsReady returns true if the resource is ready overall.
+func (em *EventMeshStatus) IsReady() bool {
+	return em.GetTopLevelCondition().IsTrue()
+}
+
+// GetTopLevelCondition returns the top level Condition.
+func (em *EventMeshStatus) GetTopLevelCondition() *apis.Condition {
+	return EventMeshCondSet.Manage(em).GetTopLevelCondition()
+}
+
+// InitializeConditions sets relevant unset conditions to Unknown state.
+func (em *EventMeshStatus) InitializeConditions() {
+	EventMeshCondSet.Manage(em).InitializeConditions()
+}
@@ -0,0 +1,892 @@
+package v1beta1
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	LogLevelTrace = "trace"
+	LogLevelDebug = "debug"
+	LogLevelInfo  = "info"
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+	LogLevelFatal = "fatal"
+
+	// LogFormatJSON writes structured logs as JSON.
+	LogFormatJSON = "json"
+	// LogFormatConsole writes human-readable logs.
+	LogFormatConsole = "console"
+
+	BrokerClassKafka           = "Kafka"
+	BrokerClassKafkaNamespaced = "KafkaNamespaced"
+	BrokerClassMTChannelBased  = "MTChannelBasedBroker"
+
+	ChannelImplementationKafka = "KafkaChannel"
+	ChannelImplementationIMC   = "InMemoryChannel"
+
+	// DeletionPolicyKeepCRDs removes the components, but keeps the CRDs and therefore the custom resources.
+	DeletionPolicyKeepCRDs = "KeepCRDs"
+	// DeletionPolicyDeleteAll removes the components including the CRDs and therefore all custom resources.
+	DeletionPolicyDeleteAll = "DeleteAll"
+	// DeletionPolicyBlockIfInUse blocks the deletion while Brokers, Triggers, InMemoryChannels or KafkaChannels exist
+	// and removes everything afterward.
+	DeletionPolicyBlockIfInUse = "BlockIfInUse"
+
+	// ObservabilityProtocolNone disables exporting metrics or traces.
+	ObservabilityProtocolNone = "none"
+	// ObservabilityProtocolPrometheus exposes metrics to be scraped by Prometheus.
+	ObservabilityProtocolPrometheus = "prometheus"
+	// ObservabilityProtocolHTTPProtobuf exports metrics or traces via OTLP over HTTP.
+	ObservabilityProtocolHTTPProtobuf = "http/protobuf"
+	// ObservabilityProtocolGRPC exports metrics or traces via OTLP over gRPC.
+	ObservabilityProtocolGRPC = "grpc"
+	// ObservabilityProtocolStdout writes traces to the logs for debugging.
+	ObservabilityProtocolStdout = "stdout"
+
+	// DefaultRevisionHistoryLimit is the number of applied revisions kept by default.
+	DefaultRevisionHistoryLimit = 10
+
+	// RevisionOutcomeAvailable is the outcome of a revision, which got applied and whose deployments are available.
+	RevisionOutcomeAvailable = "Available"
+	// RevisionOutcomeProgressing is the outcome of a revision, which got applied, but whose deployments are not
+	// available yet.
+	RevisionOutcomeProgressing = "Progressing"
+	// RevisionOutcomeFailed is the outcome of a revision, which could not be applied.
+	RevisionOutcomeFailed = "Failed"
+)
+
+var (
+	LogLevels = []string{
+		LogLevelTrace,
+		LogLevelDebug,
+		LogLevelInfo,
+		LogLevelWarn,
+		LogLevelError,
+		LogLevelFatal,
+	}
+
+	LogFormats = []string{
+		LogFormatJSON,
+		LogFormatConsole,
+	}
+
+	// GoComponents are the names, which the Go components of the bundled releases log with. Their levels are
+	// configured via the loglevel.<component> keys of config-logging.
+	GoComponents = []string{
+		"controller",
+		"webhook",
+		"eventing-webhook",
+		"mt-broker-controller",
+		"mt_broker_ingress",
+		"mt_broker_filter",
+		"inmemorychannel-webhook",
+		"inmemorychannel-dispatcher",
+		"pingsource-mt-adapter",
+		"job_sink",
+		"kafka-controller",
+		"kafka-webhook-eventing",
+	}
+
+	// KafkaDataPlaneComponents are the Java components of eventing-kafka-broker, which are configured via logback
+	// instead of zap.
+	KafkaDataPlaneComponents = []string{
+		"kafka-broker-receiver",
+		"kafka-broker-dispatcher",
+		"kafka-channel-receiver",
+		"kafka-channel-dispatcher",
+		"kafka-sink-receiver",
+		"kafka-source-dispatcher",
+	}
+
+	BrokerClasses = []string{
+		BrokerClassKafka,
+		BrokerClassKafkaNamespaced,
+		BrokerClassMTChannelBased,
+	}
+
+	ChannelImplementations = []string{
+		ChannelImplementationKafka,
+		ChannelImplementationIMC,
+	}
+
+	DeletionPolicies = []string{
+		DeletionPolicyKeepCRDs,
+		DeletionPolicyDeleteAll,
+		DeletionPolicyBlockIfInUse,
+	}
+
+	MetricsProtocols = []string{
+		ObservabilityProtocolNone,
+		ObservabilityProtocolPrometheus,
+		ObservabilityProtocolHTTPProtobuf,
+		ObservabilityProtocolGRPC,
+	}
+
+	TracingProtocols = []string{
+		ObservabilityProtocolNone,
+		ObservabilityProtocolHTTPProtobuf,
+		ObservabilityProtocolGRPC,
+		ObservabilityProtocolStdout,
+	}
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EventMesh represents the EventMesh
+type EventMesh struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec EventMeshSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status EventMeshStatus `json:"status,omitempty"`
+}
+
+type EventMeshSpec struct {
+	// Version pins the EventMesh to a bundled Knative release. It can be given as major.minor (e.g. 1.19), which
+	// resolves to the latest bundled patch release, or as full version (e.g. 1.19.2). Defaults to the latest
+	// bundled release.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	Kafka EventMeshSpecKafka `json:"kafka,omitempty"`
+
+	// +optional
+	Logging *EventMeshSpecLogging `json:"logging,omitempty"`
+
+	// +optional
+	DefaultBroker string `json:"defaultBroker,omitempty"`
+
+	// +optional
+	DefaultChannel string `json:"defaultChannel,omitempty"`
+
+	// Defaults overrides the default broker class, broker config and channel implementation per namespace.
+	// +optional
+	Defaults *EventMeshSpecDefaults `json:"defaults,omitempty"`
+
+	// +optional
+	Features *EventMeshSpecFeatures `json:"features,omitempty"`
+
+	// +optional
+	Overrides *EventMeshSpecOverrides `json:"overrides,omitempty"`
+
+	// Components allows to disable individual components. All components are enabled by default.
+	// +optional
+	Components *EventMeshSpecComponents `json:"components,omitempty"`
+
+	// DeletionPolicy defines what gets removed, when the EventMesh is deleted. One of KeepCRDs, DeleteAll or
+	// BlockIfInUse. Defaults to KeepCRDs.
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
+	// Apply configures how the manifests are applied.
+	// +optional
+	Apply *EventMeshSpecApply `json:"apply,omitempty"`
+
+	// RevisionHistoryLimit is the number of applied revisions kept for rollbacks. Defaults to 10.
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// Scaling configures how the InMemoryChannel and MT channel based broker components are scaled by the number of
+	// channels and brokers. By default, they are scaled to one replica as long as channels or brokers exist.
+	// +optional
+	Scaling *EventMeshSpecScaling `json:"scaling,omitempty"`
+
+	// Observability configures how the eventing and Kafka components export metrics and traces. The settings are
+	// written into the config-observability ConfigMap, which is shared by all components.
+	// +optional
+	Observability *EventMeshSpecObservability `json:"observability,omitempty"`
+}
+
+type EventMeshSpecScaling struct {
+	// ObjectsPerReplica is the number of objects (e.g. Brokers or InMemoryChannels) a component is scaled by one
+	// replica for. When not set, components are scaled to MinReplicas regardless of the number of objects.
+	// +optional
+	ObjectsPerReplica *int32 `json:"objectsPerReplica,omitempty"`
+
+	// MinReplicas is the minimum number of replicas of components, as long as objects exist. Components are still
+	// scaled to zero without objects. Defaults to 1.
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the maximum number of replicas of components. Not limited by default.
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// ScaleDownDelay is the time components are kept at their replicas, after their objects were deleted, before
+	// they are scaled down (e.g. to zero). This avoids restarting pods, when objects are deleted and created again
+	// shortly after. Components are scaled down immediately by default.
+	// +optional
+	ScaleDownDelay *metav1.Duration `json:"scaleDownDelay,omitempty"`
+}
+
+// GetScaleDownDelay returns the configured scale down delay or zero if none is configured
+func (scaling *EventMeshSpecScaling) GetScaleDownDelay() time.Duration {
+	if scaling == nil || scaling.ScaleDownDelay == nil {
+		return 0
+	}
+
+	return scaling.ScaleDownDelay.Duration
+}
+
+type EventMeshSpecObservability struct {
+	// MetricsProtocol is the protocol metrics are exported with. One of none, prometheus, http/protobuf (OTLP over
+	// HTTP) or grpc (OTLP over gRPC). Defaults to none.
+	// +optional
+	MetricsProtocol string `json:"metricsProtocol,omitempty"`
+
+	// MetricsEndpoint is the URL metrics are exported to. It is required for OTLP. For prometheus, it can be set to a
+	// host:port the components listen on for scrapes.
+	// +optional
+	MetricsEndpoint string `json:"metricsEndpoint,omitempty"`
+
+	// RequestMetrics enables publishing the latency quantiles of the requests handled by the Kafka data planes in
+	// addition to their request counts and latency histograms. Disabled by default.
+	// +optional
+	RequestMetrics *bool `json:"requestMetrics,omitempty"`
+
+	// TracingProtocol is the protocol traces are exported with. One of none, http/protobuf (OTLP over HTTP), grpc
+	// (OTLP over gRPC) or stdout. Defaults to none.
+	// +optional
+	TracingProtocol string `json:"tracingProtocol,omitempty"`
+
+	// TracingEndpoint is the URL traces are exported to. It is required for OTLP.
+	// +optional
+	TracingEndpoint string `json:"tracingEndpoint,omitempty"`
+
+	// TracingSamplingRate is the share of traces, which are sampled, between 0 (none) and 1 (all), e.g. "0.1".
+	// +optional
+	TracingSamplingRate string `json:"tracingSamplingRate,omitempty"`
+}
+
+type EventMeshSpecApply struct {
+	// ForceConflicts defines whether the operator takes over fields, which are owned by other field managers (e.g. a
+	// GitOps tool or kubectl). Otherwise, the conflicting fields are left to the other managers and the rest of the
+	// resources is applied. Conflicts are reported in the status. Replicas and webhook CA bundles, which are managed
+	// by controllers in the cluster, are never taken over. Defaults to false.
+	// +optional
+	ForceConflicts *bool `json:"forceConflicts,omitempty"`
+
+	// ForceConflictsByKind overrides ForceConflicts per resource kind (e.g. Deployment).
+	// +optional
+	ForceConflictsByKind map[string]bool `json:"forceConflictsByKind,omitempty"`
+}
+
+type EventMeshSpecDefaults struct {
+	// +optional
+	Namespaces []NamespaceDefaults `json:"namespaces,omitempty"`
+}
+
+type NamespaceDefaults struct {
+	// Namespace is the namespace the defaults apply to.
+	Namespace string `json:"namespace"`
+
+	// BrokerClass is the default broker class in the namespace. Defaults to the cluster default broker class.
+	// +optional
+	BrokerClass string `json:"brokerClass,omitempty"`
+
+	// BrokerConfig references the ConfigMap with the default broker config in the namespace. Defaults to the config
+	// of the broker class.
+	// +optional
+	BrokerConfig *BrokerConfigReference `json:"brokerConfig,omitempty"`
+
+	// Channel is the default channel implementation in the namespace. Defaults to the cluster default channel
+	// implementation.
+	// +optional
+	Channel string `json:"channel,omitempty"`
+}
+
+type BrokerConfigReference struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the ConfigMap. Defaults to the namespace of the defaults entry.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type EventMeshSpecKafka struct {
+	BootstrapServers []string `json:"bootstrapServers,omitempty"`
+
+	// AuthSecretRef references a secret in the system namespace, which holds the authentication configuration
+	// (protocol, SASL credentials and/or TLS certificates) used by the Kafka broker and channel.
+	// +optional
+	AuthSecretRef *corev1.LocalObjectReference `json:"authSecretRef,omitempty"`
+
+	// +optional
+	NumPartitions int32 `json:"numPartitions,omitempty"`
+
+	// +optional
+	ReplicationFactor int32 `json:"replicationFactor,omitempty"`
+
+	// +optional
+	TopicConfigOptions map[string]string `json:"topicConfigOptions,omitempty"`
+
+	// NamespacedBroker configures the data plane, which gets created in every namespace with a KafkaNamespaced
+	// broker.
+	// +optional
+	NamespacedBroker *NamespacedBrokerSpec `json:"namespacedBroker,omitempty"`
+
+	// Autoscaling configures the KEDA based autoscaling of the Kafka data plane consumers.
+	// +optional
+	Autoscaling *KafkaAutoscalingSpec `json:"autoscaling,omitempty"`
+}
+
+type KafkaAutoscalingSpec struct {
+	// Enabled enables the KEDA autoscaling of the Kafka consumers. Requires KEDA to be installed.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +optional
+	MinScale *int32 `json:"minScale,omitempty"`
+
+	// +optional
+	MaxScale *int32 `json:"maxScale,omitempty"`
+
+	// PollingInterval is the interval in seconds in which KEDA checks the consumer lag.
+	// +optional
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+
+	// CooldownPeriod is the period in seconds to wait after the last active trigger before scaling down to the
+	// minimum scale.
+	// +optional
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+
+	// LagThreshold is the consumer lag, which triggers scaling up.
+	// +optional
+	LagThreshold *int32 `json:"lagThreshold,omitempty"`
+}
+
+// IsKafkaAutoscalingEnabled returns true if the KEDA autoscaling of the Kafka consumers is enabled
+func (kafka *EventMeshSpecKafka) IsKafkaAutoscalingEnabled() bool {
+	return kafka.Autoscaling != nil && kafka.Autoscaling.Enabled
+}
+
+type NamespacedBrokerSpec struct {
+	// Receiver configures the kafka-broker-receiver deployment.
+	// +optional
+	Receiver *DataPlaneSpec `json:"receiver,omitempty"`
+
+	// Dispatcher configures the kafka-broker-dispatcher statefulset.
+	// +optional
+	Dispatcher *DataPlaneSpec `json:"dispatcher,omitempty"`
+}
+
+type DataPlaneSpec struct {
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources sets the resources of the data plane container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+type EventMeshSpecComponents struct {
+	// +optional
+	InMemoryChannel *ComponentSpec `json:"inMemoryChannel,omitempty"`
+
+	// +optional
+	MTChannelBroker *ComponentSpec `json:"mtChannelBroker,omitempty"`
+
+	// +optional
+	Kafka *KafkaComponentsSpec `json:"kafka,omitempty"`
+
+	// PingSource controls the PingSource adapter. The PingSource CRD is kept, as the eventing controller requires it.
+	// +optional
+	PingSource *ComponentSpec `json:"pingSource,omitempty"`
+
+	// JobSink controls the JobSink receiver. The JobSink CRD is kept, as the eventing controller requires it.
+	// +optional
+	JobSink *ComponentSpec `json:"jobSink,omitempty"`
+}
+
+type ComponentSpec struct {
+	// Enabled defines whether the component gets installed. Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+type KafkaComponentsSpec struct {
+	// Enabled defines whether any of the eventing-kafka-broker components gets installed. Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// +optional
+	Broker *ComponentSpec `json:"broker,omitempty"`
+
+	// +optional
+	Channel *ComponentSpec `json:"channel,omitempty"`
+
+	// +optional
+	Sink *ComponentSpec `json:"sink,omitempty"`
+
+	// +optional
+	Source *ComponentSpec `json:"source,omitempty"`
+}
+
+type EventMeshSpecLogging struct {
+	// Level is the default log level of all components.
+	// +optional
+	Level string `json:"level,omitempty"`
+
+	// Format is the format of the logs of all components. One of json or console. Defaults to the format of the
+	// release, which is json.
+	// +optional
+	Format string `json:"format,omitempty"`
+
+	// Components overrides the logging per component. Go components are keyed by the name they log with, which is
+	// used in the loglevel.<component> keys of config-logging (e.g. controller, webhook or kafka-controller). The Kafka
+	// data planes are keyed by their workload name (e.g. kafka-broker-dispatcher). Other names are rejected. Go
+	// components log trace as debug.
+	// +optional
+	Components map[string]EventMeshSpecComponentLogging `json:"components,omitempty"`
+}
+
+type EventMeshSpecComponentLogging struct {
+	// Level overrides the log level of the component.
+	// +optional
+	Level string `json:"level,omitempty"`
+
+	// Loggers sets the log level per logger name (e.g. org.apache.kafka). Only supported by the Kafka data planes,
+	// which log via logback.
+	// +optional
+	Loggers map[string]string `json:"loggers,omitempty"`
+}
+
+type EventMeshSpecFeatures struct {
+	// +optional
+	Eventing *EventingFeatures `json:"eventing,omitempty"`
+
+	// +optional
+	EventingKafkaBroker *EventingKafkaBrokerFeatures `json:"eventingKafkaBroker,omitempty"`
+}
+
+type EventMeshSpecOverrides struct {
+	// +optional
+	Config map[string]map[string]string `json:"config,omitempty"`
+
+	// +optional
+	Workloads WorkloadOverrides `json:"workloads,omitempty"`
+}
+
+type EventMeshStatus struct {
+	// inherits duck/v1 Status, which currently provides:
+	// * ObservedGeneration - the 'Generation' of the Service that was last processed by the controller.
+	// * Conditions - the latest available observations of a resource's current state.
+	duckv1.Status `json:",inline"`
+
+	// Version is the version of the installed release.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// EventingVersion is the version of the installed eventing components.
+	// +optional
+	EventingVersion string `json:"eventingVersion,omitempty"`
+
+	// EventingKafkaBrokerVersion is the version of the installed eventing-kafka-broker components.
+	// +optional
+	EventingKafkaBrokerVersion string `json:"eventingKafkaBrokerVersion,omitempty"`
+
+	// Components lists the installed components and the number of resources applied for them.
+	// +optional
+	Components []ComponentStatus `json:"components,omitempty"`
+
+	// DriftedResources lists the managed resources, which were changed by hand and got reverted by the latest
+	// reconcile. It is empty, if the latest reconcile found no drift.
+	// +optional
+	DriftedResources []DriftedResource `json:"driftedResources,omitempty"`
+
+	// Revisions lists the last applied revisions, the most recently applied first. The specs of the revisions are
+	// kept in the <name>-revisions ConfigMap in the system namespace.
+	// +optional
+	Revisions []Revision `json:"revisions,omitempty"`
+}
+
+type Revision struct {
+	// Revision is the number of the revision.
+	Revision int64 `json:"revision"`
+
+	// SpecHash is the hash of the applied spec.
+	SpecHash string `json:"specHash"`
+
+	// Version is the version of the applied release.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Timestamp is the time the revision was applied or its outcome changed most recently.
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// Outcome is the outcome of the most recent apply of the revision. One of Available, Progressing or Failed.
+	Outcome string `json:"outcome"`
+}
+
+type ComponentStatus struct {
+	// Name is the name of the component.
+	Name string `json:"name"`
+
+	// Resources is the number of resources applied for the component.
+	Resources int `json:"resources"`
+}
+
+type DriftedResource struct {
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the resource. Empty for cluster scoped resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
+}
+
+// String returns the resource as kind/namespace/name or kind/name for cluster scoped resources
+func (dr DriftedResource) String() string {
+	if dr.Namespace == "" {
+		return dr.Kind + "/" + dr.Name
+	}
+	return dr.Kind + "/" + dr.Namespace + "/" + dr.Name
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EventMeshList is a collection of EventMesh.
+type EventMeshList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EventMesh `json:"items"`
+}
+
+var (
+	// Check that EventMesh can be validated, can be defaulted, and has immutable fields.
+	_ apis.Validatable = (*EventMesh)(nil)
+	_ apis.Defaultable = (*EventMesh)(nil)
+
+	// Check that EventMesh can return its spec untyped.
+	_ apis.HasSpec = (*EventMesh)(nil)
+
+	_ runtime.Object = (*EventMesh)(nil)
+
+	// Check that we can create OwnerReferences to an EventMesh.
+	_ kmeta.OwnerRefable = (*EventMesh)(nil)
+
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*EventMesh)(nil)
+)
+
+// GetGroupVersionKind returns GroupVersionKind for EventMesh
+func (em *EventMesh) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("EventMesh")
+}
+
+// GetUntypedSpec returns the spec of the EventMesh.
+func (em *EventMesh) GetUntypedSpec() interface{} {
+	return em.Spec
+}
+
+// GetStatus retrieves the status of the EventMesh. Implements the KRShaped interface.
+func (em *EventMesh) GetStatus() *duckv1.Status {
+	return &em.Status.Status
+}
+
+// IsEnabled returns true if the component is not disabled explicitly
+func (c *ComponentSpec) IsEnabled() bool {
+	return c == nil || c.Enabled == nil || *c.Enabled
+}
+
+func (c *EventMeshSpecComponents) IsInMemoryChannelEnabled() bool {
+	return c == nil || c.InMemoryChannel.IsEnabled()
+}
+
+func (c *EventMeshSpecComponents) IsMTChannelBrokerEnabled() bool {
+	return c == nil || c.MTChannelBroker.IsEnabled()
+}
+
+func (c *EventMeshSpecComponents) IsPingSourceEnabled() bool {
+	return c == nil || c.PingSource.IsEnabled()
+}
+
+func (c *EventMeshSpecComponents) IsJobSinkEnabled() bool {
+	return c == nil || c.JobSink.IsEnabled()
+}
+
+// IsKafkaEnabled returns true if the eventing-kafka-broker control plane should be installed
+func (c *EventMeshSpecComponents) IsKafkaEnabled() bool {
+	return c == nil || c.Kafka == nil || c.Kafka.Enabled == nil || *c.Kafka.Enabled
+}
+
+func (c *EventMeshSpecComponents) IsKafkaBrokerEnabled() bool {
+	return c.IsKafkaEnabled() && (c == nil || c.Kafka == nil || c.Kafka.Broker.IsEnabled())
+}
+
+func (c *EventMeshSpecComponents) IsKafkaChannelEnabled() bool {
+	return c.IsKafkaEnabled() && (c == nil || c.Kafka == nil || c.Kafka.Channel.IsEnabled())
+}
+
+func (c *EventMeshSpecComponents) IsKafkaSinkEnabled() bool {
+	return c.IsKafkaEnabled() && (c == nil || c.Kafka == nil || c.Kafka.Sink.IsEnabled())
+}
+
+func (c *EventMeshSpecComponents) IsKafkaSourceEnabled() bool {
+	return c.IsKafkaEnabled() && (c == nil || c.Kafka == nil || c.Kafka.Source.IsEnabled())
+}
+
+// GetEnabledBrokerClasses returns the broker classes of the enabled components
+func (spec *EventMeshSpec) GetEnabledBrokerClasses() []string {
+	var classes []string
+	if spec.Components.IsKafkaBrokerEnabled() {
+		classes = append(classes, BrokerClassKafka, BrokerClassKafkaNamespaced)
+	}
+	if spec.Components.IsMTChannelBrokerEnabled() {
+		classes = append(classes, BrokerClassMTChannelBased)
+	}
+
+	return classes
+}
+
+// GetEnabledChannelImplementations returns the channel implementations of the enabled components
+func (spec *EventMeshSpec) GetEnabledChannelImplementations() []string {
+	var channels []string
+	if spec.Components.IsKafkaChannelEnabled() {
+		channels = append(channels, ChannelImplementationKafka)
+	}
+	if spec.Components.IsInMemoryChannelEnabled() {
+		channels = append(channels, ChannelImplementationIMC)
+	}
+
+	return channels
+}
+
+// GetDefaultBrokerClass returns the configured default broker class or the first enabled broker class (Kafka
+// preferred) if none is configured. Returns an empty string if no broker class is enabled.
+func (spec *EventMeshSpec) GetDefaultBrokerClass() string {
+	if spec.DefaultBroker != "" {
+		return spec.DefaultBroker
+	}
+
+	if classes := spec.GetEnabledBrokerClasses(); len(classes) > 0 {
+		return classes[0]
+	}
+	return ""
+}
+
+// GetDeletionPolicy returns the configured deletion policy or KeepCRDs if none is configured
+func (spec *EventMeshSpec) GetDeletionPolicy() string {
+	if spec.DeletionPolicy == "" {
+		return DeletionPolicyKeepCRDs
+	}
+
+	return spec.DeletionPolicy
+}
+
+// ShouldForceConflicts returns whether conflicts of resources of the given kind are forced. Conflicts are not forced
+// by default.
+func (a *EventMeshSpecApply) ShouldForceConflicts(kind string) bool {
+	if a == nil {
+		return false
+	}
+
+	if force, ok := a.ForceConflictsByKind[kind]; ok {
+		return force
+	}
+	if a.ForceConflicts != nil {
+		return *a.ForceConflicts
+	}
+
+	return false
+}
+
+// GetRevisionHistoryLimit returns the configured number of revisions to keep or 10 if none is configured
+func (spec *EventMeshSpec) GetRevisionHistoryLimit() int {
+	if spec.RevisionHistoryLimit == nil {
+		return DefaultRevisionHistoryLimit
+	}
+
+	return int(*spec.RevisionHistoryLimit)
+}
+
+// GetNamespaceDefaults returns the namespace defaults or nil if none are configured
+func (spec *EventMeshSpec) GetNamespaceDefaults() []NamespaceDefaults {
+	if spec.Defaults == nil {
+		return nil
+	}
+
+	return spec.Defaults.Namespaces
+}
+
+// GetDefaultChannel returns the configured default channel implementation or the first enabled channel
+// implementation (KafkaChannel preferred) if none is configured. Returns an empty string if no channel
+// implementation is enabled.
+func (spec *EventMeshSpec) GetDefaultChannel() string {
+	if spec.DefaultChannel != "" {
+		return spec.DefaultChannel
+	}
+
+	if channels := spec.GetEnabledChannelImplementations(); len(channels) > 0 {
+		return channels[0]
+	}
+	return ""
+}
+
+type WorkloadOverrides []WorkloadOverride
+
+func (wlos WorkloadOverrides) GetByName(name string) *WorkloadOverrides {
+	overrides := WorkloadOverrides{}
+
+	for _, override := range wlos {
+		if override.Name == name {
+			overrides = append(overrides, override)
+		}
+	}
+
+	return &overrides
+}
+
+func (wlos WorkloadOverrides) HasAtLeastOneWithReplicasSet() bool {
+	for _, override := range wlos {
+		if override.Replicas != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WorkloadOverride copied from https://github.com/knative/operator/blob/650497b2493703ac73d5b50ef2fbf70e5dc57bba/pkg/apis/operator/base/common.go#L274
+// due to dependency issues
+
+// WorkloadOverride defines the configurations of deployments to override.
+type WorkloadOverride struct {
+	// Name is the name of the deployment to override.
+	Name string `json:"name"`
+
+	// Labels overrides labels for the deployment and its template.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations overrides labels for the deployment and its template.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Replicas is the number of replicas that HA parts of the control plane
+	// will be scaled to.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// NodeSelector overrides nodeSelector for the deployment.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// TopologySpreadConstraints overrides topologySpreadConstraints for the deployment.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// Tolerations overrides tolerations for the deployment.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinities overrides affinity for the deployment.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Resources overrides resources for the containers.
+	// +optional
+	Resources []ResourceRequirementsOverride `json:"resources,omitempty"`
+
+	// Env overrides env vars for the containers.
+	// +optional
+	Env []EnvRequirementsOverride `json:"env,omitempty"`
+
+	// ReadinessProbes overrides readiness probes for the containers.
+	// +optional
+	ReadinessProbes []ProbesRequirementsOverride `json:"readinessProbes,omitempty"`
+
+	// LivenessProbes overrides liveness probes for the containers.
+	// +optional
+	LivenessProbes []ProbesRequirementsOverride `json:"livenessProbes,omitempty"`
+
+	// HostNetwork overrides hostNetwork for the containers.
+	// When hostNetwork is enabled, this will set dnsPolicy to ClusterFirstWithHostNet automatically for the containers.
+	// +optional
+	HostNetwork *bool `json:"hostNetwork,omitempty"`
+}
+
+// ResourceRequirementsOverride enables the user to override any container's
+// resource requests/limits specified in the embedded manifest
+type ResourceRequirementsOverride struct {
+	// The container name
+	Container string `json:"container"`
+	// The desired ResourceRequirements
+	corev1.ResourceRequirements
+}
+
+// EnvRequirementsOverride enables the user to override any container's env vars.
+type EnvRequirementsOverride struct {
+	// The container name
+	Container string `json:"container"`
+	// The desired EnvVarRequirements
+	EnvVars []corev1.EnvVar `json:"envVars,omitempty"`
+}
+
+// ProbesRequirementsOverride enables the user to override any container's env vars.
+type ProbesRequirementsOverride struct {
+	// The container name
+	Container string `json:"container"`
+	// Number of seconds after the container has started before liveness probes are initiated.
+	// More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes
+	// +optional
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty" protobuf:"varint,2,opt,name=initialDelaySeconds"`
+	// Number of seconds after which the probe times out.
+	// Defaults to 1 second. Minimum value is 1.
+	// More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty" protobuf:"varint,3,opt,name=timeoutSeconds"`
+	// How often (in seconds) to perform the probe.
+	// Default to 10 seconds. Minimum value is 1.
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty" protobuf:"varint,4,opt,name=periodSeconds"`
+	// Minimum consecutive successes for the probe to be considered successful after having failed.
+	// Defaults to 1. Must be 1 for liveness and startup. Minimum value is 1.
+	// +optional
+	SuccessThreshold int32 `json:"successThreshold,omitempty" protobuf:"varint,5,opt,name=successThreshold"`
+	// Minimum consecutive failures for the probe to be considered failed after having succeeded.
+	// Defaults to 3. Minimum value is 1.
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty" protobuf:"varint,6,opt,name=failureThreshold"`
+	// Optional duration in seconds the pod needs to terminate gracefully upon probe failure.
+	// The grace period is the duration in seconds after the processes running in the pod are sent
+	// a termination signal and the time when the processes are forcibly halted with a kill signal.
+	// Set this value longer than the expected cleanup time for your process.
+	// If this value is nil, the pod's terminationGracePeriodSeconds will be used. Otherwise, this
+	// value overrides the value provided by the pod spec.
+	// Value must be non-negative integer. The value zero indicates stop immediately via
+	// the kill signal (no opportunity to shut down).
+	// This is a beta field and requires enabling ProbeTerminationGracePeriod feature gate.
+	// Minimum value is 1. spec.terminationGracePeriodSeconds is used if unset.
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty" protobuf:"varint,7,opt,name=terminationGracePeriodSeconds"`
+}
@@ -0,0 +1,325 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"knative.dev/eventmesh-operator/pkg/apis/operator"
+	"knative.dev/pkg/apis"
+)
+
+func (em *EventMesh) Validate(ctx context.Context) *apis.FieldError {
+	return em.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (spec *EventMeshSpec) Validate(ctx context.Context) *apis.FieldError {
+	var err *apis.FieldError
+
+	err = err.Also(operator.ValidateVersion(ctx, spec.Version))
+
+	if spec.Logging != nil {
+		err = err.Also(spec.Logging.Validate(ctx).ViaField("logging"))
+	}
+
+	err = err.Also(operator.ValidateOneOf(spec.DeletionPolicy, DeletionPolicies, "deletionPolicy"))
+
+	if spec.RevisionHistoryLimit != nil && *spec.RevisionHistoryLimit < 0 {
+		err = err.Also(apis.ErrInvalidValue(*spec.RevisionHistoryLimit, "revisionHistoryLimit", "must not be negative"))
+	}
+
+	if spec.Scaling != nil {
+		err = err.Also(spec.Scaling.Validate(ctx).ViaField("scaling"))
+	}
+
+	if spec.Observability != nil {
+		err = err.Also(spec.Observability.Validate(ctx).ViaField("observability"))
+	}
+
+	err = err.Also(spec.validateBrokerClass(spec.DefaultBroker, "defaultBroker"))
+	err = err.Also(spec.validateChannel(spec.DefaultChannel, "defaultChannel"))
+
+	if spec.Components.IsMTChannelBrokerEnabled() && len(spec.GetEnabledChannelImplementations()) == 0 {
+		err = err.Also(apis.ErrGeneric("the MTChannelBasedBroker requires at least one enabled channel implementation", "components"))
+	}
+
+	// the bootstrap servers are only used for the Kafka broker and channel. KafkaSinks and KafkaSources define them
+	// on the resource itself
+	if (spec.Components.IsKafkaBrokerEnabled() || spec.Components.IsKafkaChannelEnabled()) && len(spec.Kafka.BootstrapServers) == 0 {
+		err = err.Also(apis.ErrMissingField("kafka.bootstrapServers"))
+	}
+
+	err = err.Also(spec.Kafka.Validate(ctx).ViaField("kafka"))
+
+	if spec.Defaults != nil {
+		err = err.Also(spec.validateNamespaceDefaults().ViaField("defaults"))
+	}
+
+	if spec.Features != nil {
+		err = err.Also(spec.Features.Validate(ctx).ViaField("features"))
+	}
+
+	return err
+}
+
+func (spec *EventMeshSpec) validateNamespaceDefaults() *apis.FieldError {
+	var err *apis.FieldError
+
+	namespaces := map[string]bool{}
+	for i, nsDefaults := range spec.Defaults.Namespaces {
+		var nsErr *apis.FieldError
+
+		if nsDefaults.Namespace == "" {
+			nsErr = nsErr.Also(apis.ErrMissingField("namespace"))
+		} else if namespaces[nsDefaults.Namespace] {
+			nsErr = nsErr.Also(apis.ErrGeneric(fmt.Sprintf("duplicate defaults for namespace %s", nsDefaults.Namespace), "namespace"))
+		}
+		namespaces[nsDefaults.Namespace] = true
+
+		nsErr = nsErr.Also(spec.validateBrokerClass(nsDefaults.BrokerClass, "brokerClass"))
+		nsErr = nsErr.Also(spec.validateChannel(nsDefaults.Channel, "channel"))
+
+		if nsDefaults.BrokerConfig != nil && nsDefaults.BrokerConfig.Name == "" {
+			nsErr = nsErr.Also(apis.ErrMissingField("brokerConfig.name"))
+		}
+
+		err = err.Also(nsErr.ViaFieldIndex("namespaces", i))
+	}
+
+	return err
+}
+
+// validateBrokerClass checks that the given broker class is known and enabled
+func (spec *EventMeshSpec) validateBrokerClass(brokerClass, field string) *apis.FieldError {
+	if brokerClass == "" {
+		return nil
+	}
+
+	if err := operator.ValidateOneOf(brokerClass, BrokerClasses, field); err != nil {
+		return err
+	}
+
+	if !slices.Contains(spec.GetEnabledBrokerClasses(), brokerClass) {
+		return apis.ErrGeneric(fmt.Sprintf("broker class %s is disabled in components", brokerClass), field)
+	}
+
+	return nil
+}
+
+// validateChannel checks that the given channel implementation is known and enabled
+func (spec *EventMeshSpec) validateChannel(channel, field string) *apis.FieldError {
+	if channel == "" {
+		return nil
+	}
+
+	if err := operator.ValidateOneOf(channel, ChannelImplementations, field); err != nil {
+		return err
+	}
+
+	if !slices.Contains(spec.GetEnabledChannelImplementations(), channel) {
+		return apis.ErrGeneric(fmt.Sprintf("channel implementation %s is disabled in components", channel), field)
+	}
+
+	return nil
+}
+
+func (kafka *EventMeshSpecKafka) Validate(ctx context.Context) *apis.FieldError {
+	var err *apis.FieldError
+
+	if kafka.AuthSecretRef != nil && kafka.AuthSecretRef.Name == "" {
+		err = err.Also(apis.ErrMissingField("authSecretRef.name"))
+	}
+
+	if kafka.NumPartitions < 0 {
+		err = err.Also(apis.ErrInvalidValue(kafka.NumPartitions, "numPartitions"))
+	}
+
+	if kafka.ReplicationFactor < 0 {
+		err = err.Also(apis.ErrInvalidValue(kafka.ReplicationFactor, "replicationFactor"))
+	}
+
+	if kafka.NamespacedBroker != nil {
+		err = err.Also(kafka.NamespacedBroker.Receiver.Validate(ctx).ViaField("namespacedBroker", "receiver"))
+		err = err.Also(kafka.NamespacedBroker.Dispatcher.Validate(ctx).ViaField("namespacedBroker", "dispatcher"))
+	}
+
+	if kafka.Autoscaling != nil {
+		err = err.Also(kafka.Autoscaling.Validate(ctx).ViaField("autoscaling"))
+	}
+
+	return err
+}
+
+func (as *KafkaAutoscalingSpec) Validate(ctx context.Context) *apis.FieldError {
+	var err *apis.FieldError
+
+	if as.MinScale != nil && *as.MinScale < 0 {
+		err = err.Also(apis.ErrInvalidValue(*as.MinScale, "minScale"))
+	}
+
+	if as.MaxScale != nil && *as.MaxScale < 1 {
+		err = err.Also(apis.ErrInvalidValue(*as.MaxScale, "maxScale"))
+	}
+
+	if as.MinScale != nil && as.MaxScale != nil && *as.MinScale > *as.MaxScale {
+		err = err.Also(apis.ErrGeneric("minScale must not be greater than maxScale", "minScale", "maxScale"))
+	}
+
+	if as.PollingInterval != nil && *as.PollingInterval < 1 {
+		err = err.Also(apis.ErrInvalidValue(*as.PollingInterval, "pollingInterval"))
+	}
+
+	if as.CooldownPeriod != nil && *as.CooldownPeriod < 0 {
+		err = err.Also(apis.ErrInvalidValue(*as.CooldownPeriod, "cooldownPeriod"))
+	}
+
+	if as.LagThreshold != nil && *as.LagThreshold < 1 {
+		err = err.Also(apis.ErrInvalidValue(*as.LagThreshold, "lagThreshold"))
+	}
+
+	return err
+}
+
+func (scaling *EventMeshSpecScaling) Validate(ctx context.Context) *apis.FieldError {
+	var err *apis.FieldError
+
+	if scaling.ObjectsPerReplica != nil && *scaling.ObjectsPerReplica < 1 {
+		err = err.Also(apis.ErrInvalidValue(*scaling.ObjectsPerReplica, "objectsPerReplica"))
+	}
+
+	if scaling.MinReplicas != nil && *scaling.MinReplicas < 1 {
+		err = err.Also(apis.ErrInvalidValue(*scaling.MinReplicas, "minReplicas"))
+	}
+
+	if scaling.MaxReplicas != nil && *scaling.MaxReplicas < 1 {
+		err = err.Also(apis.ErrInvalidValue(*scaling.MaxReplicas, "maxReplicas"))
+	}
+
+	if scaling.MinReplicas != nil && scaling.MaxReplicas != nil && *scaling.MinReplicas > *scaling.MaxReplicas {
+		err = err.Also(apis.ErrGeneric("minReplicas must not be greater than maxReplicas", "minReplicas", "maxReplicas"))
+	}
+
+	if scaling.ScaleDownDelay != nil && scaling.ScaleDownDelay.Duration < 0 {
+		err = err.Also(apis.ErrInvalidValue(scaling.ScaleDownDelay.Duration.String(), "scaleDownDelay", "must not be negative"))
+	}
+
+	return err
+}
+
+func (o *EventMeshSpecObservability) Validate(ctx context.Context) *apis.FieldError {
+	var err *apis.FieldError
+
+	err = err.Also(operator.ValidateOneOf(o.MetricsProtocol, MetricsProtocols, "metricsProtocol"))
+
+	switch o.MetricsProtocol {
+	case ObservabilityProtocolHTTPProtobuf, ObservabilityProtocolGRPC:
+		err = err.Also(operator.ValidateEndpointURL(o.MetricsEndpoint, "metricsEndpoint"))
+	case ObservabilityProtocolPrometheus:
+		// the endpoint is optional and only sets the address to listen on
+		if o.MetricsEndpoint != "" {
+			if _, _, splitErr := net.SplitHostPort(o.MetricsEndpoint); splitErr != nil {
+				err = err.Also(apis.ErrInvalidValue(o.MetricsEndpoint, "metricsEndpoint", "must be host:port"))
+			}
+		}
+	default:
+		if o.MetricsEndpoint != "" {
+			err = err.Also(apis.ErrGeneric("metricsEndpoint must not be set without a metricsProtocol exporting to it", "metricsEndpoint"))
+		}
+	}
+
+	err = err.Also(operator.ValidateOneOf(o.TracingProtocol, TracingProtocols, "tracingProtocol"))
+
+	switch o.TracingProtocol {
+	case ObservabilityProtocolHTTPProtobuf, ObservabilityProtocolGRPC:
+		err = err.Also(operator.ValidateEndpointURL(o.TracingEndpoint, "tracingEndpoint"))
+	default:
+		if o.TracingEndpoint != "" {
+			err = err.Also(apis.ErrGeneric("tracingEndpoint must not be set without a tracingProtocol exporting to it", "tracingEndpoint"))
+		}
+	}
+
+	err = err.Also(operator.ValidateSamplingRate(o.TracingSamplingRate, "tracingSamplingRate"))
+
+	return err
+}
+
+func (l *EventMeshSpecLogging) Validate(ctx context.Context) *apis.FieldError {
+	var err *apis.FieldError
+
+	err = err.Also(operator.ValidateOneOf(l.Level, LogLevels, "level"))
+
+	err = err.Also(operator.ValidateOneOf(l.Format, LogFormats, "format"))
+
+	for name, component := range l.Components {
+		if !slices.Contains(GoComponents, name) && !slices.Contains(KafkaDataPlaneComponents, name) {
+			err = err.Also(apis.ErrInvalidKeyName(name, "components", "must be a Go component or a Kafka data plane"))
+			continue
+		}
+		err = err.Also(component.validate(name).ViaFieldKey("components", name))
+	}
+
+	return err
+}
+
+func (c *EventMeshSpecComponentLogging) validate(name string) *apis.FieldError {
+	var err *apis.FieldError
+
+	err = err.Also(operator.ValidateOneOf(c.Level, LogLevels, "level"))
+
+	// Go components have a single logger per component
+	if len(c.Loggers) > 0 && !slices.Contains(KafkaDataPlaneComponents, name) {
+		err = err.Also(apis.ErrDisallowedFields("loggers"))
+	}
+
+	for logger, level := range c.Loggers {
+		if !slices.Contains(LogLevels, level) {
+			err = err.Also(apis.ErrInvalidValue(level, fmt.Sprintf("loggers[%s]", logger), fmt.Sprintf("must be one of %q", strings.Join(LogLevels, ", "))))
+		}
+	}
+
+	return err
+}
+
+func (dp *DataPlaneSpec) Validate(ctx context.Context) *apis.FieldError {
+	if dp != nil && dp.Replicas != nil && *dp.Replicas < 0 {
+		return apis.ErrInvalidValue(*dp.Replicas, "replicas")
+	}
+
+	return nil
+}
+
+func (features *EventMeshSpecFeatures) Validate(ctx context.Context) *apis.FieldError {
+	var err *apis.FieldError
+
+	if eventing := features.Eventing; eventing != nil {
+		for key, value := range map[string]FeatureFlag{
+			"kreferenceGroup":           eventing.KReferenceGroup,
+			"deliveryRetryAfter":        eventing.DeliveryRetryAfter,
+			"deliveryTimeout":           eventing.DeliveryTimeout,
+			"kreferenceMapping":         eventing.KReferenceMapping,
+			"eventTypeAutoCreate":       eventing.EventTypeAutoCreate,
+			"authenticationOIDC":        eventing.AuthenticationOIDC,
+			"crossNamespaceEventLinks":  eventing.CrossNamespaceEventLinks,
+			"newAPIServerSourceFilters": eventing.NewAPIServerSourceFilters,
+		} {
+			err = err.Also(operator.ValidateOneOf(string(value), FeatureFlags, key).ViaField("eventing"))
+		}
+
+		err = err.Also(operator.ValidateOneOf(string(eventing.TransportEncryption), TransportEncryptionModes, "transportEncryption").ViaField("eventing"))
+		err = err.Also(operator.ValidateOneOf(string(eventing.DefaultAuthorizationMode), AuthorizationModes, "defaultAuthorizationMode").ViaField("eventing"))
+	}
+
+	if ekb := features.EventingKafkaBroker; ekb != nil {
+		for key, value := range map[string]FeatureFlag{
+			"dispatcherRateLimiter":            ekb.DispatcherRateLimiter,
+			"dispatcherOrderedExecutorMetrics": ekb.DispatcherOrderedExecutorMetrics,
+			"controllerAutoscalerKeda":         ekb.ControllerAutoscalerKeda,
+		} {
+			err = err.Also(operator.ValidateOneOf(string(value), FeatureFlags, key).ViaField("eventingKafkaBroker"))
+		}
+	}
+
+	return err
+}
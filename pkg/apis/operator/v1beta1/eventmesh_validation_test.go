@@ -0,0 +1,242 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"knative.dev/eventmesh-operator/pkg/apis/operator"
+	"knative.dev/pkg/apis"
+)
+
+func TestEventMeshSpecValidationLogging(t *testing.T) {
+	tests := []struct {
+		name string
+		em   *EventMesh
+		want *apis.FieldError
+	}{
+		{
+			name: "valid log level",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					Logging: &EventMeshSpecLogging{
+						Level: LogLevelDebug,
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+		{
+			name: "invalid, unknown log level",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					Logging: &EventMeshSpecLogging{
+						Level: "ultra",
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue("ultra", "spec.logging.level", fmt.Sprintf("must be one of %q", strings.Join(LogLevels, ", ")))
+			}(),
+		},
+		{
+			name: "invalid, logging format and per component levels",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					Logging: &EventMeshSpecLogging{
+						Format: "xml",
+						Components: map[string]EventMeshSpecComponentLogging{
+							"controller": {
+								Level:   "ultra",
+								Loggers: map[string]string{"knative.dev": LogLevelDebug},
+							},
+							"kafka-broker-dispatcher": {
+								Loggers: map[string]string{"org.apache.kafka": "ultra"},
+							},
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue("xml", "spec.logging.format", `must be one of "json, console"`).
+					Also(apis.ErrInvalidValue("ultra", "spec.logging.components[controller].level", fmt.Sprintf("must be one of %q", strings.Join(LogLevels, ", ")))).
+					Also(apis.ErrDisallowedFields("spec.logging.components[controller].loggers")).
+					Also(apis.ErrInvalidValue("ultra", "spec.logging.components[kafka-broker-dispatcher].loggers[org.apache.kafka]", fmt.Sprintf("must be one of %q", strings.Join(LogLevels, ", "))))
+			}(),
+		},
+		{
+			name: "invalid, unknown component",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					Logging: &EventMeshSpecLogging{
+						Components: map[string]EventMeshSpecComponentLogging{
+							"mt_broker_filter":      {Level: LogLevelDebug},
+							"eventing-controller":   {Level: LogLevelDebug},
+							"kafka-sink-dispatcher": {Loggers: map[string]string{"org.apache.kafka": LogLevelDebug}},
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidKeyName("eventing-controller", "spec.logging.components", "must be a Go component or a Kafka data plane").
+					Also(apis.ErrInvalidKeyName("kafka-sink-dispatcher", "spec.logging.components", "must be a Go component or a Kafka data plane"))
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := apis.WithinCreate(context.TODO())
+			got := test.em.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: Validate EventMeshSpec (-want, +got) = %v", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestEventMeshSpecValidationFeatures(t *testing.T) {
+	tests := []struct {
+		name string
+		em   *EventMesh
+		want *apis.FieldError
+	}{
+		{
+			name: "valid feature flags",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					Features: &EventMeshSpecFeatures{
+						Eventing: &EventingFeatures{
+							KReferenceGroup:     FeatureEnabled,
+							TransportEncryption: "strict",
+						},
+						EventingKafkaBroker: &EventingKafkaBrokerFeatures{
+							DispatcherRateLimiter: FeatureDisabled,
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+		{
+			name: "invalid, unknown feature flag values",
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+					Features: &EventMeshSpecFeatures{
+						Eventing: &EventingFeatures{
+							DeliveryTimeout:          "maybe",
+							DefaultAuthorizationMode: "allow-some",
+						},
+						EventingKafkaBroker: &EventingKafkaBrokerFeatures{
+							ControllerAutoscalerKeda: "maybe",
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue("maybe", "spec.features.eventing.deliveryTimeout", fmt.Sprintf("must be one of %q", strings.Join(FeatureFlags, ", "))).
+					Also(apis.ErrInvalidValue("allow-some", "spec.features.eventing.defaultAuthorizationMode", fmt.Sprintf("must be one of %q", strings.Join(AuthorizationModes, ", ")))).
+					Also(apis.ErrInvalidValue("maybe", "spec.features.eventingKafkaBroker.controllerAutoscalerKeda", fmt.Sprintf("must be one of %q", strings.Join(FeatureFlags, ", "))))
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := apis.WithinCreate(context.TODO())
+			got := test.em.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: Validate EventMeshSpec (-want, +got) = %v", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestEventMeshSpecValidationVersion(t *testing.T) {
+	versions := []string{"1.19", "1.19.2"}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		em   *EventMesh
+		want *apis.FieldError
+	}{
+		{
+			name: "valid version",
+			ctx:  operator.WithAvailableVersions(context.TODO(), versions),
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Version: "1.19",
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+		{
+			name: "invalid, unknown version",
+			ctx:  operator.WithAvailableVersions(context.TODO(), versions),
+			em: &EventMesh{
+				Spec: EventMeshSpec{
+					Version: "1.18",
+					Kafka: EventMeshSpecKafka{
+						BootstrapServers: []string{
+							"server-1",
+						},
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue("1.18", "spec.version", fmt.Sprintf("must be one of %q", strings.Join(versions, ", ")))
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := apis.WithinCreate(test.ctx)
+			got := test.em.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: Validate EventMeshSpec (-want, +got) = %v", test.name, diff)
+			}
+		})
+	}
+}
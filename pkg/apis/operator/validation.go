@@ -0,0 +1,58 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"knative.dev/pkg/apis"
+)
+
+// ValidateVersion checks that the version is one of the versions bundled with the operator. Any version is accepted,
+// if the available versions are not set on the context.
+func ValidateVersion(ctx context.Context, version string) *apis.FieldError {
+	if versions := GetAvailableVersions(ctx); versions != nil {
+		return ValidateOneOf(version, versions, "version")
+	}
+
+	return nil
+}
+
+// ValidateOneOf checks that the value is empty or one of the allowed values
+func ValidateOneOf(value string, allowed []string, field string) *apis.FieldError {
+	if value != "" && !slices.Contains(allowed, value) {
+		return apis.ErrInvalidValue(value, field, fmt.Sprintf("must be one of %q", strings.Join(allowed, ", ")))
+	}
+
+	return nil
+}
+
+// ValidateEndpointURL validates that the OTLP endpoint is set to an absolute http or https URL
+func ValidateEndpointURL(endpoint, field string) *apis.FieldError {
+	if endpoint == "" {
+		return apis.ErrMissingField(field)
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apis.ErrInvalidValue(endpoint, field, "must be an absolute http or https URL")
+	}
+
+	return nil
+}
+
+// ValidateSamplingRate validates that the sampling rate is empty or a number between 0 and 1
+func ValidateSamplingRate(rate, field string) *apis.FieldError {
+	if rate == "" {
+		return nil
+	}
+
+	if r, err := strconv.ParseFloat(rate, 64); err != nil || r < 0 || r > 1 {
+		return apis.ErrInvalidValue(rate, field, "must be a number between 0 and 1")
+	}
+
+	return nil
+}
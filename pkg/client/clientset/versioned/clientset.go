@@ -26,17 +26,20 @@ import (
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
 	operatorv1alpha1 "knative.dev/eventmesh-operator/pkg/client/clientset/versioned/typed/operator/v1alpha1"
+	operatorv1beta1 "knative.dev/eventmesh-operator/pkg/client/clientset/versioned/typed/operator/v1beta1"
 )
 
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	OperatorV1alpha1() operatorv1alpha1.OperatorV1alpha1Interface
+	OperatorV1beta1() operatorv1beta1.OperatorV1beta1Interface
 }
 
 // Clientset contains the clients for groups.
 type Clientset struct {
 	*discovery.DiscoveryClient
 	operatorV1alpha1 *operatorv1alpha1.OperatorV1alpha1Client
+	operatorV1beta1  *operatorv1beta1.OperatorV1beta1Client
 }
 
 // OperatorV1alpha1 retrieves the OperatorV1alpha1Client
@@ -44,6 +47,11 @@ func (c *Clientset) OperatorV1alpha1() operatorv1alpha1.OperatorV1alpha1Interfac
 	return c.operatorV1alpha1
 }
 
+// OperatorV1beta1 retrieves the OperatorV1beta1Client
+func (c *Clientset) OperatorV1beta1() operatorv1beta1.OperatorV1beta1Interface {
+	return c.operatorV1beta1
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -92,6 +100,10 @@ func NewForConfigAndClient(c *rest.Config, httpClient *http.Client) (*Clientset,
 	if err != nil {
 		return nil, err
 	}
+	cs.operatorV1beta1, err = operatorv1beta1.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
@@ -114,6 +126,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.operatorV1alpha1 = operatorv1alpha1.New(c)
+	cs.operatorV1beta1 = operatorv1beta1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
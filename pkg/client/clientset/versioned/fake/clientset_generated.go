@@ -28,6 +28,8 @@ import (
 	clientset "knative.dev/eventmesh-operator/pkg/client/clientset/versioned"
 	operatorv1alpha1 "knative.dev/eventmesh-operator/pkg/client/clientset/versioned/typed/operator/v1alpha1"
 	fakeoperatorv1alpha1 "knative.dev/eventmesh-operator/pkg/client/clientset/versioned/typed/operator/v1alpha1/fake"
+	operatorv1beta1 "knative.dev/eventmesh-operator/pkg/client/clientset/versioned/typed/operator/v1beta1"
+	fakeoperatorv1beta1 "knative.dev/eventmesh-operator/pkg/client/clientset/versioned/typed/operator/v1beta1/fake"
 )
 
 // NewSimpleClientset returns a clientset that will respond with the provided objects.
@@ -92,3 +94,8 @@ var (
 func (c *Clientset) OperatorV1alpha1() operatorv1alpha1.OperatorV1alpha1Interface {
 	return &fakeoperatorv1alpha1.FakeOperatorV1alpha1{Fake: &c.Fake}
 }
+
+// OperatorV1beta1 retrieves the OperatorV1beta1Client
+func (c *Clientset) OperatorV1beta1() operatorv1beta1.OperatorV1beta1Interface {
+	return &fakeoperatorv1beta1.FakeOperatorV1beta1{Fake: &c.Fake}
+}
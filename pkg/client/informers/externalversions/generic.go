@@ -24,6 +24,7 @@ import (
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 	v1alpha1 "knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	v1beta1 "knative.dev/eventmesh-operator/pkg/apis/operator/v1beta1"
 )
 
 // GenericInformer is type of SharedIndexInformer which will locate and delegate to other
@@ -56,6 +57,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	case v1alpha1.SchemeGroupVersion.WithResource("eventmeshes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1alpha1().EventMeshes().Informer()}, nil
 
+		// Group=operator.knative.dev, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("eventmeshes"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().EventMeshes().Informer()}, nil
+
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
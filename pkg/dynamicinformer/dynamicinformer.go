@@ -8,7 +8,10 @@ import (
 	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -20,15 +23,57 @@ import (
 // DynamicInformer creates an informer dynamically. This allows for example to "use" its lister, before the CRD of this
 // type got created. This helps for example the operator to "use" the lister of the InMemoryChannel before the CRD got
 // applied.
+// The informer is started asynchronously, so starting it doesn't block the caller (e.g. the event handler of the CRD
+// informer), while it waits for the CRD to be established and for the cache to be synced. Its progress can be observed
+// via State.
 type DynamicInformer[T any, Lister SimpleLister[T]] struct {
-	cancel      atomic.Pointer[context.CancelFunc]
 	lister      atomic.Pointer[Lister]
 	factoryFunc FactoryFunc[T, Lister]
 	crdName     string
-	mu          sync.Mutex
+	// backoff is the backoff, with which failures to create the informer are retried
+	backoff wait.Backoff
+
+	mu sync.Mutex
+	// cancel stops the started informer. It is nil, as long as the informer is not started.
+	cancel context.CancelFunc
+	state  State
+	err    error
 }
 
-type FactoryFunc[T any, Lister SimpleLister[T]] func(ctx context.Context) (SharedInformerFactory, Informer[Lister])
+// State is the state of a DynamicInformer
+type State int
+
+const (
+	// StateStopped means the informer is not started (e.g. because the CRD is not installed) or it got stopped
+	StateStopped State = iota
+	// StateStarting means the informer waits for the CRD to be established or for its cache to be synced
+	StateStarting
+	// StateSynced means the cache of the informer is synced, so its lister lists all objects
+	StateSynced
+	// StateFailed means the informer failed to start. Failures to create the informer are retried with a backoff and
+	// failures while waiting for the cache to be synced by the informer itself. Other failures are retried by starting
+	// the informer again.
+	StateFailed
+)
+
+func (s State) String() string {
+	switch s {
+	case StateStopped:
+		return "Stopped"
+	case StateStarting:
+		return "Starting"
+	case StateSynced:
+		return "Synced"
+	case StateFailed:
+		return "Failed"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// FactoryFunc creates the informer for the given CRD. It is called, when the CRD is established, so the informer can be
+// created for one of the versions served by the CRD.
+type FactoryFunc[T any, Lister SimpleLister[T]] func(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition) (SharedInformerFactory, Informer[Lister], error)
 
 type Informer[Lister any] interface {
 	Informer() cache.SharedIndexInformer
@@ -48,86 +93,217 @@ type SimpleLister[T any] interface {
 type EventHandlerFunc[T any, Lister SimpleLister[T]] func(ctx context.Context, informer Informer[Lister]) cache.ResourceEventHandler
 
 func New[T any, Lister SimpleLister[T]](crdName string, factoryFunc FactoryFunc[T, Lister]) *DynamicInformer[T, Lister] {
-	return &DynamicInformer[T, Lister]{
-		cancel:      atomic.Pointer[context.CancelFunc]{},
+	di := &DynamicInformer[T, Lister]{
 		lister:      atomic.Pointer[Lister]{},
 		mu:          sync.Mutex{},
 		factoryFunc: factoryFunc,
 		crdName:     crdName,
+		backoff: wait.Backoff{
+			Duration: time.Second,
+			Factor:   2,
+			Jitter:   0.1,
+			Steps:    10,
+			Cap:      5 * time.Minute,
+		},
+	}
+
+	if err := observeObjects(informerMetrics, di); err != nil {
+		// metrics are not essential for the informer to work
+		otel.Handle(fmt.Errorf("failed to observe objects of %s: %w", crdName, err))
 	}
+
+	return di
 }
 
-// SetupInformerAndRegisterEventHandler setups the informer and registers an EventHandler for it.
-// The eventHandlerFunc gets the created informer passed, which allows for more dynamic event handling.
-func (di *DynamicInformer[T, Lister]) SetupInformerAndRegisterEventHandler(ctx context.Context, eventHandlerFn EventHandlerFunc[T, Lister]) error {
-	logger := logging.FromContext(ctx).With(zap.String("component", "DynamicInformer"), zap.String("resource", di.crdName))
+// Start starts the informer in the background and registers an EventHandler for it, once the CRD is established.
+// The eventHandlerFunc gets the created informer passed, which allows for more dynamic event handling. onSynced is
+// called, when the cache of the informer is synced.
+// An informer, which is already started, is left untouched, unless it failed to start.
+func (di *DynamicInformer[T, Lister]) Start(ctx context.Context, eventHandlerFn EventHandlerFunc[T, Lister], onSynced func()) {
+	di.mu.Lock()
+	defer di.mu.Unlock()
+
+	if di.cancel != nil && di.state != StateFailed {
+		logging.FromContext(ctx).Debugw("Dynamic informer already started, skipping start", zap.String("resource", di.crdName))
+		return
+	}
 
+	di.stop()
+	di.start(ctx, eventHandlerFn, onSynced)
+}
+
+// Restart stops the informer, if it is started, and starts it again. This is needed, when the versions of the CRD
+// change, as the informer watches one specific version.
+func (di *DynamicInformer[T, Lister]) Restart(ctx context.Context, eventHandlerFn EventHandlerFunc[T, Lister], onSynced func()) {
 	di.mu.Lock()
 	defer di.mu.Unlock()
 
-	if di.cancel.Load() != nil {
-		logger.Debug("Cancel function already loaded, skipping start")
-		return nil
+	di.stop()
+	di.start(ctx, eventHandlerFn, onSynced)
+}
+
+func (di *DynamicInformer[T, Lister]) Stop(ctx context.Context) {
+	di.mu.Lock()
+	defer di.mu.Unlock()
+
+	if di.cancel == nil {
+		logging.FromContext(ctx).Debug("Dynamic informer has not been started, nothing to stop")
+		return
 	}
 
-	ctx, cancel := context.WithCancel(ctx)
-	factory, informer := di.factoryFunc(ctx)
+	di.stop()
+}
 
-	informer.Informer().AddEventHandler(eventHandlerFn(ctx, informer))
+// State returns the state of the informer and the error, which made it fail
+func (di *DynamicInformer[T, Lister]) State() (State, error) {
+	di.mu.Lock()
+	defer di.mu.Unlock()
 
-	err := wait.PollUntilContextCancel(ctx, time.Second, false, func(ctx context.Context) (done bool, err error) {
-		logger.Debugf("Waiting for %s CRD to be installed", di.crdName)
-		isCRDInstalled, err := di.isCRDInstalled(ctx)
+	return di.state, di.err
+}
+
+func (di *DynamicInformer[T, Lister]) Lister() *atomic.Pointer[Lister] {
+	return &di.lister
+}
+
+// start starts the informer in the background. It must be called with the lock held.
+func (di *DynamicInformer[T, Lister]) start(ctx context.Context, eventHandlerFn EventHandlerFunc[T, Lister], onSynced func()) {
+	logger := logging.FromContext(ctx).With(zap.String("component", "DynamicInformer"), zap.String("resource", di.crdName))
+
+	ctx, cancel := context.WithCancel(logging.WithLogger(ctx, logger))
+	di.cancel = cancel
+	di.state, di.err = StateStarting, nil
+
+	go func() {
+		if err := di.run(ctx, eventHandlerFn); err != nil {
+			logger.Errorw("Failed to start dynamic informer", zap.Error(err))
+			di.setState(ctx, StateFailed, err)
+			return
+		}
+
+		if ctx.Err() == nil {
+			logger.Debug("Dynamic informer synced")
+			onSynced()
+		}
+	}()
+}
+
+// stop stops the informer. It must be called with the lock held.
+func (di *DynamicInformer[T, Lister]) stop() {
+	if di.cancel != nil {
+		di.cancel()
+	}
+
+	di.cancel = nil
+	di.lister.Store(nil)
+	di.state, di.err = StateStopped, nil
+}
+
+// run waits for the CRD to be established, starts the informer and waits for its cache to be synced. It returns, when
+// the informer is synced, when it fails to start or when it gets stopped.
+func (di *DynamicInformer[T, Lister]) run(ctx context.Context, eventHandlerFn EventHandlerFunc[T, Lister]) error {
+	logger := logging.FromContext(ctx)
+
+	var crd *apiextensionsv1.CustomResourceDefinition
+	err := wait.PollUntilContextCancel(ctx, time.Second, true, func(ctx context.Context) (done bool, err error) {
+		logger.Debugf("Waiting for %s CRD to be established", di.crdName)
+		crd, err = client.Get(ctx).ApiextensionsV1().CustomResourceDefinitions().Get(ctx, di.crdName, metav1.GetOptions{})
 		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				// keep polling, but let the failure be observed
+				di.setState(ctx, StateFailed, fmt.Errorf("failed to get %s CRD: %w", di.crdName, err))
+			}
 			return false, nil
 		}
-		return isCRDInstalled, nil
+
+		di.setState(ctx, StateStarting, nil)
+		return isEstablished(crd), nil
 	})
 	if err != nil {
-		defer cancel()
-		return fmt.Errorf("could not check if CRD is installed: %w", err)
+		// the informer got stopped
+		return nil
+	}
+
+	factory, informer, ok := di.createInformer(ctx, crd)
+	if !ok {
+		// the informer got stopped
+		return nil
+	}
+
+	if _, err := informer.Informer().AddEventHandler(eventHandlerFn(ctx, informer)); err != nil {
+		return fmt.Errorf("failed to register event handler: %w", err)
+	}
+
+	// failures to list and watch the objects are retried by the informer, until it is synced
+	err = informer.Informer().SetWatchErrorHandlerWithContext(func(ctx context.Context, r *cache.Reflector, err error) {
+		cache.DefaultWatchErrorHandler(ctx, r, err)
+		if !informer.Informer().HasSynced() {
+			di.setState(ctx, StateFailed, fmt.Errorf("failed to list and watch %s: %w", di.crdName, err))
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register watch error handler: %w", err)
 	}
 
 	factory.Start(ctx.Done())
 
 	if !cache.WaitForCacheSync(ctx.Done(), informer.Informer().HasSynced) {
-		defer cancel()
-		logger.Error("Failed to sync dynamic informer cache")
-		return fmt.Errorf("failed to sync dynamic informer")
+		// the informer got stopped
+		return nil
 	}
 
-	lister := informer.Lister()
-	di.lister.Store(&lister)
-	di.cancel.Store(&cancel) // Cancel is always set as last field since it's used as a "guard".
+	di.mu.Lock()
+	defer di.mu.Unlock()
+
+	// an informer, which got stopped or restarted meanwhile, must not override the state of the current one
+	if ctx.Err() == nil {
+		lister := informer.Lister()
+		di.lister.Store(&lister)
+		di.state, di.err = StateSynced, nil
+	}
 
 	return nil
 }
 
-func (di *DynamicInformer[T, Lister]) isCRDInstalled(ctx context.Context) (bool, error) {
-	apiExtensionsClient := client.Get(ctx)
+// createInformer creates the informer via the factory. Failures are retried with a backoff, while the informer is
+// Failed. Returns false, when the informer got stopped before it was created.
+func (di *DynamicInformer[T, Lister]) createInformer(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition) (SharedInformerFactory, Informer[Lister], bool) {
+	backoff := di.backoff
+	for {
+		factory, informer, err := di.factoryFunc(ctx, crd)
+		if err == nil {
+			di.setState(ctx, StateStarting, nil)
+			return factory, informer, true
+		}
 
-	_, err := apiExtensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, di.crdName, metav1.GetOptions{})
-	if err != nil {
-		return false, fmt.Errorf("failed to get %s CRD: %w", di.crdName, err)
-	}
-	return true, nil
-}
+		delay := backoff.Step()
+		logging.FromContext(ctx).Warnw("Failed to create informer, retrying", zap.Duration("delay", delay), zap.Error(err))
+		di.setState(ctx, StateFailed, fmt.Errorf("failed to create informer: %w", err))
 
-func (di *DynamicInformer[T, Lister]) Stop(ctx context.Context) {
-	cancel := di.cancel.Load()
-	if cancel == nil {
-		logging.FromContext(ctx).Debug("Dynamic informer has not been started, nothing to stop")
-		return
+		select {
+		case <-ctx.Done():
+			return nil, nil, false
+		case <-time.After(delay):
+		}
 	}
-
-	(*cancel)()
-	di.lister.Store(nil)
-	di.cancel.Store(nil) // Cancel is always set as last field since it's used as a "guard".
 }
 
-func (di *DynamicInformer[T, Lister]) Lister() *atomic.Pointer[Lister] {
+// setState sets the state of the informer, unless it got stopped or restarted meanwhile
+func (di *DynamicInformer[T, Lister]) setState(ctx context.Context, state State, err error) {
 	di.mu.Lock()
 	defer di.mu.Unlock()
 
-	return &di.lister
+	if ctx.Err() == nil {
+		di.state, di.err = state, err
+	}
+}
+
+func isEstablished(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	for _, cond := range crd.Status.Conditions {
+		if cond.Type == apiextensionsv1.Established {
+			return cond.Status == apiextensionsv1.ConditionTrue
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,242 @@
+package dynamicinformer
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/metadata/metadatalister"
+	"k8s.io/client-go/tools/cache"
+	fakeapiextensionsclient "knative.dev/pkg/client/injection/apiextensions/client/fake"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+const brokerCRDName = "brokers.eventing.knative.dev"
+
+var brokerGVR = schema.GroupVersionResource{Group: "eventing.knative.dev", Version: "v1", Resource: "brokers"}
+
+func TestDynamicInformerStates(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+
+	factory := &fakeFactory{objs: []metav1.PartialObjectMetadata{broker("broker-1"), broker("broker-2")}}
+	di := New(brokerCRDName, factory.create)
+
+	var synced atomic.Int32
+	di.Start(ctx, noopHandler, func() { synced.Add(1) })
+
+	// the informer waits for the CRD to be installed
+	assertState(t, di, StateStarting)
+	createCRD(ctx, t)
+	waitForState(t, di, StateSynced)
+
+	if synced.Load() != 1 {
+		t.Errorf("onSynced called %d times, want 1", synced.Load())
+	}
+	objs, err := (*di.Lister().Load()).List(labels.Everything())
+	if err != nil || len(objs) != 2 {
+		t.Errorf("List() = %d objects, %v, want 2 objects", len(objs), err)
+	}
+
+	// starting a started informer leaves it untouched
+	di.Start(ctx, noopHandler, func() { synced.Add(1) })
+	assertState(t, di, StateSynced)
+	if got := factory.calls.Load(); got != 1 {
+		t.Errorf("factory called %d times, want 1", got)
+	}
+
+	di.Stop(ctx)
+	assertState(t, di, StateStopped)
+	if di.Lister().Load() != nil {
+		t.Error("Lister() of stopped informer is set, want nil")
+	}
+}
+
+func TestDynamicInformerRestartIgnoresStaleRun(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	createCRD(ctx, t)
+
+	// the first run is blocked in the factory and fails, once the informer got restarted
+	release := make(chan struct{})
+	factory := &fakeFactory{}
+	var calls atomic.Int32
+	di := New(brokerCRDName, func(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition) (SharedInformerFactory, Informer[metadatalister.Lister], error) {
+		if calls.Add(1) == 1 {
+			<-release
+			return nil, nil, errors.New("stale")
+		}
+		return factory.create(ctx, crd)
+	})
+
+	di.Start(ctx, noopHandler, func() {})
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		return calls.Load() == 1, nil
+	}); err != nil {
+		t.Fatal("factory was not called")
+	}
+
+	di.Restart(ctx, noopHandler, func() {})
+	waitForState(t, di, StateSynced)
+
+	close(release)
+	time.Sleep(50 * time.Millisecond)
+	assertState(t, di, StateSynced)
+	if di.Lister().Load() == nil {
+		t.Error("Lister() = nil, want the lister of the restarted informer")
+	}
+}
+
+func TestDynamicInformerWatchErrors(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	createCRD(ctx, t)
+
+	factory := &fakeFactory{}
+	factory.failList.Store(true)
+	di := New(brokerCRDName, factory.create)
+
+	di.Start(ctx, noopHandler, func() {})
+	waitForState(t, di, StateFailed)
+	if _, err := di.State(); err == nil {
+		t.Error("State() error = nil, want the watch error")
+	}
+
+	// a failed informer is started again
+	factory.failList.Store(false)
+	di.Start(ctx, noopHandler, func() {})
+	waitForState(t, di, StateSynced)
+	if got := factory.calls.Load(); got != 2 {
+		t.Errorf("factory called %d times, want 2", got)
+	}
+}
+
+func TestDynamicInformerRetriesFactoryErrors(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	createCRD(ctx, t)
+
+	factory := &fakeFactory{}
+	failures := make(chan struct{})
+	var calls atomic.Int32
+	di := New(brokerCRDName, func(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition) (SharedInformerFactory, Informer[metadatalister.Lister], error) {
+		if calls.Add(1) == 1 {
+			return nil, nil, errors.New("no version served")
+		}
+		<-failures
+		return factory.create(ctx, crd)
+	})
+	di.backoff = wait.Backoff{Duration: 10 * time.Millisecond, Factor: 1, Steps: 1}
+
+	di.Start(ctx, noopHandler, func() {})
+	waitForState(t, di, StateFailed)
+
+	// the factory is retried without starting the informer again
+	close(failures)
+	waitForState(t, di, StateSynced)
+	if got := calls.Load(); got != 2 {
+		t.Errorf("factory called %d times, want 2", got)
+	}
+}
+
+func noopHandler(context.Context, Informer[metadatalister.Lister]) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{}
+}
+
+func assertState(t *testing.T, di *DynamicInformer[metav1.PartialObjectMetadata, metadatalister.Lister], want State) {
+	t.Helper()
+	if got, err := di.State(); got != want {
+		t.Errorf("State() = %s (%v), want %s", got, err, want)
+	}
+}
+
+func waitForState(t *testing.T, di *DynamicInformer[metav1.PartialObjectMetadata, metadatalister.Lister], want State) {
+	t.Helper()
+	err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 10*time.Second, true, func(context.Context) (bool, error) {
+		state, _ := di.State()
+		return state == want, nil
+	})
+	if err != nil {
+		got, stateErr := di.State()
+		t.Fatalf("State() = %s (%v), want %s", got, stateErr, want)
+	}
+}
+
+func createCRD(ctx context.Context, t *testing.T) {
+	t.Helper()
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: brokerCRDName},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group:    brokerGVR.Group,
+			Names:    apiextensionsv1.CustomResourceDefinitionNames{Plural: brokerGVR.Resource},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: brokerGVR.Version, Served: true, Storage: true}},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{{
+				Type:   apiextensionsv1.Established,
+				Status: apiextensionsv1.ConditionTrue,
+			}},
+		},
+	}
+	if _, err := fakeapiextensionsclient.Get(ctx).ApiextensionsV1().CustomResourceDefinitions().Create(ctx, crd, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create CRD: %v", err)
+	}
+}
+
+func broker(name string) metav1.PartialObjectMetadata {
+	return metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+}
+
+// fakeFactory creates informers, which list the given objects or fail, while failList is set
+type fakeFactory struct {
+	objs     []metav1.PartialObjectMetadata
+	failList atomic.Bool
+	calls    atomic.Int32
+}
+
+func (f *fakeFactory) create(_ context.Context, crd *apiextensionsv1.CustomResourceDefinition) (SharedInformerFactory, Informer[metadatalister.Lister], error) {
+	f.calls.Add(1)
+
+	gvr, err := servedResource(crd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	informer := cache.NewSharedIndexInformer(&cache.ListWatch{
+		ListFunc: func(metav1.ListOptions) (runtime.Object, error) {
+			if f.failList.Load() {
+				return nil, errors.New("forbidden")
+			}
+			return &metav1.PartialObjectMetadataList{Items: f.objs}, nil
+		},
+		WatchFunc: func(metav1.ListOptions) (watch.Interface, error) {
+			return watch.NewFake(), nil
+		},
+	}, &metav1.PartialObjectMetadata{}, 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	return &fakeSharedInformerFactory{informer: informer}, metadataInformer{informer: informer, gvr: gvr}, nil
+}
+
+type fakeSharedInformerFactory struct {
+	informer cache.SharedIndexInformer
+	once     sync.Once
+}
+
+func (f *fakeSharedInformerFactory) Start(stopCh <-chan struct{}) {
+	f.once.Do(func() {
+		go f.informer.Run(stopCh)
+	})
+}
+
+func (f *fakeSharedInformerFactory) Shutdown() {}
+
+func (f *fakeSharedInformerFactory) WaitForCacheSync(stopCh <-chan struct{}) map[reflect.Type]bool {
+	return map[reflect.Type]bool{reflect.TypeOf(&metav1.PartialObjectMetadata{}): cache.WaitForCacheSync(stopCh, f.informer.HasSynced)}
+}
@@ -0,0 +1,91 @@
+package dynamicinformer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/metadata/metadatalister"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
+)
+
+// ForMetadata returns a FactoryFunc, which creates informers for the metadata of the objects of the CRD. Only the
+// metadata of the objects is cached, which saves memory, when the objects are only counted or filtered by their labels
+// or annotations. It also allows to watch resources without typed clients, e.g. the resources of
+// eventing-kafka-broker.
+func ForMetadata() FactoryFunc[metav1.PartialObjectMetadata, metadatalister.Lister] {
+	return func(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition) (SharedInformerFactory, Informer[metadatalister.Lister], error) {
+		gvr, err := servedResource(crd)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		metadataClient, err := metadata.NewForConfig(injection.GetConfig(ctx))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create metadata client: %w", err)
+		}
+
+		factory := metadatainformer.NewSharedInformerFactory(metadataClient, controller.GetResyncPeriod(ctx))
+		informer := factory.ForResource(gvr)
+
+		return metadataInformerFactory{factory}, metadataInformer{informer: informer.Informer(), gvr: gvr}, nil
+	}
+}
+
+// servedResource returns the resource of the CRD in its storage version, if it is served, or in its first served version
+// otherwise
+func servedResource(crd *apiextensionsv1.CustomResourceDefinition) (schema.GroupVersionResource, error) {
+	var served []string
+	for _, version := range crd.Spec.Versions {
+		if !version.Served {
+			continue
+		}
+		if version.Storage {
+			served = append([]string{version.Name}, served...)
+		} else {
+			served = append(served, version.Name)
+		}
+	}
+
+	if len(served) == 0 {
+		return schema.GroupVersionResource{}, fmt.Errorf("no version of %s is served", crd.Name)
+	}
+
+	return schema.GroupVersionResource{Group: crd.Spec.Group, Version: served[0], Resource: crd.Spec.Names.Plural}, nil
+}
+
+// metadataInformerFactory adapts the metadata SharedInformerFactory to the SharedInformerFactory interface
+type metadataInformerFactory struct {
+	metadatainformer.SharedInformerFactory
+}
+
+func (f metadataInformerFactory) WaitForCacheSync(stopCh <-chan struct{}) map[reflect.Type]bool {
+	// all informers of the factory share the same type
+	synced := true
+	for _, ok := range f.SharedInformerFactory.WaitForCacheSync(stopCh) {
+		synced = synced && ok
+	}
+
+	return map[reflect.Type]bool{reflect.TypeOf(&metav1.PartialObjectMetadata{}): synced}
+}
+
+// metadataInformer adapts the metadata informer to the Informer interface
+type metadataInformer struct {
+	informer cache.SharedIndexInformer
+	gvr      schema.GroupVersionResource
+}
+
+func (i metadataInformer) Informer() cache.SharedIndexInformer {
+	return i.informer
+}
+
+func (i metadataInformer) Lister() metadatalister.Lister {
+	return metadatalister.New(i.informer.GetIndexer(), i.gvr)
+}
@@ -0,0 +1,88 @@
+package dynamicinformer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/observability/attributekey"
+)
+
+const scopeName = "knative.dev/eventmesh-operator/pkg/dynamicinformer"
+
+// ResourceAttr is the name of the CRD, whose objects are observed by the informer
+var ResourceAttr = attributekey.String("kn.eventmesh.dynamicinformer.resource")
+
+// StateAttr is the state of the informer
+var StateAttr = attributekey.String("kn.eventmesh.dynamicinformer.state")
+
+type metrics struct {
+	meter   metric.Meter
+	objects metric.Int64ObservableGauge
+	state   metric.Int64ObservableGauge
+}
+
+var informerMetrics = newMetrics(otel.GetMeterProvider())
+
+func newMetrics(provider metric.MeterProvider) *metrics {
+	var (
+		m   metrics
+		err error
+	)
+
+	m.meter = provider.Meter(scopeName)
+
+	m.objects, err = m.meter.Int64ObservableGauge(
+		"kn.eventmesh.dynamicinformer.objects",
+		metric.WithDescription("The number of objects in the cache of the dynamic informer."),
+		metric.WithUnit("{object}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	m.state, err = m.meter.Int64ObservableGauge(
+		"kn.eventmesh.dynamicinformer.state",
+		metric.WithDescription("Whether the dynamic informer is in the state given by the state attribute (1) or not (0)."),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return &m
+}
+
+var states = []State{StateStopped, StateStarting, StateSynced, StateFailed}
+
+// observeObjects registers a callback, which reports the state of the given informer and the number of objects in its
+// cache, as long as the informer is synced
+func observeObjects[T any, Lister SimpleLister[T]](m *metrics, di *DynamicInformer[T, Lister]) error {
+	attrs := metric.WithAttributes(ResourceAttr.With(di.crdName))
+
+	_, err := m.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		current, _ := di.State()
+		for _, state := range states {
+			var value int64
+			if state == current {
+				value = 1
+			}
+			o.ObserveInt64(m.state, value, metric.WithAttributes(ResourceAttr.With(di.crdName), StateAttr.With(state.String())))
+		}
+
+		lister := di.lister.Load()
+		if lister == nil {
+			return nil
+		}
+
+		objs, err := (*lister).List(labels.Everything())
+		if err != nil {
+			return err
+		}
+
+		o.ObserveInt64(m.objects, int64(len(objs)), attrs)
+		return nil
+	}, m.objects, m.state)
+
+	return err
+}
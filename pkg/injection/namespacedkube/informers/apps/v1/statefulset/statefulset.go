@@ -0,0 +1,36 @@
+// Package statefulset provides the injected informer of the StatefulSets in the system namespace. The operator installs
+// all of its StatefulSets there, so they are not watched in the whole cluster.
+package statefulset
+
+import (
+	context "context"
+
+	v1 "k8s.io/client-go/informers/apps/v1"
+	controller "knative.dev/pkg/controller"
+	injection "knative.dev/pkg/injection"
+	factory "knative.dev/pkg/injection/clients/namespacedkube/informers/factory"
+	logging "knative.dev/pkg/logging"
+)
+
+func init() {
+	injection.Default.RegisterInformer(withInformer)
+}
+
+// Key is used for associating the Informer inside the context.Context.
+type Key struct{}
+
+func withInformer(ctx context.Context) (context.Context, controller.Informer) {
+	f := factory.Get(ctx)
+	inf := f.Apps().V1().StatefulSets()
+	return context.WithValue(ctx, Key{}, inf), inf.Informer()
+}
+
+// Get extracts the typed informer from the context.
+func Get(ctx context.Context) v1.StatefulSetInformer {
+	untyped := ctx.Value(Key{})
+	if untyped == nil {
+		logging.FromContext(ctx).Panic(
+			"Unable to fetch k8s.io/client-go/informers/apps/v1.StatefulSetInformer from context.")
+	}
+	return untyped.(v1.StatefulSetInformer)
+}
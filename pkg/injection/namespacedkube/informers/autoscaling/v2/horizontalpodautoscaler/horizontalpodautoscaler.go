@@ -0,0 +1,37 @@
+// Package horizontalpodautoscaler provides the injected informer of the HorizontalPodAutoscalers in the system
+// namespace. The operator installs all of its HorizontalPodAutoscalers there, so they are not watched in the whole
+// cluster.
+package horizontalpodautoscaler
+
+import (
+	context "context"
+
+	v2 "k8s.io/client-go/informers/autoscaling/v2"
+	controller "knative.dev/pkg/controller"
+	injection "knative.dev/pkg/injection"
+	factory "knative.dev/pkg/injection/clients/namespacedkube/informers/factory"
+	logging "knative.dev/pkg/logging"
+)
+
+func init() {
+	injection.Default.RegisterInformer(withInformer)
+}
+
+// Key is used for associating the Informer inside the context.Context.
+type Key struct{}
+
+func withInformer(ctx context.Context) (context.Context, controller.Informer) {
+	f := factory.Get(ctx)
+	inf := f.Autoscaling().V2().HorizontalPodAutoscalers()
+	return context.WithValue(ctx, Key{}, inf), inf.Informer()
+}
+
+// Get extracts the typed informer from the context.
+func Get(ctx context.Context) v2.HorizontalPodAutoscalerInformer {
+	untyped := ctx.Value(Key{})
+	if untyped == nil {
+		logging.FromContext(ctx).Panic(
+			"Unable to fetch k8s.io/client-go/informers/autoscaling/v2.HorizontalPodAutoscalerInformer from context.")
+	}
+	return untyped.(v2.HorizontalPodAutoscalerInformer)
+}
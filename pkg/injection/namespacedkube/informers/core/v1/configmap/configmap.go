@@ -0,0 +1,36 @@
+// Package configmap provides the injected informer of the ConfigMaps in the system namespace. The operator installs all
+// of its ConfigMaps there, so they are not watched in the whole cluster.
+package configmap
+
+import (
+	context "context"
+
+	v1 "k8s.io/client-go/informers/core/v1"
+	controller "knative.dev/pkg/controller"
+	injection "knative.dev/pkg/injection"
+	factory "knative.dev/pkg/injection/clients/namespacedkube/informers/factory"
+	logging "knative.dev/pkg/logging"
+)
+
+func init() {
+	injection.Default.RegisterInformer(withInformer)
+}
+
+// Key is used for associating the Informer inside the context.Context.
+type Key struct{}
+
+func withInformer(ctx context.Context) (context.Context, controller.Informer) {
+	f := factory.Get(ctx)
+	inf := f.Core().V1().ConfigMaps()
+	return context.WithValue(ctx, Key{}, inf), inf.Informer()
+}
+
+// Get extracts the typed informer from the context.
+func Get(ctx context.Context) v1.ConfigMapInformer {
+	untyped := ctx.Value(Key{})
+	if untyped == nil {
+		logging.FromContext(ctx).Panic(
+			"Unable to fetch k8s.io/client-go/informers/core/v1.ConfigMapInformer from context.")
+	}
+	return untyped.(v1.ConfigMapInformer)
+}
@@ -0,0 +1,480 @@
+package manifests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	mfdynamic "github.com/manifestival/client-go-client/pkg/dynamic"
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// FieldManager is the field manager, with which the operator applies the manifests
+	FieldManager = "eventmesh-operator"
+
+	// createdByManifestivalAnnotation marks namespaces, which were created by the operator and therefore may be
+	// deleted by manifestival
+	createdByManifestivalAnnotation = "manifestival"
+	createdByManifestivalValue      = "new"
+)
+
+// FieldConflict describes a resource with fields, which are owned by other field managers
+type FieldConflict struct {
+	// Resource is the kind, namespace and name of the resource
+	Resource string
+	// Causes lists the conflicting fields and their managers
+	Causes []string
+	// Forced is set if the conflicting fields were taken over. Otherwise, they were left to the other managers and
+	// only the rest of the resource was applied.
+	Forced bool
+}
+
+func (c FieldConflict) String() string {
+	return fmt.Sprintf("%s %v", c.Resource, c.Causes)
+}
+
+// ApplyResult describes the outcome of applying a manifest
+type ApplyResult struct {
+	// Changed is the number of resources, which were created or modified
+	Changed int
+	// Conflicts lists the resources with fields owned by other field managers
+	Conflicts []FieldConflict
+}
+
+// Applier applies resources via server-side apply
+type Applier struct {
+	resources mfdynamic.ResourceGetter
+}
+
+func NewApplier(config *rest.Config) (*Applier, error) {
+	resources, err := mfdynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Applier{resources: resources}, nil
+}
+
+// Apply applies the resources of the manifest in order. Conflicts with fields owned by other field managers are
+// returned instead of failing the apply. force decides per kind, whether the conflicting fields are taken over
+// afterwards, except for the fields managed by controllers in the cluster (see controllerManagedFields). Otherwise,
+// the conflicting fields are dropped and the rest of the resource is applied.
+// Like with client-side apply, the applied configuration is stored in the last-applied-configuration annotation.
+func (a *Applier) Apply(ctx context.Context, manifest mf.Manifest, force func(kind string) bool) (*ApplyResult, error) {
+	result := &ApplyResult{}
+	for _, resource := range manifest.Resources() {
+		spec := resource.DeepCopy()
+
+		ri, err := a.resources.ResourceInterface(spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get client for %s: %w", resourceName(spec), err)
+		}
+
+		if spec.GetName() == "" && spec.GetGenerateName() != "" {
+			// server-side apply requires a name, so resources with a generated name (e.g. post-install jobs) get
+			// created each time
+			if _, err := ri.Create(ctx, spec, metav1.CreateOptions{FieldManager: FieldManager}); err != nil {
+				return nil, fmt.Errorf("failed to create %s: %w", resourceName(spec), err)
+			}
+			result.Changed++
+			continue
+		}
+
+		// server-side apply doesn't tell whether it changed anything, so compare the resource versions
+		live, err := ri.Get(ctx, spec.GetName(), metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get %s: %w", resourceName(spec), err)
+		}
+		if err != nil {
+			live = nil
+		}
+
+		if spec.GetKind() == "Namespace" {
+			markCreatedNamespace(spec, live)
+		}
+
+		setAnnotation(spec, corev1.LastAppliedConfigAnnotation, lastAppliedConfig(spec))
+
+		// apply without forcing first, so that conflicts are reported even if they are taken over
+		applied, err := ri.Apply(ctx, spec.GetName(), spec, metav1.ApplyOptions{FieldManager: FieldManager})
+		if err == nil {
+			if changed(live, applied) {
+				result.Changed++
+			}
+			continue
+		}
+		if !apierrors.IsConflict(err) {
+			return nil, fmt.Errorf("failed to apply %s: %w", resourceName(spec), err)
+		}
+
+		conflict := FieldConflict{
+			Resource: resourceName(spec),
+			Causes:   conflictCauses(err),
+			Forced:   force(spec.GetKind()),
+		}
+		if conflict.Forced {
+			removeControllerManagedFields(spec, err)
+		} else {
+			dropped, ok := removeConflictingFields(spec, err)
+			if !ok {
+				// the fields can't be applied without the conflicting ones
+				result.Conflicts = append(result.Conflicts, conflict)
+				continue
+			}
+			conflict.Causes = dropped
+		}
+		result.Conflicts = append(result.Conflicts, conflict)
+
+		setAnnotation(spec, corev1.LastAppliedConfigAnnotation, lastAppliedConfig(spec))
+		applied, err = ri.Apply(ctx, spec.GetName(), spec, metav1.ApplyOptions{FieldManager: FieldManager, Force: conflict.Forced})
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply %s: %w", resourceName(spec), err)
+		}
+		if changed(live, applied) {
+			result.Changed++
+		}
+	}
+
+	return result, nil
+}
+
+// Delete deletes the resources of the manifest in reverse order and returns the number of resources, which were
+// actually removed. Like manifestival, it skips resources, which don't exist, and namespaces, which were not created
+// by the operator.
+func (a *Applier) Delete(ctx context.Context, manifest mf.Manifest) (int, error) {
+	resources := manifest.Resources()
+	deleted := 0
+	for i := len(resources) - 1; i >= 0; i-- {
+		spec := &resources[i]
+		if spec.GetName() == "" {
+			// resources with a generated name can't be looked up
+			continue
+		}
+
+		ri, err := a.resources.ResourceInterface(spec)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to get client for %s: %w", resourceName(spec), err)
+		}
+
+		live, err := ri.Get(ctx, spec.GetName(), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return deleted, fmt.Errorf("failed to get %s: %w", resourceName(spec), err)
+		}
+		if live.GetKind() == "Namespace" && live.GetAnnotations()[createdByManifestivalAnnotation] != createdByManifestivalValue {
+			continue
+		}
+
+		if err := ri.Delete(ctx, spec.GetName(), metav1.DeleteOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return deleted, fmt.Errorf("failed to delete %s: %w", resourceName(spec), err)
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// changed returns whether the applied resource was created or modified
+func changed(live, applied *unstructured.Unstructured) bool {
+	return live == nil || live.GetResourceVersion() != applied.GetResourceVersion()
+}
+
+// controllerManagedFields are fields, which are managed by controllers in the cluster (e.g. the replicas by HPAs or
+// the CA bundles of webhooks by the webhook certificate controller) and are therefore never taken over
+var controllerManagedFields = []struct {
+	// suffix of the conflicting field path
+	suffix string
+	remove func(u *unstructured.Unstructured)
+}{{
+	suffix: ".spec.replicas",
+	remove: func(u *unstructured.Unstructured) {
+		unstructured.RemoveNestedField(u.Object, "spec", "replicas")
+	},
+}, {
+	suffix: ".clientConfig.caBundle",
+	remove: func(u *unstructured.Unstructured) {
+		webhooks, _, _ := unstructured.NestedSlice(u.Object, "webhooks")
+		for _, webhook := range webhooks {
+			if w, ok := webhook.(map[string]interface{}); ok {
+				unstructured.RemoveNestedField(w, "clientConfig", "caBundle")
+			}
+		}
+		if len(webhooks) > 0 {
+			_ = unstructured.SetNestedSlice(u.Object, webhooks, "webhooks")
+		}
+		unstructured.RemoveNestedField(u.Object, "spec", "conversion", "webhook", "clientConfig", "caBundle")
+	},
+}}
+
+// removeControllerManagedFields removes the controller managed fields, which conflict with other field managers, from
+// the resource
+func removeControllerManagedFields(spec *unstructured.Unstructured, err error) {
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) || statusErr.Status().Details == nil {
+		return
+	}
+
+	for _, field := range controllerManagedFields {
+		for _, cause := range statusErr.Status().Details.Causes {
+			if cause.Type == metav1.CauseTypeFieldManagerConflict && strings.HasSuffix(cause.Field, field.suffix) {
+				field.remove(spec)
+				break
+			}
+		}
+	}
+}
+
+// removeConflictingFields removes the fields, which conflict with other field managers, from the resource and returns
+// the causes of the removed conflicts. It returns false, if a conflicting field couldn't be found in the resource.
+func removeConflictingFields(spec *unstructured.Unstructured, err error) ([]string, bool) {
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) || statusErr.Status().Details == nil {
+		return nil, false
+	}
+
+	var dropped []string
+	for _, cause := range statusErr.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		if !removeField(spec.Object, parseFieldPath(cause.Field)) {
+			return nil, false
+		}
+		dropped = append(dropped, fmt.Sprintf("%s: %s", cause.Field, cause.Message))
+	}
+
+	return dropped, len(dropped) > 0
+}
+
+// parseFieldPath splits a field path of a server-side apply conflict (e.g.
+// .spec.template.spec.containers[name="controller"].image) into field names and list selectors. Selectors keep their
+// brackets. Field names aren't escaped, so names with dots (e.g. of labels) are split and joined again by removeField.
+func parseFieldPath(path string) []string {
+	var elements []string
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			end := strings.IndexAny(path[1:], ".[")
+			if end < 0 {
+				end = len(path) - 1
+			}
+			elements = append(elements, path[1:end+1])
+			path = path[end+1:]
+		case '[':
+			end := selectorEnd(path)
+			elements = append(elements, path[:end])
+			path = path[end:]
+		default:
+			return append(elements, path)
+		}
+	}
+
+	return elements
+}
+
+// selectorEnd returns the index after the closing bracket of the selector, at which the path starts
+func selectorEnd(path string) int {
+	quoted := false
+	for i := 1; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && quoted:
+			i++
+		case path[i] == '"':
+			quoted = !quoted
+		case path[i] == ']' && !quoted:
+			return i + 1
+		}
+	}
+
+	return len(path)
+}
+
+// removeField removes the field or list item at the parsed path from the object and returns whether it was found
+func removeField(obj interface{}, path []string) bool {
+	if len(path) == 0 {
+		return false
+	}
+
+	switch node := obj.(type) {
+	case map[string]interface{}:
+		// field names with dots are split into several elements, so try the longest names too
+		for n := 1; n <= len(path) && !strings.HasPrefix(path[n-1], "["); n++ {
+			name := strings.Join(path[:n], ".")
+			value, ok := node[name]
+			if !ok {
+				continue
+			}
+			if n == len(path) {
+				delete(node, name)
+				return true
+			}
+			if removed, ok := removeListItem(value, path[n:]); ok {
+				node[name] = removed
+				return true
+			}
+			if removeField(value, path[n:]) {
+				return true
+			}
+		}
+	case []interface{}:
+		// the last item is removed from its parent, which keeps the list
+		if len(path) < 2 {
+			return false
+		}
+		for _, item := range node {
+			if matchesSelector(item, path[0]) {
+				return removeField(item, path[1:])
+			}
+		}
+	}
+
+	return false
+}
+
+// removeListItem removes the item selected by the first element of the path from the list, if it is the last
+// element, and returns the list without it
+func removeListItem(obj interface{}, path []string) ([]interface{}, bool) {
+	list, ok := obj.([]interface{})
+	if !ok || len(path) != 1 || !strings.HasPrefix(path[0], "[") {
+		return nil, false
+	}
+
+	for i, item := range list {
+		if matchesSelector(item, path[0]) {
+			return append(list[:i:i], list[i+1:]...), true
+		}
+	}
+
+	return nil, false
+}
+
+// matchesSelector returns whether the list item is selected by the selector, which is either an index ([0]), a value
+// ([="value"]) or the JSON values of the keys of the item ([name="controller",protocol="TCP"])
+func matchesSelector(item interface{}, selector string) bool {
+	selector = strings.TrimSuffix(strings.TrimPrefix(selector, "["), "]")
+
+	if value, ok := strings.CutPrefix(selector, "="); ok {
+		return jsonEquals(item, value)
+	}
+
+	fields, ok := item.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for _, key := range splitSelectorKeys(selector) {
+		name, value, ok := strings.Cut(key, "=")
+		if !ok || !jsonEquals(fields[name], value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// splitSelectorKeys splits the selector at the commas between its keys
+func splitSelectorKeys(selector string) []string {
+	var keys []string
+	quoted, start := false, 0
+	for i := 0; i < len(selector); i++ {
+		switch {
+		case selector[i] == '\\' && quoted:
+			i++
+		case selector[i] == '"':
+			quoted = !quoted
+		case selector[i] == ',' && !quoted:
+			keys = append(keys, selector[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(keys, selector[start:])
+}
+
+func jsonEquals(value interface{}, expected string) bool {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(expected), &parsed); err != nil {
+		return false
+	}
+	actual, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(actual, &normalized); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(normalized, parsed)
+}
+
+// markCreatedNamespace keeps the annotation, with which manifestival marks namespaces it is allowed to delete, or adds
+// it if the namespace does not exist yet
+func markCreatedNamespace(spec, live *unstructured.Unstructured) {
+	if live == nil || live.GetAnnotations()[createdByManifestivalAnnotation] == createdByManifestivalValue {
+		setAnnotation(spec, createdByManifestivalAnnotation, createdByManifestivalValue)
+	}
+}
+
+// conflictCauses returns the conflicting fields and their managers of a conflict error
+func conflictCauses(err error) []string {
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) || statusErr.Status().Details == nil {
+		return []string{err.Error()}
+	}
+
+	var causes []string
+	for _, cause := range statusErr.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		causes = append(causes, fmt.Sprintf("%s: %s", cause.Field, cause.Message))
+	}
+	if len(causes) == 0 {
+		return []string{err.Error()}
+	}
+
+	return causes
+}
+
+// lastAppliedConfig returns the resource as JSON without the last-applied-configuration annotation itself
+func lastAppliedConfig(spec *unstructured.Unstructured) string {
+	u := spec.DeepCopy()
+	annotations := u.GetAnnotations()
+	if len(annotations) > 0 {
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+		u.SetAnnotations(annotations)
+	}
+
+	bytes, _ := u.MarshalJSON()
+	return string(bytes)
+}
+
+func setAnnotation(u *unstructured.Unstructured, key, value string) {
+	annotations := u.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = value
+	u.SetAnnotations(annotations)
+}
+
+func resourceName(u *unstructured.Unstructured) string {
+	if u.GetNamespace() == "" {
+		return fmt.Sprintf("%s/%s", u.GetKind(), u.GetName())
+	}
+	return fmt.Sprintf("%s/%s/%s", u.GetKind(), u.GetNamespace(), u.GetName())
+}
@@ -0,0 +1,589 @@
+package manifests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/pkg/controller"
+)
+
+func TestConflictCauses(t *testing.T) {
+	conflict := apierrors.NewApplyConflict([]metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldManagerConflict,
+		Message: `conflict with "kubectl-edit" using apps/v1`,
+		Field:   ".spec.replicas",
+	}, {
+		Type:    metav1.CauseTypeFieldManagerConflict,
+		Message: `conflict with "argocd-controller" using apps/v1`,
+		Field:   ".spec.template.spec.containers[name=\"controller\"].image",
+	}}, "Apply failed with 2 conflicts")
+
+	want := []string{
+		`.spec.replicas: conflict with "kubectl-edit" using apps/v1`,
+		`.spec.template.spec.containers[name="controller"].image: conflict with "argocd-controller" using apps/v1`,
+	}
+	if diff := cmp.Diff(want, conflictCauses(conflict)); diff != "" {
+		t.Errorf("conflictCauses() (-want, +got) = %v", diff)
+	}
+
+	wrapped := fmt.Errorf("failed: %w", apierrors.NewConflict(schema.GroupResource{Resource: "deployments"}, "kafka-controller", fmt.Errorf("stale")))
+	if got := conflictCauses(wrapped); len(got) != 1 || got[0] != wrapped.Error() {
+		t.Errorf("conflictCauses() = %v, want the error message for conflicts without causes", got)
+	}
+}
+
+func TestLastAppliedConfig(t *testing.T) {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("ConfigMap")
+	u.SetName("config-features")
+	u.SetAnnotations(map[string]string{
+		corev1.LastAppliedConfigAnnotation: "outdated",
+		"foo":                              "bar",
+	})
+
+	want := `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"annotations":{"foo":"bar"},"name":"config-features"}}` + "\n"
+	if got := lastAppliedConfig(u); got != want {
+		t.Errorf("lastAppliedConfig() = %s, want %s", got, want)
+	}
+
+	if _, ok := u.GetAnnotations()[corev1.LastAppliedConfigAnnotation]; !ok {
+		t.Error("lastAppliedConfig() modified the given resource")
+	}
+}
+
+func TestShouldForceConflicts(t *testing.T) {
+	tests := []struct {
+		name  string
+		apply *v1alpha1.EventMeshSpecApply
+		kind  string
+		want  bool
+	}{
+		{
+			name: "not forced by default",
+			kind: "Deployment",
+			want: false,
+		},
+		{
+			name:  "enabled globally",
+			apply: &v1alpha1.EventMeshSpecApply{ForceConflicts: ptr.To(true)},
+			kind:  "Deployment",
+			want:  true,
+		},
+		{
+			name:  "disabled globally",
+			apply: &v1alpha1.EventMeshSpecApply{ForceConflicts: ptr.To(false)},
+			kind:  "Deployment",
+			want:  false,
+		},
+		{
+			name: "disabled for kind",
+			apply: &v1alpha1.EventMeshSpecApply{
+				ForceConflictsByKind: map[string]bool{"Deployment": false},
+			},
+			kind: "Deployment",
+			want: false,
+		},
+		{
+			name: "enabled for kind",
+			apply: &v1alpha1.EventMeshSpecApply{
+				ForceConflicts:       ptr.To(false),
+				ForceConflictsByKind: map[string]bool{"ConfigMap": true},
+			},
+			kind: "ConfigMap",
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.apply.ShouldForceConflicts(tt.kind); got != tt.want {
+				t.Errorf("ShouldForceConflicts(%q) = %v, want %v", tt.kind, got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeResources stores all resources by name and fails non-forced applies of the resources, which set conflicting
+// fields
+type fakeResources struct {
+	dynamic.ResourceInterface
+
+	conflicts map[string][]fakeConflict
+	objects   map[string]*unstructured.Unstructured
+	version   int
+	applied   []appliedResource
+	created   []string
+}
+
+// fakeConflict is a conflict, which occurs while the applied object sets the field
+type fakeConflict struct {
+	cause metav1.StatusCause
+	isSet func(obj map[string]interface{}) bool
+}
+
+type appliedResource struct {
+	name   string
+	force  bool
+	object map[string]interface{}
+}
+
+// fakeResourceGetter returns the fake resources for all kinds
+type fakeResourceGetter struct {
+	resources *fakeResources
+}
+
+func (g fakeResourceGetter) ResourceInterface(*unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	return g.resources, nil
+}
+
+func (f *fakeResources) Get(_ context.Context, name string, _ metav1.GetOptions, _ ...string) (*unstructured.Unstructured, error) {
+	if obj, ok := f.objects[name]; ok {
+		return obj.DeepCopy(), nil
+	}
+	return nil, apierrors.NewNotFound(schema.GroupResource{}, name)
+}
+
+func (f *fakeResources) Apply(_ context.Context, name string, obj *unstructured.Unstructured, options metav1.ApplyOptions, _ ...string) (*unstructured.Unstructured, error) {
+	if options.FieldManager != FieldManager {
+		return nil, fmt.Errorf("unexpected field manager %q", options.FieldManager)
+	}
+	var causes []metav1.StatusCause
+	for _, conflict := range f.conflicts[name] {
+		if conflict.isSet(obj.Object) {
+			causes = append(causes, conflict.cause)
+		}
+	}
+	if len(causes) > 0 && !options.Force {
+		return nil, apierrors.NewApplyConflict(causes, fmt.Sprintf("Apply failed with %d conflicts", len(causes)))
+	}
+	f.applied = append(f.applied, appliedResource{name: name, force: options.Force, object: obj.DeepCopy().Object})
+
+	// like the API server, only changes get a new resource version
+	stored := obj.DeepCopy()
+	if live, ok := f.objects[name]; ok {
+		stored.SetResourceVersion(live.GetResourceVersion())
+		if cmp.Equal(live.Object, stored.Object) {
+			return live.DeepCopy(), nil
+		}
+	}
+	if f.objects == nil {
+		f.objects = map[string]*unstructured.Unstructured{}
+	}
+	f.version++
+	stored.SetResourceVersion(fmt.Sprint(f.version))
+	f.objects[name] = stored
+	return stored.DeepCopy(), nil
+}
+
+func (f *fakeResources) Create(_ context.Context, obj *unstructured.Unstructured, _ metav1.CreateOptions, _ ...string) (*unstructured.Unstructured, error) {
+	f.created = append(f.created, obj.GetGenerateName())
+	return obj, nil
+}
+
+func (f *fakeResources) Delete(_ context.Context, name string, _ metav1.DeleteOptions, _ ...string) error {
+	if _, ok := f.objects[name]; !ok {
+		return apierrors.NewNotFound(schema.GroupResource{}, name)
+	}
+	delete(f.objects, name)
+	return nil
+}
+
+func TestApply(t *testing.T) {
+	replicasConflict := fakeConflict{
+		cause: metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kube-controller-manager" using apps/v1`,
+			Field:   ".spec.replicas",
+		},
+		isSet: func(obj map[string]interface{}) bool {
+			_, found, _ := unstructured.NestedFieldNoCopy(obj, "spec", "replicas")
+			return found
+		},
+	}
+	imageConflict := fakeConflict{
+		cause: metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kubectl-edit" using apps/v1`,
+			Field:   ".spec.template.spec.containers[name=\"controller\"].image",
+		},
+		isSet: func(obj map[string]interface{}) bool {
+			containers, _, _ := unstructured.NestedSlice(obj, "spec", "template", "spec", "containers")
+			_, found, _ := unstructured.NestedFieldNoCopy(containers[0].(map[string]interface{}), "image")
+			return found
+		},
+	}
+	caBundleConflict := fakeConflict{
+		cause: metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "webhook" using admissionregistration.k8s.io/v1`,
+			Field:   `.webhooks[name="defaulting.webhook.kafka.eventing.knative.dev"].clientConfig.caBundle`,
+		},
+		isSet: func(obj map[string]interface{}) bool {
+			webhooks, _, _ := unstructured.NestedSlice(obj, "webhooks")
+			_, found, _ := unstructured.NestedFieldNoCopy(webhooks[0].(map[string]interface{}), "clientConfig", "caBundle")
+			return found
+		},
+	}
+
+	manifest, err := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "kafka-controller", "namespace": "knative-eventing"},
+		"spec": map[string]interface{}{
+			"replicas": int64(1),
+			"template": map[string]interface{}{"spec": map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "controller", "image": "controller:v1"},
+			}}},
+		},
+	}}, {Object: map[string]interface{}{
+		"apiVersion": "admissionregistration.k8s.io/v1",
+		"kind":       "MutatingWebhookConfiguration",
+		"metadata":   map[string]interface{}{"name": "defaulting.webhook.kafka.eventing.knative.dev"},
+		"webhooks": []interface{}{map[string]interface{}{
+			"name":         "defaulting.webhook.kafka.eventing.knative.dev",
+			"clientConfig": map[string]interface{}{"caBundle": "", "service": map[string]interface{}{"name": "kafka-webhook-eventing"}},
+		}},
+	}}, {Object: map[string]interface{}{
+		"apiVersion": "batch/v1",
+		"kind":       "Job",
+		"metadata":   map[string]interface{}{"generateName": "storage-version-migration-", "namespace": "knative-eventing"},
+	}}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conflicts := map[string][]fakeConflict{
+		"kafka-controller": {replicasConflict, imageConflict},
+		"defaulting.webhook.kafka.eventing.knative.dev": {caBundleConflict},
+	}
+	wantConflicts := func(forced bool) []FieldConflict {
+		return []FieldConflict{{
+			Resource: "Deployment/knative-eventing/kafka-controller",
+			Causes: []string{
+				`.spec.replicas: conflict with "kube-controller-manager" using apps/v1`,
+				`.spec.template.spec.containers[name="controller"].image: conflict with "kubectl-edit" using apps/v1`,
+			},
+			Forced: forced,
+		}, {
+			Resource: "MutatingWebhookConfiguration/defaulting.webhook.kafka.eventing.knative.dev",
+			Causes:   []string{`.webhooks[name="defaulting.webhook.kafka.eventing.knative.dev"].clientConfig.caBundle: conflict with "webhook" using admissionregistration.k8s.io/v1`},
+			Forced:   forced,
+		}}
+	}
+
+	t.Run("conflicting fields are reported and the rest is applied", func(t *testing.T) {
+		resources := &fakeResources{conflicts: conflicts}
+		got, err := (&Applier{resources: fakeResourceGetter{resources}}).Apply(context.Background(), manifest, func(string) bool { return false })
+		if err != nil {
+			t.Fatal("Apply() =", err)
+		}
+
+		if diff := cmp.Diff(wantConflicts(false), got.Conflicts); diff != "" {
+			t.Errorf("Apply() conflicts (-want, +got) = %v", diff)
+		}
+		if got.Changed != 3 {
+			t.Errorf("Apply() changed %d resources, want 3", got.Changed)
+		}
+		if len(resources.applied) != 2 || resources.applied[0].force || resources.applied[1].force {
+			t.Fatalf("Apply() applied %+v, want both resources applied without force", resources.applied)
+		}
+
+		deployment := &unstructured.Unstructured{Object: resources.applied[0].object}
+		if _, found, _ := unstructured.NestedFieldNoCopy(deployment.Object, "spec", "replicas"); found {
+			t.Error("Apply() applied the conflicting spec.replicas")
+		}
+		containers, _, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+		if diff := cmp.Diff([]interface{}{map[string]interface{}{"name": "controller"}}, containers); diff != "" {
+			t.Errorf("Apply() containers (-want, +got) = %v", diff)
+		}
+		if got := deployment.GetAnnotations()[corev1.LastAppliedConfigAnnotation]; got != lastAppliedConfig(deployment) {
+			t.Errorf("last-applied-configuration = %s, want the applied configuration", got)
+		}
+
+		webhooks, _, _ := unstructured.NestedSlice(resources.applied[1].object, "webhooks")
+		want := map[string]interface{}{"service": map[string]interface{}{"name": "kafka-webhook-eventing"}}
+		if diff := cmp.Diff(want, webhooks[0].(map[string]interface{})["clientConfig"]); diff != "" {
+			t.Errorf("Apply() webhook clientConfig (-want, +got) = %v", diff)
+		}
+
+		if diff := cmp.Diff([]string{"storage-version-migration-"}, resources.created); diff != "" {
+			t.Errorf("Apply() created (-want, +got) = %v", diff)
+		}
+	})
+
+	t.Run("forced conflicts keep controller managed fields", func(t *testing.T) {
+		resources := &fakeResources{conflicts: conflicts}
+		got, err := (&Applier{resources: fakeResourceGetter{resources}}).Apply(context.Background(), manifest, func(kind string) bool { return kind != "Job" })
+		if err != nil {
+			t.Fatal("Apply() =", err)
+		}
+
+		if diff := cmp.Diff(wantConflicts(true), got.Conflicts); diff != "" {
+			t.Errorf("Apply() conflicts (-want, +got) = %v", diff)
+		}
+		if len(resources.applied) != 2 || !resources.applied[0].force || !resources.applied[1].force {
+			t.Fatalf("Apply() applied %+v, want both resources forced", resources.applied)
+		}
+
+		deployment := &unstructured.Unstructured{Object: resources.applied[0].object}
+		if _, found, _ := unstructured.NestedFieldNoCopy(deployment.Object, "spec", "replicas"); found {
+			t.Error("Apply() forced spec.replicas, want it kept by the other manager")
+		}
+		if _, found, _ := unstructured.NestedFieldNoCopy(deployment.Object, "spec", "template"); !found {
+			t.Error("Apply() dropped spec.template, want it forced")
+		}
+		if got := deployment.GetAnnotations()[corev1.LastAppliedConfigAnnotation]; got != lastAppliedConfig(deployment) {
+			t.Errorf("last-applied-configuration = %s, want the forced configuration", got)
+		}
+
+		webhooks, _, _ := unstructured.NestedSlice(resources.applied[1].object, "webhooks")
+		if _, found, _ := unstructured.NestedFieldNoCopy(webhooks[0].(map[string]interface{}), "clientConfig", "caBundle"); found {
+			t.Error("Apply() forced the webhook caBundle, want it kept by the other manager")
+		}
+	})
+
+	t.Run("generateName is created without apply", func(t *testing.T) {
+		resources := &fakeResources{}
+		got, err := (&Applier{resources: fakeResourceGetter{resources}}).Apply(context.Background(), manifest, func(string) bool { return false })
+		if err != nil {
+			t.Fatal("Apply() =", err)
+		}
+
+		if len(got.Conflicts) != 0 {
+			t.Errorf("Apply() conflicts = %v, want none", got.Conflicts)
+		}
+		if got.Changed != 3 {
+			t.Errorf("Apply() changed %d resources, want 3", got.Changed)
+		}
+		if len(resources.applied) != 2 || resources.applied[0].force || resources.applied[1].force {
+			t.Errorf("Apply() applied %+v, want both resources applied without force", resources.applied)
+		}
+		if diff := cmp.Diff([]string{"storage-version-migration-"}, resources.created); diff != "" {
+			t.Errorf("Apply() created (-want, +got) = %v", diff)
+		}
+	})
+
+	t.Run("unchanged resources are not counted", func(t *testing.T) {
+		resources := &fakeResources{}
+		applier := &Applier{resources: fakeResourceGetter{resources}}
+		if _, err := applier.Apply(context.Background(), manifest, func(string) bool { return false }); err != nil {
+			t.Fatal("Apply() =", err)
+		}
+
+		got, err := applier.Apply(context.Background(), manifest.Filter(mf.Not(mf.ByKind("Job"))), func(string) bool { return false })
+		if err != nil {
+			t.Fatal("Apply() =", err)
+		}
+		if got.Changed != 0 {
+			t.Errorf("Apply() changed %d resources, want none", got.Changed)
+		}
+	})
+}
+
+func TestRemoveField(t *testing.T) {
+	object := func() map[string]interface{} {
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{"app.kubernetes.io/version": "1.19.2", "app": "kafka-controller"},
+			},
+			"spec": map[string]interface{}{
+				"ports": []interface{}{
+					map[string]interface{}{"port": int64(80), "protocol": "TCP", "name": "http"},
+					map[string]interface{}{"port": int64(80), "protocol": "UDP", "name": "dns"},
+				},
+				"finalizers": []interface{}{"kubernetes", "eventing.knative.dev"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		path  string
+		want  func(obj map[string]interface{})
+		found bool
+	}{{
+		name: "field with dots",
+		path: ".metadata.labels.app.kubernetes.io/version",
+		want: func(obj map[string]interface{}) {
+			unstructured.RemoveNestedField(obj, "metadata", "labels", "app.kubernetes.io/version")
+		},
+		found: true,
+	}, {
+		name: "field of list item selected by keys",
+		path: `.spec.ports[port=80,protocol="UDP"].name`,
+		want: func(obj map[string]interface{}) {
+			ports, _, _ := unstructured.NestedSlice(obj, "spec", "ports")
+			delete(ports[1].(map[string]interface{}), "name")
+			_ = unstructured.SetNestedSlice(obj, ports, "spec", "ports")
+		},
+		found: true,
+	}, {
+		name: "list item selected by value",
+		path: `.spec.finalizers[="kubernetes"]`,
+		want: func(obj map[string]interface{}) {
+			_ = unstructured.SetNestedStringSlice(obj, []string{"eventing.knative.dev"}, "spec", "finalizers")
+		},
+		found: true,
+	}, {
+		name: "missing field",
+		path: `.spec.ports[port=443,protocol="TCP"].name`,
+		want: func(map[string]interface{}) {},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, want := object(), object()
+			tt.want(want)
+
+			if found := removeField(got, parseFieldPath(tt.path)); found != tt.found {
+				t.Errorf("removeField(%s) = %v, want %v", tt.path, found, tt.found)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("removeField(%s) (-want, +got) = %v", tt.path, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	namespace := func(name string, annotations map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("Namespace")
+		u.SetName(name)
+		u.SetAnnotations(annotations)
+		return u
+	}
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetName("config-kafka-broker-data-plane")
+
+	resources := &fakeResources{objects: map[string]*unstructured.Unstructured{
+		"created":                        namespace("created", map[string]string{createdByManifestivalAnnotation: createdByManifestivalValue}),
+		"foreign":                        namespace("foreign", nil),
+		"config-kafka-broker-data-plane": configMap,
+	}}
+
+	manifest, err := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{
+		*namespace("created", nil),
+		*namespace("foreign", nil),
+		*namespace("missing", nil),
+		*configMap,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := (&Applier{resources: fakeResourceGetter{resources}}).Delete(context.Background(), manifest)
+	if err != nil {
+		t.Fatal("Delete() =", err)
+	}
+
+	// missing resources and namespaces, which were not created by the operator, are not counted
+	if deleted != 2 {
+		t.Errorf("Delete() = %d, want 2", deleted)
+	}
+	if _, ok := resources.objects["foreign"]; !ok {
+		t.Error("Delete() deleted a namespace, which was not created by the operator")
+	}
+}
+
+func TestUninstallKeepCRDs(t *testing.T) {
+	crd := &unstructured.Unstructured{}
+	crd.SetAPIVersion("apiextensions.k8s.io/v1")
+	crd.SetKind("CustomResourceDefinition")
+	crd.SetName("brokers.eventing.knative.dev")
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetName("config-features")
+
+	installed := crd.DeepCopy()
+	installed.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "operator.knative.dev/v1alpha1", Kind: "EventMesh", Name: "eventmesh"}})
+	resources := &fakeResources{objects: map[string]*unstructured.Unstructured{
+		crd.GetName():       installed,
+		configMap.GetName(): configMap.DeepCopy(),
+	}}
+
+	toApply, err := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{*crd, *configMap}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uninstall := Uninstall(&Applier{resources: fakeResourceGetter{resources}}, true)
+	if err := uninstall(context.Background(), &Manifests{ToApply: toApply}, &v1alpha1.EventMesh{}); err != nil {
+		t.Fatal("Uninstall() =", err)
+	}
+
+	if _, ok := resources.objects[configMap.GetName()]; ok {
+		t.Error("Uninstall() kept the ConfigMap, want it deleted")
+	}
+	kept, ok := resources.objects[crd.GetName()]
+	if !ok {
+		t.Fatal("Uninstall() deleted the CRD, want it kept")
+	}
+	if refs := kept.GetOwnerReferences(); len(refs) != 0 {
+		t.Errorf("Uninstall() kept the owner references %v of the CRD", refs)
+	}
+	if len(resources.applied) != 1 || resources.applied[0].name != crd.GetName() {
+		t.Errorf("Uninstall() applied %+v, want the CRD applied by the operator's field manager", resources.applied)
+	}
+}
+
+func TestRecordConflicts(t *testing.T) {
+	taken := FieldConflict{Resource: "Deployment/knative-eventing/kafka-controller", Causes: []string{"image"}, Forced: true}
+	kept := FieldConflict{Resource: "ConfigMap/knative-eventing/config-features", Causes: []string{"data"}}
+
+	tests := []struct {
+		name       string
+		conflicts  []FieldConflict
+		wantStatus corev1.ConditionStatus
+		wantReason string
+	}{{
+		name:       "no conflicts",
+		wantStatus: corev1.ConditionTrue,
+	}, {
+		name:       "forced conflicts",
+		conflicts:  []FieldConflict{taken},
+		wantStatus: corev1.ConditionTrue,
+		wantReason: "FieldOwnershipTakenOver",
+	}, {
+		name:       "not forced conflicts",
+		conflicts:  []FieldConflict{taken, kept},
+		wantStatus: corev1.ConditionFalse,
+		wantReason: "FieldOwnershipConflict",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			em := &v1alpha1.EventMesh{}
+			em.Status.InitializeConditions()
+			recorder := record.NewFakeRecorder(10)
+
+			recordConflicts(controller.WithEventRecorder(context.Background(), recorder), em, tt.conflicts)
+
+			cond := em.Status.GetCondition(v1alpha1.EventMeshConditionFieldsOwned)
+			if cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Errorf("FieldsOwned = %s (%s), want %s (%s)", cond.Status, cond.Reason, tt.wantStatus, tt.wantReason)
+			}
+			if len(recorder.Events) != len(tt.conflicts) {
+				t.Errorf("recorded %d events, want %d", len(recorder.Events), len(tt.conflicts))
+			}
+		})
+	}
+}
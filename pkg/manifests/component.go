@@ -0,0 +1,101 @@
+package manifests
+
+import (
+	"fmt"
+	"sort"
+
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+)
+
+const (
+	// ComponentLabel is the label containing the component a resource belongs to
+	ComponentLabel = "operator.knative.dev/component"
+
+	ComponentEventingCore    = "eventing-core"
+	ComponentInMemoryChannel = "in-memory-channel"
+	ComponentMTChannelBroker = "mt-channel-broker"
+	ComponentPingSource      = "ping-source"
+	ComponentJobSink         = "job-sink"
+	ComponentEventingTLS     = "eventing-tls"
+
+	ComponentKafkaController = "kafka-controller"
+	ComponentKafkaBroker     = "kafka-broker"
+	ComponentKafkaChannel    = "kafka-channel"
+	ComponentKafkaSink       = "kafka-sink"
+	ComponentKafkaSource     = "kafka-source"
+	ComponentKafkaTLS        = "kafka-tls"
+)
+
+// withComponent labels all resources of the manifest with the given component
+func withComponent(component string, manifest mf.Manifest) (mf.Manifest, error) {
+	labeled, err := manifest.Transform(func(u *unstructured.Unstructured) error {
+		labels := u.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[ComponentLabel] = component
+		u.SetLabels(labels)
+
+		return nil
+	})
+	if err != nil {
+		return mf.Manifest{}, fmt.Errorf("failed to label manifests with component %s: %w", component, err)
+	}
+
+	return labeled, nil
+}
+
+// loadComponentManifests loads the given files and labels them with the given component
+func loadComponentManifests(component, dirname string, filenames ...string) (mf.Manifest, error) {
+	manifest, err := loadManifests(dirname, filenames...)
+	if err != nil {
+		return mf.Manifest{}, err
+	}
+
+	return withComponent(component, manifest)
+}
+
+// Inventory returns the number of resources to apply (including post-install resources) per component, sorted by
+// the component name
+func (m *Manifests) Inventory() []v1alpha1.ComponentStatus {
+	counts := map[string]int{}
+	for _, u := range m.ToApply.Append(m.PostInstall).Resources() {
+		if component, ok := u.GetLabels()[ComponentLabel]; ok {
+			counts[component]++
+		}
+	}
+
+	inventory := make([]v1alpha1.ComponentStatus, 0, len(counts))
+	for component, count := range counts {
+		inventory = append(inventory, v1alpha1.ComponentStatus{
+			Name:      component,
+			Resources: count,
+		})
+	}
+
+	sort.Slice(inventory, func(i, j int) bool {
+		return inventory[i].Name < inventory[j].Name
+	})
+
+	return inventory
+}
+
+// ComponentVersion returns the version of the given component from the version label of its resources to apply or
+// an empty string if the component is not applied
+func (m *Manifests) ComponentVersion(component string) (string, error) {
+	for _, u := range m.ToApply.Filter(mf.ByLabel(ComponentLabel, component)).Resources() {
+		if _, ok := u.GetLabels()[versionLabel]; !ok {
+			continue
+		}
+
+		v, err := parseVersionFromLabels(u.GetLabels())
+		if err != nil {
+			return "", fmt.Errorf("failed to get version of component %s from %s/%s: %w", component, u.GetKind(), u.GetName(), err)
+		}
+		return v.String(), nil
+	}
+
+	return "", nil
+}
@@ -14,6 +14,23 @@ import (
 	"knative.dev/pkg/logging"
 )
 
+var (
+	pingSourceResources = []mf.Predicate{
+		mf.All(mf.ByKind("Deployment"), mf.ByName("pingsource-mt-adapter")),
+		mf.All(mf.ByKind("ServiceAccount"), mf.ByName("pingsource-mt-adapter")),
+		mf.All(mf.ByKind("ClusterRoleBinding"), mf.ByName("knative-eventing-pingsource-mt-adapter")),
+		mf.All(mf.ByKind("ClusterRole"), mf.ByName("knative-eventing-pingsource-mt-adapter")),
+	}
+
+	jobSinkResources = []mf.Predicate{
+		mf.All(mf.ByKind("Deployment"), mf.ByName("job-sink")),
+		mf.All(mf.ByKind("Service"), mf.ByName("job-sink")),
+		mf.All(mf.ByKind("ServiceAccount"), mf.ByName("job-sink")),
+		mf.All(mf.ByKind("ClusterRoleBinding"), mf.ByName("knative-eventing-job-sink")),
+		mf.All(mf.ByKind("ClusterRole"), mf.ByName("knative-eventing-job-sink")),
+	}
+)
+
 // eventingParser parses eventing manifests
 type eventingParser struct {
 	crdLister        apiextensionsv1.CustomResourceDefinitionLister
@@ -32,31 +49,36 @@ func NewEventingParser(crdLister apiextensionsv1.CustomResourceDefinitionLister,
 func (p *eventingParser) Parse(ctx context.Context, em *v1alpha1.EventMesh) (*Manifests, error) {
 	manifests := &Manifests{}
 
-	coreManifests, err := p.eventingCoreManifests(ctx, em)
+	release, err := PlanRelease(em)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve release: %w", err)
+	}
+
+	coreManifests, err := p.eventingCoreManifests(ctx, em, release)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load eventing core manifests: %w", err)
 	}
 	manifests.Append(coreManifests)
 
-	imcManifests, err := p.eventingIMCManifests(ctx, em)
+	imcManifests, err := p.eventingIMCManifests(ctx, em, release)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load eventing IMC manifests: %w", err)
 	}
 	manifests.Append(imcManifests)
 
-	mtBrokerManifests, err := p.eventingMTBrokerManifests(ctx, em)
+	mtBrokerManifests, err := p.eventingMTBrokerManifests(ctx, em, release)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load eventing MT channel Broker manifests: %w", err)
 	}
 	manifests.Append(mtBrokerManifests)
 
-	tlsManifests, err := p.eventingTLSManifests(ctx, em)
+	tlsManifests, err := p.eventingTLSManifests(ctx, em, release)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load eventing tls manifests: %w", err)
 	}
 	manifests.Append(tlsManifests)
 
-	postInstallManifests, err := p.eventingPostInstallManifests(ctx, em, coreManifests)
+	postInstallManifests, err := p.eventingPostInstallManifests(ctx, em, release, coreManifests)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load eventing post-install manifests: %w", err)
 	}
@@ -65,58 +87,76 @@ func (p *eventingParser) Parse(ctx context.Context, em *v1alpha1.EventMesh) (*Ma
 	return manifests, nil
 }
 
-func (p *eventingParser) eventingCoreManifests(ctx context.Context, em *v1alpha1.EventMesh) (*Manifests, error) {
+func (p *eventingParser) eventingCoreManifests(ctx context.Context, em *v1alpha1.EventMesh, release *Release) (*Manifests, error) {
 	manifests := Manifests{}
 
-	coreManifests, err := p.loadEventingCoreManifests()
+	coreManifests, err := p.loadEventingCoreManifests(release)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load eventing core manifests: %w", err)
 	}
-	manifests.AddToApply(coreManifests)
+
+	// the CRDs of disabled sources are kept, as the eventing-controller watches them. Only their dedicated
+	// workloads get removed
+	pingSourceFilter := mf.Any(pingSourceResources...)
+	jobSinkFilter := mf.Any(jobSinkResources...)
+
+	pingSourceManifests, err := withComponent(ComponentPingSource, coreManifests.Filter(pingSourceFilter))
+	if err != nil {
+		return nil, err
+	}
+	jobSinkManifests, err := withComponent(ComponentJobSink, coreManifests.Filter(jobSinkFilter))
+	if err != nil {
+		return nil, err
+	}
+
+	manifests.AddToApply(coreManifests.Filter(mf.Not(mf.Any(pingSourceFilter, jobSinkFilter))))
+	manifests.AddToApplyOrDelete(em.Spec.Components.IsPingSourceEnabled(), pingSourceManifests)
+	manifests.AddToApplyOrDelete(em.Spec.Components.IsJobSinkEnabled(), jobSinkManifests)
 
 	manifests.AddTransformers(
-		transform.EventingCoreLogging(em.Spec.LogLevel),
-		transform.DefaultChannelImplementation(em.Spec.DefaultChannel),
-		transform.DefaultBrokerClass(em.Spec.DefaultBroker, em.Spec.DefaultChannel),
+		transform.EventingCoreLogging(em.Spec.LogLevel, em.Spec.Logging),
+		transform.DefaultChannelImplementation(em.Spec.GetDefaultChannel(), em.Spec.GetNamespaceDefaults()),
+		transform.DefaultBrokerClass(em.Spec.GetDefaultBrokerClass(), em.Spec.GetEnabledBrokerClasses(), em.Spec.GetDefaultChannel(), em.Spec.GetNamespaceDefaults()),
 		transform.EventingFeatureFlags(em.Spec.Features),
-		transform.ConfigMapOverride(em.Spec.Overrides.Config),
-		transform.WorkloadsOverride(em.Spec.Overrides.Workloads))
+		transform.Observability(em.Spec.Observability),
+		transform.ConfigMapOverride(em.Spec.Overrides.GetConfig()),
+		transform.WorkloadsOverride(em.Spec.Overrides.GetWorkloads()))
 
 	return &manifests, nil
 }
 
-func (p *eventingParser) eventingIMCManifests(ctx context.Context, em *v1alpha1.EventMesh) (*Manifests, error) {
+func (p *eventingParser) eventingIMCManifests(ctx context.Context, em *v1alpha1.EventMesh, release *Release) (*Manifests, error) {
 	manifests := Manifests{}
 
-	imcManifests, err := loadManifests("eventing-latest", "in-memory-channel.yaml")
+	imcManifests, err := loadComponentManifests(ComponentInMemoryChannel, release.eventingDir(), "in-memory-channel.yaml")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load eventing IMC manifests: %w", err)
 	}
 
 	// we install the manifests by default, but scale the deployments down when no IMC exists
-	manifests.AddToApply(imcManifests)
+	manifests.AddToApplyOrDelete(em.Spec.Components.IsInMemoryChannelEnabled(), imcManifests)
 
 	return &manifests, nil
 }
 
-func (p *eventingParser) eventingMTBrokerManifests(ctx context.Context, em *v1alpha1.EventMesh) (*Manifests, error) {
+func (p *eventingParser) eventingMTBrokerManifests(ctx context.Context, em *v1alpha1.EventMesh, release *Release) (*Manifests, error) {
 	manifests := Manifests{}
 
-	mtBroker, err := loadManifests("eventing-latest", "mt-channel-broker.yaml")
+	mtBroker, err := loadComponentManifests(ComponentMTChannelBroker, release.eventingDir(), "mt-channel-broker.yaml")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load eventing MT channel broker manifests: %w", err)
 	}
 
 	// we install the manifests by default, but scale the deployments down when no MTCB broker exists
-	manifests.AddToApply(mtBroker)
+	manifests.AddToApplyOrDelete(em.Spec.Components.IsMTChannelBrokerEnabled(), mtBroker)
 
 	return &manifests, nil
 }
 
-func (p *eventingParser) eventingTLSManifests(ctx context.Context, em *v1alpha1.EventMesh) (*Manifests, error) {
+func (p *eventingParser) eventingTLSManifests(ctx context.Context, em *v1alpha1.EventMesh, release *Release) (*Manifests, error) {
 	manifests := Manifests{}
 
-	tlsManifests, err := loadManifests("eventing-latest", "eventing-tls-networking.yaml")
+	tlsManifests, err := loadComponentManifests(ComponentEventingTLS, release.eventingDir(), "eventing-tls-networking.yaml")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load eventing TLS manifests: %w", err)
 	}
@@ -146,23 +186,32 @@ func (p *eventingParser) eventingTLSManifests(ctx context.Context, em *v1alpha1.
 	return &manifests, nil
 }
 
-func (p *eventingParser) eventingPostInstallManifests(ctx context.Context, em *v1alpha1.EventMesh, manifests *Manifests) (*Manifests, error) {
+func (p *eventingParser) eventingPostInstallManifests(ctx context.Context, em *v1alpha1.EventMesh, release *Release, manifests *Manifests) (*Manifests, error) {
 	logger := logging.FromContext(ctx)
 
-	upgrade, err := isUpgrade(manifests.ToApply, p.deploymentLister)
+	upgrade, err := isUpgrade(manifests.ToApply, em.Status.EventingVersion, p.deploymentLister)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check if this is an upgrade: %w", err)
 	}
-
 	if upgrade {
-		logger.Debug("Adding post-install manifests because at least minor version of manifests are upgraded")
-		// we only install post-install manifests on updates
+		utils.RecordEvent(ctx, em, upgradeDetectedEvent(ComponentEventingCore, em.Status.EventingVersion, release.Eventing))
+	}
+
+	if upgrade || em.Status.IsPostInstallPending() {
+		logger.Debug("Adding post-install manifests because at least minor version of manifests are upgraded or the post-install jobs did not complete yet")
+		// we only install post-install manifests on updates and until their jobs completed
 
-		postInstallManifests, err := loadManifests("eventing-latest", "eventing-post-install.yaml")
+		postInstallManifests, err := loadComponentManifests(ComponentEventingCore, release.eventingDir(), "eventing-post-install.yaml")
 		if err != nil {
 			return nil, fmt.Errorf("failed to load eventing post-install manifests: %w", err)
 		}
 
+		// name the jobs per version to create them once per upgrade
+		postInstallManifests, err = postInstallManifests.Transform(transform.PostInstallJobName())
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform eventing post-install manifests: %w", err)
+		}
+
 		result := Manifests{}
 		result.AddToPostInstall(postInstallManifests)
 
@@ -173,11 +222,11 @@ func (p *eventingParser) eventingPostInstallManifests(ctx context.Context, em *v
 	return nil, nil
 }
 
-func (p *eventingParser) loadEventingCoreManifests() (mf.Manifest, error) {
+func (p *eventingParser) loadEventingCoreManifests(release *Release) (mf.Manifest, error) {
 	eventingCoreFiles := []string{
 		"eventing-crds.yaml",
 		"eventing-core.yaml",
 	}
 
-	return loadManifests("eventing-latest", eventingCoreFiles...)
+	return loadComponentManifests(ComponentEventingCore, release.eventingDir(), eventingCoreFiles...)
 }
@@ -34,19 +34,24 @@ func NewKafkaBrokerParser(crdLister apiextensionsv1.CustomResourceDefinitionList
 func (p *kafkaBrokerParser) Parse(ctx context.Context, em *v1alpha1.EventMesh) (*Manifests, error) {
 	manifests := &Manifests{}
 
-	coreManifests, err := p.eventingKafkaBrokerCoreManifests(em)
+	release, err := PlanRelease(em)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve release: %w", err)
+	}
+
+	coreManifests, err := p.eventingKafkaBrokerCoreManifests(em, release)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load EKB core manifests: %w", err)
 	}
 	manifests.Append(coreManifests)
 
-	tlsManifests, err := p.eventingKafkaBrokerTLSManifests(em)
+	tlsManifests, err := p.eventingKafkaBrokerTLSManifests(em, release)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load EKB tls manifests: %w", err)
 	}
 	manifests.Append(tlsManifests)
 
-	postInstallManifests, err := p.eventingKafkaBrokerPostInstallManifests(ctx, em, coreManifests)
+	postInstallManifests, err := p.eventingKafkaBrokerPostInstallManifests(ctx, em, release, coreManifests)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load EKB post-install manifests: %w", err)
 	}
@@ -55,34 +60,57 @@ func (p *kafkaBrokerParser) Parse(ctx context.Context, em *v1alpha1.EventMesh) (
 	return manifests, nil
 }
 
-func (p *kafkaBrokerParser) eventingKafkaBrokerCoreManifests(em *v1alpha1.EventMesh) (*Manifests, error) {
+func (p *kafkaBrokerParser) eventingKafkaBrokerCoreManifests(em *v1alpha1.EventMesh, release *Release) (*Manifests, error) {
 	manifests := Manifests{}
 
-	coreManifests, err := p.loadEventingKafkaBrokerCoreManifests()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load EKB core manifests: %w", err)
+	components := em.Spec.Components
+	ekbCoreFiles := []struct {
+		component string
+		file      string
+		enabled   bool
+	}{
+		{component: ComponentKafkaController, file: "eventing-kafka-controller.yaml", enabled: components.IsKafkaEnabled()},
+		{component: ComponentKafkaBroker, file: "eventing-kafka-broker.yaml", enabled: components.IsKafkaBrokerEnabled()},
+		{component: ComponentKafkaChannel, file: "eventing-kafka-channel.yaml", enabled: components.IsKafkaChannelEnabled()},
+		{component: ComponentKafkaSink, file: "eventing-kafka-sink.yaml", enabled: components.IsKafkaSinkEnabled()},
+		{component: ComponentKafkaSource, file: "eventing-kafka-source.yaml", enabled: components.IsKafkaSourceEnabled()},
+	}
+
+	for _, f := range ekbCoreFiles {
+		coreManifests, err := loadComponentManifests(f.component, release.eventingKafkaBrokerDir(), f.file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load EKB core manifests: %w", err)
+		}
+		manifests.AddToApplyOrDelete(f.enabled, coreManifests)
 	}
-	manifests.AddToApply(coreManifests)
 
 	// we need a CM for the kafka-channel template
-	manifests.AddToApply(p.eventingKafkaChannelTemplateConfigMap(em))
+	channelTemplate, err := withComponent(ComponentKafkaChannel, p.eventingKafkaChannelTemplateConfigMap(em))
+	if err != nil {
+		return nil, err
+	}
+	manifests.AddToApplyOrDelete(components.IsKafkaChannelEnabled(), channelTemplate)
 
 	manifests.AddTransformers(
-		transform.KafkaLogging(em.Spec.LogLevel),
+		transform.KafkaLogging(em.Spec.LogLevel, em.Spec.Logging),
 		transform.EventingKafkaBrokerFeatureFlags(em.Spec.Features),
+		transform.Observability(em.Spec.Observability),
 		transform.BootstrapServers(em.Spec.Kafka.BootstrapServers),
+		transform.KafkaAuthSecret(em.Spec.Kafka.AuthSecretRef),
 		transform.NumberOfPartitions(em.Spec.Kafka.NumPartitions),
 		transform.ReplicationFactor(em.Spec.Kafka.ReplicationFactor),
 		transform.KafkaTopicOption(em.Spec.Kafka.TopicConfigOptions),
+		transform.NamespacedBrokerResources(em.Spec.Kafka.NamespacedBroker),
+		transform.KafkaAutoscaling(em.Spec.Kafka.Autoscaling),
 	)
 
 	return &manifests, nil
 }
 
-func (p *kafkaBrokerParser) eventingKafkaBrokerTLSManifests(em *v1alpha1.EventMesh) (*Manifests, error) {
+func (p *kafkaBrokerParser) eventingKafkaBrokerTLSManifests(em *v1alpha1.EventMesh, release *Release) (*Manifests, error) {
 	manifests := Manifests{}
 
-	tlsManifests, err := loadManifests("eventing-kafka-broker-latest", "eventing-kafka-tls-networking.yaml")
+	tlsManifests, err := loadComponentManifests(ComponentKafkaTLS, release.eventingKafkaBrokerDir(), "eventing-kafka-tls-networking.yaml")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load EKB TLS manifests: %w", err)
 	}
@@ -97,7 +125,11 @@ func (p *kafkaBrokerParser) eventingKafkaBrokerTLSManifests(em *v1alpha1.EventMe
 		return nil, fmt.Errorf("failed to load feature flags: %w", err)
 	}
 
-	if !features.IsDisabledTransportEncryption() {
+	if !em.Spec.Components.IsKafkaEnabled() {
+		if certManagerIsInstalled {
+			manifests.AddToDelete(tlsManifests)
+		}
+	} else if !features.IsDisabledTransportEncryption() {
 		if certManagerIsInstalled {
 			manifests.AddToApply(tlsManifests)
 		} else {
@@ -112,23 +144,37 @@ func (p *kafkaBrokerParser) eventingKafkaBrokerTLSManifests(em *v1alpha1.EventMe
 	return &manifests, nil
 }
 
-func (p *kafkaBrokerParser) eventingKafkaBrokerPostInstallManifests(ctx context.Context, em *v1alpha1.EventMesh, manifests *Manifests) (*Manifests, error) {
+func (p *kafkaBrokerParser) eventingKafkaBrokerPostInstallManifests(ctx context.Context, em *v1alpha1.EventMesh, release *Release, manifests *Manifests) (*Manifests, error) {
 	logger := logging.FromContext(ctx)
 
-	upgrade, err := isUpgrade(manifests.ToApply, p.deploymentLister)
+	if !em.Spec.Components.IsKafkaEnabled() {
+		logger.Debug("Skipping to add EKB post-install manifests, as Kafka components are disabled")
+		return nil, nil
+	}
+
+	upgrade, err := isUpgrade(manifests.ToApply, em.Status.EventingKafkaBrokerVersion, p.deploymentLister)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check if this is an upgrade: %w", err)
 	}
-
 	if upgrade {
-		logger.Debug("Adding post-install manifests because at least minor version of manifests are upgraded")
-		// we only install post-install manifests on updates
+		utils.RecordEvent(ctx, em, upgradeDetectedEvent(ComponentKafkaController, em.Status.EventingKafkaBrokerVersion, release.EventingKafkaBroker))
+	}
+
+	if upgrade || em.Status.IsPostInstallPending() {
+		logger.Debug("Adding post-install manifests because at least minor version of manifests are upgraded or the post-install jobs did not complete yet")
+		// we only install post-install manifests on updates and until their jobs completed
 
-		postInstallManifests, err := loadManifests("eventing-kafka-broker-latest", "eventing-kafka-post-install.yaml")
+		postInstallManifests, err := loadComponentManifests(ComponentKafkaController, release.eventingKafkaBrokerDir(), "eventing-kafka-post-install.yaml")
 		if err != nil {
 			return nil, fmt.Errorf("failed to load EKB post-install manifests: %w", err)
 		}
 
+		// name the jobs per version to create them once per upgrade
+		postInstallManifests, err = postInstallManifests.Transform(transform.PostInstallJobName())
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform EKB post-install manifests: %w", err)
+		}
+
 		result := Manifests{}
 		result.AddToPostInstall(postInstallManifests)
 
@@ -139,18 +185,6 @@ func (p *kafkaBrokerParser) eventingKafkaBrokerPostInstallManifests(ctx context.
 	return nil, nil
 }
 
-func (p *kafkaBrokerParser) loadEventingKafkaBrokerCoreManifests() (mf.Manifest, error) {
-	ekbCoreFiles := []string{
-		"eventing-kafka-controller.yaml",
-		"eventing-kafka-broker.yaml",
-		"eventing-kafka-channel.yaml",
-		"eventing-kafka-sink.yaml",
-		"eventing-kafka-source.yaml",
-	}
-
-	return loadManifests("eventing-kafka-broker-latest", ekbCoreFiles...)
-}
-
 func (p *kafkaBrokerParser) eventingKafkaChannelTemplateConfigMap(em *v1alpha1.EventMesh) mf.Manifest {
 	cm := fmt.Sprintf(`apiVersion: v1
 kind: ConfigMap
@@ -22,6 +22,16 @@ func (m *Manifests) AddToDelete(manifests mf.Manifest) {
 	m.ToDelete = m.ToDelete.Append(manifests)
 }
 
+// AddToApplyOrDelete adds the manifests to the manifests to apply if the component is enabled and to the manifests to
+// delete otherwise. The CRDs of disabled components are kept, as deleting them deletes all their custom resources.
+func (m *Manifests) AddToApplyOrDelete(enabled bool, manifests mf.Manifest) {
+	if enabled {
+		m.AddToApply(manifests)
+	} else {
+		m.AddToDelete(manifests.Filter(mf.NoCRDs))
+	}
+}
+
 func (m *Manifests) AddToPostInstall(manifests mf.Manifest) {
 	m.PostInstall = m.PostInstall.Append(manifests)
 }
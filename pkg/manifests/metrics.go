@@ -0,0 +1,50 @@
+package manifests
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"knative.dev/pkg/observability/attributekey"
+)
+
+const (
+	scopeName = "knative.dev/eventmesh-operator/pkg/manifests"
+
+	OperationApply  = "apply"
+	OperationDelete = "delete"
+)
+
+// OperationAttr is the operation, which was run on the resources. One of OperationApply or OperationDelete.
+var OperationAttr = attributekey.String("kn.eventmesh.operation")
+
+type metrics struct {
+	resources metric.Int64Histogram
+}
+
+var installMetrics = newMetrics(otel.GetMeterProvider())
+
+func newMetrics(provider metric.MeterProvider) *metrics {
+	var (
+		m   metrics
+		err error
+	)
+
+	meter := provider.Meter(scopeName)
+
+	m.resources, err = meter.Int64Histogram(
+		"kn.eventmesh.reconcile.resources",
+		metric.WithDescription("The number of resources applied or deleted per reconcile."),
+		metric.WithUnit("{resource}"),
+		metric.WithExplicitBucketBoundaries(0, 1, 5, 10, 25, 50, 100, 250, 500, 1000),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return &m
+}
+
+func (m *metrics) recordResources(ctx context.Context, operation string, count int) {
+	m.resources.Record(ctx, int64(count), metric.WithAttributes(OperationAttr.With(operation)))
+}
@@ -0,0 +1,95 @@
+package manifests
+
+import (
+	"context"
+	"testing"
+
+	mf "github.com/manifestival/manifestival"
+	apiextensionsv1listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+)
+
+func TestParseDisabledComponentsKeepCRDs(t *testing.T) {
+	em := &v1alpha1.EventMesh{
+		Spec: v1alpha1.EventMeshSpec{
+			Components: &v1alpha1.EventMeshSpecComponents{
+				InMemoryChannel: &v1alpha1.ComponentSpec{Enabled: ptr.To(false)},
+				MTChannelBroker: &v1alpha1.ComponentSpec{Enabled: ptr.To(false)},
+				PingSource:      &v1alpha1.ComponentSpec{Enabled: ptr.To(false)},
+				Kafka:           &v1alpha1.KafkaComponentsSpec{Enabled: ptr.To(false)},
+			},
+		},
+	}
+
+	manifests := parseTestManifests(t, em)
+
+	if len(manifests.ToDelete.Resources()) == 0 {
+		t.Fatal("no manifests of the disabled components are deleted")
+	}
+	for _, crd := range manifests.ToDelete.Filter(mf.CRDs).Resources() {
+		t.Errorf("CRD %s of a disabled component is deleted", crd.GetName())
+	}
+}
+
+func TestParseRequestMetrics(t *testing.T) {
+	em := &v1alpha1.EventMesh{
+		Spec: v1alpha1.EventMeshSpec{
+			Observability: &v1alpha1.EventMeshSpecObservability{
+				RequestMetrics: ptr.To(true),
+			},
+		},
+	}
+	em.SetDefaults(context.Background())
+
+	manifests := parseTestManifests(t, em)
+	if err := manifests.TransformToApply(); err != nil {
+		t.Fatalf("TransformToApply() = %v", err)
+	}
+
+	dispatchers := manifests.ToApply.Filter(mf.ByKind("StatefulSet"), mf.ByName("kafka-broker-dispatcher")).Resources()
+	if len(dispatchers) != 1 {
+		t.Fatalf("got %d kafka-broker-dispatcher StatefulSets, want 1", len(dispatchers))
+	}
+
+	found := false
+	containers, _, _ := unstructured.NestedSlice(dispatchers[0].Object, "spec", "template", "spec", "containers")
+	for _, c := range containers {
+		env, _, _ := unstructured.NestedSlice(c.(map[string]interface{}), "env")
+		for _, e := range env {
+			if e := e.(map[string]interface{}); e["name"] == "METRICS_PUBLISH_QUANTILES" {
+				found = true
+				if e["value"] != "true" {
+					t.Errorf("METRICS_PUBLISH_QUANTILES = %v, want true", e["value"])
+				}
+			}
+		}
+	}
+	if !found {
+		t.Error("METRICS_PUBLISH_QUANTILES is not set on the kafka-broker-dispatcher")
+	}
+}
+
+// parseTestManifests parses the manifests of the bundled releases with the eventing and the Kafka parser
+func parseTestManifests(t *testing.T, em *v1alpha1.EventMesh) *Manifests {
+	t.Helper()
+	t.Setenv("KO_DATA_PATH", "../../cmd/operator/kodata")
+	t.Setenv("SYSTEM_NAMESPACE", "knative-eventing")
+
+	crdLister := apiextensionsv1listers.NewCustomResourceDefinitionLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}))
+	deploymentLister := appsv1listers.NewDeploymentLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}))
+
+	manifests := &Manifests{}
+	for _, parser := range []Parser{NewEventingParser(crdLister, deploymentLister), NewKafkaBrokerParser(crdLister, deploymentLister)} {
+		parsed, err := parser.Parse(context.Background(), em)
+		if err != nil {
+			t.Fatalf("Parse() = %v", err)
+		}
+		manifests.Append(parsed)
+	}
+
+	return manifests
+}
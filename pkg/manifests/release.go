@@ -0,0 +1,176 @@
+package manifests
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"sync"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+const (
+	eventingBundle            = "eventing"
+	eventingKafkaBrokerBundle = "eventing-kafka-broker"
+)
+
+// ErrUnknownVersion is returned, when no release matches the requested version
+var ErrUnknownVersion = errors.New("unknown version")
+
+// Release is a pair of an eventing and an eventing-kafka-broker manifest bundle of the same minor version
+type Release struct {
+	Eventing            *semver.Version
+	EventingKafkaBroker *semver.Version
+}
+
+// Version returns the version of the release, which is the version of the eventing bundle
+func (r *Release) Version() string {
+	return r.Eventing.String()
+}
+
+func (r *Release) eventingDir() string {
+	return path.Join(eventingBundle, r.Eventing.String())
+}
+
+func (r *Release) eventingKafkaBrokerDir() string {
+	return path.Join(eventingKafkaBrokerBundle, r.EventingKafkaBroker.String())
+}
+
+// releaseCache holds the releases by the kodata path they were read from. The bundles don't change, while the
+// operator is running, so they are only read once.
+var releaseCache sync.Map
+
+// AvailableReleases returns all releases bundled in kodata, sorted ascending by version. Each eventing bundle is
+// paired with the eventing-kafka-broker bundle with the highest patch version of the same minor version. Eventing
+// bundles without a matching eventing-kafka-broker bundle are skipped. The releases are read from kodata on the first
+// call and cached afterwards.
+func AvailableReleases() ([]Release, error) {
+	koDataPath := os.Getenv("KO_DATA_PATH")
+	if cached, ok := releaseCache.Load(koDataPath); ok {
+		return slices.Clone(cached.([]Release)), nil
+	}
+
+	releases, err := readReleases(koDataPath)
+	if err != nil {
+		return nil, err
+	}
+	releaseCache.Store(koDataPath, releases)
+
+	return slices.Clone(releases), nil
+}
+
+// readReleases reads the releases bundled in the given kodata path
+func readReleases(koDataPath string) ([]Release, error) {
+	eventingVersions, err := bundleVersions(koDataPath, eventingBundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eventing bundle versions: %w", err)
+	}
+
+	ekbVersions, err := bundleVersions(koDataPath, eventingKafkaBrokerBundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eventing-kafka-broker bundle versions: %w", err)
+	}
+
+	var releases []Release
+	for _, eventingVersion := range eventingVersions {
+		var ekbVersion *semver.Version
+		for _, v := range ekbVersions {
+			if v.Major == eventingVersion.Major && v.Minor == eventingVersion.Minor {
+				// versions are sorted, so the last match is the highest patch version
+				ekbVersion = v
+			}
+		}
+
+		if ekbVersion == nil {
+			continue
+		}
+
+		releases = append(releases, Release{
+			Eventing:            eventingVersion,
+			EventingKafkaBroker: ekbVersion,
+		})
+	}
+
+	return releases, nil
+}
+
+// ResolveRelease returns the release for the given version. The version can either be given as major.minor, which
+// resolves to the highest patch version of this minor version, or as full version of the eventing bundle. An empty
+// version resolves to the latest release.
+func ResolveRelease(version string) (*Release, error) {
+	releases, err := AvailableReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("no releases found in %s", os.Getenv("KO_DATA_PATH"))
+	}
+
+	if version == "" {
+		return &releases[len(releases)-1], nil
+	}
+
+	// walk backwards to get the highest patch version first
+	for i := len(releases) - 1; i >= 0; i-- {
+		if slices.Contains(releaseVersionAliases(releases[i]), version) {
+			return &releases[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w %q", ErrUnknownVersion, version)
+}
+
+// AvailableVersions returns all versions, which can be used to pin an EventMesh to a release
+func AvailableVersions() ([]string, error) {
+	releases, err := AvailableReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, release := range releases {
+		for _, alias := range releaseVersionAliases(release) {
+			if !slices.Contains(versions, alias) {
+				versions = append(versions, alias)
+			}
+		}
+	}
+
+	return versions, nil
+}
+
+func releaseVersionAliases(release Release) []string {
+	return []string{
+		fmt.Sprintf("%d.%d", release.Eventing.Major, release.Eventing.Minor),
+		release.Eventing.String(),
+	}
+}
+
+// bundleVersions returns the sorted versions of the given bundle in kodata. Entries, which are not named by a
+// version, are skipped.
+func bundleVersions(koDataPath, bundle string) ([]*semver.Version, error) {
+	entries, err := os.ReadDir(path.Join(koDataPath, bundle))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle directory: %w", err)
+	}
+
+	var versions []*semver.Version
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		v, err := semver.NewVersion(entry.Name())
+		if err != nil {
+			continue
+		}
+		versions = append(versions, v)
+	}
+
+	semver.Sort(versions)
+
+	return versions, nil
+}
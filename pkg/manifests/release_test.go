@@ -0,0 +1,146 @@
+package manifests
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResolveRelease(t *testing.T) {
+	setupKoData(t, map[string][]string{
+		eventingBundle:            {"1.18.0", "1.18.1", "1.19.2", "1.20.0"},
+		eventingKafkaBrokerBundle: {"1.18.3", "1.19.0", "1.19.3"},
+	})
+
+	tests := []struct {
+		name                    string
+		version                 string
+		wantEventing            string
+		wantEventingKafkaBroker string
+		wantErr                 error
+	}{
+		{
+			name:                    "empty version resolves to latest release with both bundles",
+			version:                 "",
+			wantEventing:            "1.19.2",
+			wantEventingKafkaBroker: "1.19.3",
+		},
+		{
+			name:                    "minor version resolves to latest patch",
+			version:                 "1.18",
+			wantEventing:            "1.18.1",
+			wantEventingKafkaBroker: "1.18.3",
+		},
+		{
+			name:                    "full version",
+			version:                 "1.18.0",
+			wantEventing:            "1.18.0",
+			wantEventingKafkaBroker: "1.18.3",
+		},
+		{
+			name:    "eventing bundle without eventing-kafka-broker bundle",
+			version: "1.20",
+			wantErr: ErrUnknownVersion,
+		},
+		{
+			name:    "unknown version",
+			version: "1.17",
+			wantErr: ErrUnknownVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release, err := ResolveRelease(tt.version)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveRelease() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if release.Eventing.String() != tt.wantEventing {
+				t.Errorf("ResolveRelease() eventing = %s, want %s", release.Eventing, tt.wantEventing)
+			}
+			if release.EventingKafkaBroker.String() != tt.wantEventingKafkaBroker {
+				t.Errorf("ResolveRelease() eventing-kafka-broker = %s, want %s", release.EventingKafkaBroker, tt.wantEventingKafkaBroker)
+			}
+		})
+	}
+}
+
+func TestAvailableVersions(t *testing.T) {
+	setupKoData(t, map[string][]string{
+		eventingBundle:            {"1.19.2", "1.18.0", "1.18.1"},
+		eventingKafkaBrokerBundle: {"1.18.3", "1.19.3"},
+	})
+
+	versions, err := AvailableVersions()
+	if err != nil {
+		t.Fatalf("AvailableVersions() error = %v", err)
+	}
+
+	want := []string{"1.18", "1.18.0", "1.18.1", "1.19", "1.19.2"}
+	if diff := cmp.Diff(want, versions); diff != "" {
+		t.Errorf("AvailableVersions() (-want, +got) = %s", diff)
+	}
+}
+
+func TestAvailableReleasesSkipsNonVersions(t *testing.T) {
+	setupKoData(t, map[string][]string{
+		eventingBundle:            {"1.19.2", "latest"},
+		eventingKafkaBrokerBundle: {"1.19.3", ".cache"},
+	})
+
+	releases, err := AvailableReleases()
+	if err != nil {
+		t.Fatalf("AvailableReleases() error = %v", err)
+	}
+	if len(releases) != 1 || releases[0].Version() != "1.19.2" {
+		t.Errorf("AvailableReleases() = %v, want release 1.19.2", releases)
+	}
+}
+
+func TestAvailableReleasesCached(t *testing.T) {
+	setupKoData(t, map[string][]string{
+		eventingBundle:            {"1.19.2"},
+		eventingKafkaBrokerBundle: {"1.19.3"},
+	})
+
+	if _, err := AvailableReleases(); err != nil {
+		t.Fatalf("AvailableReleases() error = %v", err)
+	}
+
+	// bundles added later are not read again
+	for _, bundle := range []string{eventingBundle, eventingKafkaBrokerBundle} {
+		if err := os.MkdirAll(path.Join(os.Getenv("KO_DATA_PATH"), bundle, "1.20.0"), 0755); err != nil {
+			t.Fatalf("failed to create bundle directory: %v", err)
+		}
+	}
+
+	release, err := ResolveRelease("")
+	if err != nil {
+		t.Fatalf("ResolveRelease() error = %v", err)
+	}
+	if release.Version() != "1.19.2" {
+		t.Errorf("ResolveRelease() = %s, want cached release 1.19.2", release.Version())
+	}
+}
+
+func setupKoData(t *testing.T, bundles map[string][]string) {
+	t.Helper()
+
+	koDataPath := t.TempDir()
+	for bundle, versions := range bundles {
+		for _, version := range versions {
+			if err := os.MkdirAll(path.Join(koDataPath, bundle, version), 0755); err != nil {
+				t.Fatalf("failed to create bundle directory: %v", err)
+			}
+		}
+	}
+
+	t.Setenv("KO_DATA_PATH", koDataPath)
+}
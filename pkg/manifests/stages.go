@@ -3,10 +3,14 @@ package manifests
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
 	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/eventmesh-operator/pkg/utils"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
 )
 
 func AppendFromParser(ctx context.Context, parser Parser) func(context.Context, *Manifests, *v1alpha1.EventMesh) error {
@@ -41,7 +45,9 @@ func Transform(ctx context.Context, manifests *Manifests, em *v1alpha1.EventMesh
 	return nil
 }
 
-func Install(baseManifest mf.Manifest) func(ctx context.Context, manifests *Manifests, em *v1alpha1.EventMesh) error {
+// Install deletes the unneeded manifests and applies the manifests and post-install manifests via server-side apply.
+// Field ownership conflicts are reported in the status and as events.
+func Install(applier *Applier) func(ctx context.Context, manifests *Manifests, em *v1alpha1.EventMesh) error {
 	return func(ctx context.Context, manifests *Manifests, em *v1alpha1.EventMesh) error {
 		logger := logging.FromContext(ctx)
 
@@ -50,22 +56,98 @@ func Install(baseManifest mf.Manifest) func(ctx context.Context, manifests *Mani
 
 		// Delete old manifests
 		logger.Debugf("Deleting unneeded manifests (%d)", len(manifests.ToDelete.Resources()))
-		if err := baseManifest.Append(manifests.ToDelete).Delete(ctx, mf.IgnoreNotFound(true)); err != nil {
+		deleted, err := applier.Delete(ctx, manifests.ToDelete)
+		installMetrics.recordResources(ctx, OperationDelete, deleted)
+		if err != nil {
 			return fmt.Errorf("failed to delete manifests: %w", err)
 		}
+		if deleted > 0 {
+			utils.RecordEvent(ctx, em, reconciler.NewEvent(corev1.EventTypeNormal, "ResourcesDeleted", "Deleted %d resources of disabled or removed components", deleted))
+		}
 
 		// Install manifests
 		logger.Debugf("Applying manifests (%d)", len(manifests.ToApply.Resources()))
-		if err := baseManifest.Append(manifests.ToApply).Apply(ctx); err != nil {
+		applied, err := applier.Apply(ctx, manifests.ToApply, em.Spec.Apply.ShouldForceConflicts)
+		if err != nil {
 			return fmt.Errorf("failed to apply manifests: %w", err)
 		}
 
 		// Install post-install manifests
 		logger.Debugf("Applying post-install manifests (%d)", len(manifests.PostInstall.Resources()))
-		if err := baseManifest.Append(manifests.PostInstall).Apply(ctx); err != nil {
+		postInstallApplied, err := applier.Apply(ctx, manifests.PostInstall, em.Spec.Apply.ShouldForceConflicts)
+		if err != nil {
 			return fmt.Errorf("failed to apply post-install manifests: %w", err)
 		}
 
+		installMetrics.recordResources(ctx, OperationApply, len(manifests.ToApply.Resources())+len(manifests.PostInstall.Resources()))
+		if applied.Changed > 0 || postInstallApplied.Changed > 0 {
+			utils.RecordEvent(ctx, em, reconciler.NewEvent(corev1.EventTypeNormal, "ManifestsApplied", "Changed %d manifests and %d post-install manifests", applied.Changed, postInstallApplied.Changed))
+		}
+
+		recordConflicts(ctx, em, append(applied.Conflicts, postInstallApplied.Conflicts...))
+
+		return nil
+	}
+}
+
+// recordConflicts reports the field ownership conflicts in the FieldsOwned condition and as warning events. Fields,
+// which were taken over, only mark the condition as false, if other conflicts were not forced.
+func recordConflicts(ctx context.Context, em *v1alpha1.EventMesh, conflicts []FieldConflict) {
+	if len(conflicts) == 0 {
+		em.Status.MarkFieldsOwned()
+		return
+	}
+
+	var resources, forced []string
+	for _, conflict := range conflicts {
+		if conflict.Forced {
+			logging.FromContext(ctx).Infof("Took over fields of %s from other managers: %s", conflict.Resource, strings.Join(conflict.Causes, ", "))
+			utils.RecordEvent(ctx, em, reconciler.NewEvent(corev1.EventTypeWarning, "FieldOwnershipTakenOver", "Took over fields of %s from other managers: %s", conflict.Resource, strings.Join(conflict.Causes, ", ")))
+			forced = append(forced, conflict.String())
+			continue
+		}
+		logging.FromContext(ctx).Warnf("Fields of %s are owned by other managers: %s", conflict.Resource, strings.Join(conflict.Causes, ", "))
+		utils.RecordEvent(ctx, em, reconciler.NewEvent(corev1.EventTypeWarning, "FieldOwnershipConflict", "Fields of %s are owned by other managers: %s", conflict.Resource, strings.Join(conflict.Causes, ", ")))
+		resources = append(resources, conflict.String())
+	}
+
+	if len(resources) == 0 {
+		em.Status.MarkFieldOwnershipTakenOver(forced)
+		return
+	}
+	em.Status.MarkFieldOwnershipConflicts(resources)
+}
+
+// Uninstall deletes the manifests to apply and the post-install manifests in reverse kind order. Namespaces are kept,
+// as the operator might run in the system namespace. If keepCRDs is set, the CRDs are kept and re-applied without
+// the owner reference, so that they and their custom resources are not garbage collected with the EventMesh.
+func Uninstall(applier *Applier, keepCRDs bool) func(ctx context.Context, manifests *Manifests, em *v1alpha1.EventMesh) error {
+	return func(ctx context.Context, manifests *Manifests, em *v1alpha1.EventMesh) error {
+		logger := logging.FromContext(ctx)
+
+		toDelete := manifests.ToApply.Append(manifests.PostInstall).Filter(mf.Not(mf.ByKind("Namespace")))
+
+		if keepCRDs {
+			crds := toDelete.Filter(mf.CRDs)
+			logger.Debugf("Removing owner references from CRDs (%d)", len(crds.Resources()))
+			if _, err := applier.Apply(ctx, crds, em.Spec.Apply.ShouldForceConflicts); err != nil {
+				return fmt.Errorf("failed to remove owner references from CRDs: %w", err)
+			}
+
+			toDelete = toDelete.Filter(mf.NoCRDs)
+		}
+
+		// Delete() deletes in reverse order
+		logger.Debugf("Deleting manifests (%d)", len(toDelete.Resources()))
+		deleted, err := applier.Delete(ctx, toDelete.Sort(mf.ByKindPriority()))
+		installMetrics.recordResources(ctx, OperationDelete, deleted)
+		if err != nil {
+			return fmt.Errorf("failed to delete manifests: %w", err)
+		}
+		if deleted > 0 {
+			utils.RecordEvent(ctx, em, reconciler.NewEvent(corev1.EventTypeNormal, "ResourcesDeleted", "Deleted %d resources on uninstall", deleted))
+		}
+
 		return nil
 	}
 }
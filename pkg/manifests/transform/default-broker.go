@@ -2,6 +2,7 @@ package transform
 
 import (
 	"fmt"
+	"slices"
 
 	mf "github.com/manifestival/manifestival"
 	corev1 "k8s.io/api/core/v1"
@@ -9,10 +10,20 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"knative.dev/eventing/pkg/apis/config"
 	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/system"
+	"sigs.k8s.io/yaml"
 )
 
-func DefaultBrokerClass(brokerClass, channel string) mf.Transformer {
+// DefaultBrokerClass sets the given broker class as the cluster default and adds the configs for the other
+// enabled broker classes. The namespace defaults are added for all entries, which set a broker class, config or
+// channel. The channel of a namespace is also used for MTChannelBasedBrokers, which aren't the namespace default.
+func DefaultBrokerClass(brokerClass string, enabledBrokerClasses []string, channel string, namespaceDefaults []v1alpha1.NamespaceDefaults) mf.Transformer {
+	if brokerClass == "" {
+		// no broker class enabled. Keep the defaults from the manifests
+		return NoOp()
+	}
+
 	return func(u *unstructured.Unstructured) error {
 		if u.GetKind() != "ConfigMap" {
 			return nil
@@ -27,43 +38,85 @@ func DefaultBrokerClass(brokerClass, channel string) mf.Transformer {
 			return fmt.Errorf("error converting unstructured to configmap: %w", err)
 		}
 
-		if brokerClass == "" {
-			brokerClass = v1alpha1.BrokerClassKafka
+		defaults := config.Defaults{
+			ClusterDefaultConfig: &config.DefaultConfig{
+				DefaultBrokerClass: brokerClass,
+				BrokerConfig:       brokerConfig(configMapForBrokerClass(brokerClass, channel), system.Namespace()),
+			},
+		}
+
+		for _, alternativeBrokerClass := range enabledBrokerClasses {
+			if alternativeBrokerClass == brokerClass {
+				continue
+			}
+
+			if defaults.ClusterDefaultConfig.BrokerClasses == nil {
+				defaults.ClusterDefaultConfig.BrokerClasses = map[string]*config.BrokerConfig{}
+			}
+			defaults.ClusterDefaultConfig.BrokerClasses[alternativeBrokerClass] = brokerConfig(configMapForBrokerClass(alternativeBrokerClass, channel), system.Namespace())
+		}
+
+		for _, nsDefaults := range namespaceDefaults {
+			if nsDefaults.BrokerClass == "" && nsDefaults.BrokerConfig == nil && nsDefaults.Channel == "" {
+				continue
+			}
+
+			nsBrokerClass := nsDefaults.BrokerClass
+			if nsBrokerClass == "" {
+				nsBrokerClass = brokerClass
+			}
+
+			nsChannel := nsDefaults.Channel
+			if nsChannel == "" {
+				nsChannel = channel
+			}
+
+			nsConfig := brokerConfig(configMapForBrokerClass(nsBrokerClass, nsChannel), system.Namespace())
+			if ref := nsDefaults.BrokerConfig; ref != nil {
+				namespace := ref.Namespace
+				if namespace == "" {
+					namespace = nsDefaults.Namespace
+				}
+				nsConfig = brokerConfig(ref.Name, namespace)
+			}
+
+			if defaults.NamespaceDefaultsConfig == nil {
+				defaults.NamespaceDefaultsConfig = map[string]*config.DefaultConfig{}
+			}
+			nsDefaultConfig := &config.DefaultConfig{
+				DefaultBrokerClass: nsBrokerClass,
+				BrokerConfig:       nsConfig,
+			}
+			if nsDefaults.Channel != "" && nsBrokerClass != v1alpha1.BrokerClassMTChannelBased && slices.Contains(enabledBrokerClasses, v1alpha1.BrokerClassMTChannelBased) {
+				nsDefaultConfig.BrokerClasses = map[string]*config.BrokerConfig{
+					v1alpha1.BrokerClassMTChannelBased: brokerConfig(configMapForBrokerClass(v1alpha1.BrokerClassMTChannelBased, nsDefaults.Channel), system.Namespace()),
+				}
+			}
+			defaults.NamespaceDefaultsConfig[nsDefaults.Namespace] = nsDefaultConfig
 		}
 
-		var alternativeBrokerClass string
-		switch brokerClass {
-		case v1alpha1.BrokerClassKafka:
-			alternativeBrokerClass = v1alpha1.BrokerClassMTChannelBased
-		case v1alpha1.BrokerClassMTChannelBased:
-			alternativeBrokerClass = v1alpha1.BrokerClassKafka
+		data, err := yaml.Marshal(defaults)
+		if err != nil {
+			return fmt.Errorf("failed to marshal broker defaults: %w", err)
 		}
 
-		defaults := fmt.Sprintf(`clusterDefault:
-  brokerClass: %s
-  apiVersion: v1
-  kind: ConfigMap
-  name: %s
-  namespace: %s
-  brokerClasses:
-    %s:
-      apiVersion: v1
-      kind: ConfigMap
-      name: %s
-      namespace: %s`,
-			brokerClass,
-			configMapForBrokerClass(brokerClass, channel),
-			system.Namespace(),
-			alternativeBrokerClass,
-			configMapForBrokerClass(alternativeBrokerClass, channel),
-			system.Namespace())
-
-		cm.Data[config.BrokerDefaultsKey] = defaults
+		cm.Data[config.BrokerDefaultsKey] = string(data)
 
 		return scheme.Scheme.Convert(cm, u, nil)
 	}
 }
 
+func brokerConfig(name, namespace string) *config.BrokerConfig {
+	return &config.BrokerConfig{
+		KReference: &duckv1.KReference{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Name:       name,
+			Namespace:  namespace,
+		},
+	}
+}
+
 func configMapForBrokerClass(brokerClass, channel string) string {
 	if brokerClass == v1alpha1.BrokerClassMTChannelBased {
 		if channel == v1alpha1.ChannelImplementationIMC {
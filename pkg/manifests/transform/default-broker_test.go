@@ -0,0 +1,126 @@
+package transform
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"knative.dev/eventing/pkg/apis/config"
+
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+)
+
+func TestDefaultBrokerClassNamespaceDefaults(t *testing.T) {
+	t.Setenv("SYSTEM_NAMESPACE", "knative-eventing")
+
+	u := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      config.DefaultsConfigName,
+				"namespace": "knative-eventing",
+			},
+			"data": map[string]interface{}{
+				config.BrokerDefaultsKey: "",
+			},
+		},
+	}
+
+	namespaceDefaults := []v1alpha1.NamespaceDefaults{
+		{
+			Namespace:   "tenant-a",
+			BrokerClass: v1alpha1.BrokerClassMTChannelBased,
+			Channel:     v1alpha1.ChannelImplementationIMC,
+		},
+		{
+			Namespace:    "tenant-b",
+			BrokerConfig: &v1alpha1.BrokerConfigReference{Name: "tenant-b-config"},
+		},
+		{
+			Namespace: "tenant-c",
+			Channel:   v1alpha1.ChannelImplementationIMC,
+		},
+	}
+
+	transformer := DefaultBrokerClass(
+		v1alpha1.BrokerClassKafka,
+		[]string{v1alpha1.BrokerClassKafka, v1alpha1.BrokerClassMTChannelBased},
+		v1alpha1.ChannelImplementationKafka,
+		namespaceDefaults)
+	if err := transformer(u); err != nil {
+		t.Fatalf("DefaultBrokerClass() = %v", err)
+	}
+
+	data, _, _ := unstructured.NestedStringMap(u.Object, "data")
+	defaults, err := config.NewDefaultsConfigFromMap(data)
+	if err != nil {
+		t.Fatalf("failed to parse broker defaults: %v", err)
+	}
+
+	tests := []struct {
+		namespace   string
+		brokerClass string
+		configName  string
+		configNs    string
+		// mtConfigName is the config of MTChannelBasedBrokers in the namespace
+		mtConfigName string
+	}{
+		{
+			namespace:    "tenant-a",
+			brokerClass:  v1alpha1.BrokerClassMTChannelBased,
+			configName:   "config-br-default-channel",
+			configNs:     "knative-eventing",
+			mtConfigName: "config-br-default-channel",
+		},
+		{
+			namespace:    "tenant-b",
+			brokerClass:  v1alpha1.BrokerClassKafka,
+			configName:   "tenant-b-config",
+			configNs:     "tenant-b",
+			mtConfigName: "kafka-channel",
+		},
+		{
+			namespace:    "tenant-c",
+			brokerClass:  v1alpha1.BrokerClassKafka,
+			configName:   "kafka-broker-config",
+			configNs:     "knative-eventing",
+			mtConfigName: "config-br-default-channel",
+		},
+		{
+			namespace:    "tenant-d",
+			brokerClass:  v1alpha1.BrokerClassKafka,
+			configName:   "kafka-broker-config",
+			configNs:     "knative-eventing",
+			mtConfigName: "kafka-channel",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.namespace, func(t *testing.T) {
+			brokerClass, err := defaults.GetBrokerClass(test.namespace)
+			if err != nil {
+				t.Fatalf("GetBrokerClass() = %v", err)
+			}
+			if brokerClass != test.brokerClass {
+				t.Errorf("GetBrokerClass() = %s, want %s", brokerClass, test.brokerClass)
+			}
+
+			brokerConfig, err := defaults.GetBrokerConfig(test.namespace, ptr.To(brokerClass))
+			if err != nil {
+				t.Fatalf("GetBrokerConfig() = %v", err)
+			}
+			if brokerConfig.Name != test.configName || brokerConfig.Namespace != test.configNs {
+				t.Errorf("GetBrokerConfig() = %s/%s, want %s/%s", brokerConfig.Namespace, brokerConfig.Name, test.configNs, test.configName)
+			}
+
+			mtConfig, err := defaults.GetBrokerConfig(test.namespace, ptr.To(v1alpha1.BrokerClassMTChannelBased))
+			if err != nil {
+				t.Fatalf("GetBrokerConfig(%s) = %v", v1alpha1.BrokerClassMTChannelBased, err)
+			}
+			if mtConfig.Name != test.mtConfigName {
+				t.Errorf("GetBrokerConfig(%s) = %s, want %s", v1alpha1.BrokerClassMTChannelBased, mtConfig.Name, test.mtConfigName)
+			}
+		})
+	}
+}
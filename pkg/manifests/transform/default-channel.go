@@ -5,14 +5,23 @@ import (
 
 	mf "github.com/manifestival/manifestival"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/kubernetes/scheme"
 	"knative.dev/eventing/pkg/apis/messaging/config"
 	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
 	"knative.dev/pkg/system"
+	"sigs.k8s.io/yaml"
 )
 
-func DefaultChannelImplementation(channel string) mf.Transformer {
+// DefaultChannelImplementation sets the given channel implementation as the cluster default. The namespace defaults
+// are added for all entries, which set a channel.
+func DefaultChannelImplementation(channel string, namespaceDefaults []v1alpha1.NamespaceDefaults) mf.Transformer {
+	if channel == "" {
+		// no channel implementation enabled. Keep the defaults from the manifests
+		return NoOp()
+	}
+
 	return func(u *unstructured.Unstructured) error {
 		if u.GetKind() != "ConfigMap" {
 			return nil
@@ -27,17 +36,37 @@ func DefaultChannelImplementation(channel string) mf.Transformer {
 			return fmt.Errorf("error converting unstructured to configmap: %w", err)
 		}
 
-		if channel == "" {
-			channel = v1alpha1.ChannelImplementationKafka
+		defaults := config.ChannelDefaults{
+			ClusterDefault: channelTemplate(channel),
 		}
 
-		defaults := fmt.Sprintf(`clusterDefault:
-  apiVersion: messaging.knative.dev/v1
-  kind: %s`,
-			channel)
+		for _, nsDefaults := range namespaceDefaults {
+			if nsDefaults.Channel == "" {
+				continue
+			}
 
-		cm.Data[config.ChannelDefaulterKey] = defaults
+			if defaults.NamespaceDefaults == nil {
+				defaults.NamespaceDefaults = map[string]*config.ChannelTemplateSpec{}
+			}
+			defaults.NamespaceDefaults[nsDefaults.Namespace] = channelTemplate(nsDefaults.Channel)
+		}
+
+		data, err := yaml.Marshal(defaults)
+		if err != nil {
+			return fmt.Errorf("failed to marshal channel defaults: %w", err)
+		}
+
+		cm.Data[config.ChannelDefaulterKey] = string(data)
 
 		return scheme.Scheme.Convert(cm, u, nil)
 	}
 }
+
+func channelTemplate(channel string) *config.ChannelTemplateSpec {
+	return &config.ChannelTemplateSpec{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "messaging.knative.dev/v1",
+			Kind:       channel,
+		},
+	}
+}
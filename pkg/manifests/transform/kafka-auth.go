@@ -0,0 +1,53 @@
+package transform
+
+import (
+	"fmt"
+
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"knative.dev/pkg/system"
+)
+
+const (
+	kafkaAuthSecretNameKey      = "auth.secret.ref.name"
+	kafkaAuthSecretNamespaceKey = "auth.secret.ref.namespace"
+)
+
+// KafkaAuthSecret references the given secret as the authentication secret in the Kafka broker and channel
+// ConfigMaps. KafkaSinks and KafkaSources reference their authentication secret on the resource itself, therefore
+// the eventing-kafka-broker manifests don't provide a cluster wide default for them which could be set here.
+func KafkaAuthSecret(secretRef *corev1.LocalObjectReference) mf.Transformer {
+	if secretRef == nil || secretRef.Name == "" {
+		return NoOp()
+	}
+
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() != "ConfigMap" {
+			return nil
+		}
+
+		if u.GetNamespace() != system.Namespace() || (u.GetName() != "kafka-broker-config" && u.GetName() != "kafka-channel-config") {
+			return nil
+		}
+
+		var cm = &corev1.ConfigMap{}
+		if err := scheme.Scheme.Convert(u, cm, nil); err != nil {
+			return fmt.Errorf("error converting unstructured to configmap: %w", err)
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+
+		cm.Data[kafkaAuthSecretNameKey] = secretRef.Name
+		if u.GetName() == "kafka-channel-config" {
+			// the channel reads the namespace of the secret from its config. The broker always uses the namespace of
+			// its config
+			cm.Data[kafkaAuthSecretNamespaceKey] = system.Namespace()
+		}
+
+		return scheme.Scheme.Convert(cm, u, nil)
+	}
+}
@@ -0,0 +1,62 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestKafkaAuthSecret(t *testing.T) {
+	t.Setenv("SYSTEM_NAMESPACE", "knative-eventing")
+
+	configMap := func(name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "knative-eventing",
+			},
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		expected map[string]string
+	}{
+		{
+			name:     "kafka-broker-config",
+			expected: map[string]string{"auth.secret.ref.name": "kafka-auth"},
+		},
+		{
+			name:     "kafka-channel-config",
+			expected: map[string]string{"auth.secret.ref.name": "kafka-auth", "auth.secret.ref.namespace": "knative-eventing"},
+		},
+		{
+			// sinks and sources reference their authentication secret on the resource itself
+			name: "config-kafka-sink-data-plane",
+		},
+		{
+			name: "config-kafka-source-defaults",
+		},
+		{
+			name: "config-kafka-features",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := configMap(tt.name)
+			if err := KafkaAuthSecret(&corev1.LocalObjectReference{Name: "kafka-auth"})(u); err != nil {
+				t.Fatalf("KafkaAuthSecret() = %v", err)
+			}
+
+			data, _, _ := unstructured.NestedStringMap(u.Object, "data")
+			if diff := cmp.Diff(tt.expected, data); diff != "" {
+				t.Errorf("data (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
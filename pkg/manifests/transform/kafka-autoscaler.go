@@ -0,0 +1,46 @@
+package transform
+
+import (
+	"fmt"
+
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/pkg/system"
+
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+)
+
+// KafkaAutoscaling writes the autoscaling configuration into the config-kafka-autoscaler ConfigMap and enables the
+// KEDA autoscaler feature of the Kafka controller. It must run after EventingKafkaBrokerFeatureFlags, as this
+// replaces the data of config-kafka-features.
+func KafkaAutoscaling(autoscaling *v1alpha1.KafkaAutoscalingSpec) mf.Transformer {
+	if autoscaling == nil {
+		return NoOp()
+	}
+
+	config := map[string]string{}
+	for key, value := range map[string]*int32{
+		"min-scale":        autoscaling.MinScale,
+		"max-scale":        autoscaling.MaxScale,
+		"polling-interval": autoscaling.PollingInterval,
+		"cooldown-period":  autoscaling.CooldownPeriod,
+		"lag-threshold":    autoscaling.LagThreshold,
+	} {
+		if value != nil {
+			config[key] = fmt.Sprintf("%d", *value)
+		}
+	}
+
+	autoscalerConfig := ConfigMapMultipleValues("config-kafka-autoscaler", system.Namespace(), config, true)
+	kedaFeature := NoOp()
+	if autoscaling.Enabled {
+		kedaFeature = ConfigMap("config-kafka-features", system.Namespace(), "controller-autoscaler-keda", "enabled")
+	}
+
+	return func(u *unstructured.Unstructured) error {
+		if err := autoscalerConfig(u); err != nil {
+			return err
+		}
+		return kedaFeature(u)
+	}
+}
@@ -0,0 +1,88 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+)
+
+func TestKafkaAutoscaling(t *testing.T) {
+	t.Setenv("SYSTEM_NAMESPACE", "knative-eventing")
+
+	configMap := func(name string, data map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "knative-eventing",
+			},
+			"data": data,
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		autoscaling *v1alpha1.KafkaAutoscalingSpec
+		configMap   string
+		data        map[string]interface{}
+		expected    map[string]string
+	}{
+		{
+			name:      "no autoscaling keeps the features",
+			configMap: "config-kafka-features",
+			data:      map[string]interface{}{"controller-autoscaler-keda": "disabled"},
+			expected:  map[string]string{"controller-autoscaler-keda": "disabled"},
+		},
+		{
+			name:        "enabled autoscaling enables the KEDA feature",
+			autoscaling: &v1alpha1.KafkaAutoscalingSpec{Enabled: true},
+			configMap:   "config-kafka-features",
+			data:        map[string]interface{}{"controller-autoscaler-keda": "disabled", "dispatcher.ordered-executor-metrics": "disabled"},
+			expected:    map[string]string{"controller-autoscaler-keda": "enabled", "dispatcher.ordered-executor-metrics": "disabled"},
+		},
+		{
+			name:        "disabled autoscaling keeps the features",
+			autoscaling: &v1alpha1.KafkaAutoscalingSpec{MinScale: ptr.To[int32](1)},
+			configMap:   "config-kafka-features",
+			data:        map[string]interface{}{"controller-autoscaler-keda": "disabled"},
+			expected:    map[string]string{"controller-autoscaler-keda": "disabled"},
+		},
+		{
+			name: "autoscaler configuration is merged",
+			autoscaling: &v1alpha1.KafkaAutoscalingSpec{
+				Enabled:      true,
+				MinScale:     ptr.To[int32](0),
+				MaxScale:     ptr.To[int32](10),
+				LagThreshold: ptr.To[int32](50),
+			},
+			configMap: "config-kafka-autoscaler",
+			data:      map[string]interface{}{"class": "keda.autoscaling.knative.dev", "max-scale": "50", "polling-interval": "10"},
+			expected: map[string]string{
+				"class":            "keda.autoscaling.knative.dev",
+				"min-scale":        "0",
+				"max-scale":        "10",
+				"polling-interval": "10",
+				"lag-threshold":    "50",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := configMap(tt.configMap, tt.data)
+			if err := KafkaAutoscaling(tt.autoscaling)(u); err != nil {
+				t.Fatalf("KafkaAutoscaling() = %v", err)
+			}
+
+			data, _, _ := unstructured.NestedStringMap(u.Object, "data")
+			if diff := cmp.Diff(tt.expected, data); diff != "" {
+				t.Errorf("data (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package transform
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
-	"regexp"
+	"maps"
+	"slices"
+	"sort"
 	"strings"
 
 	mf "github.com/manifestival/manifestival"
@@ -15,7 +18,17 @@ import (
 	"knative.dev/pkg/system"
 )
 
-func EventingCoreLogging(logLevel string) mf.Transformer {
+const (
+	kafkaLoggingConfigMap = "kafka-config-logging"
+	kafkaLoggingConfigKey = "config.xml"
+
+	// consoleLogPattern is the logback pattern of the console format
+	consoleLogPattern = "%d{ISO8601} %-5level %logger - %msg%n"
+)
+
+// EventingCoreLogging configures the config-logging ConfigMap used by the Go components. logLevel is set for all
+// components, unless it is overridden per component in logging. As zap doesn't know trace, it is logged as debug.
+func EventingCoreLogging(logLevel string, logging *v1alpha1.EventMeshSpecLogging) mf.Transformer {
 	return func(u *unstructured.Unstructured) error {
 		if u.GetKind() != "ConfigMap" {
 			return nil
@@ -42,8 +55,25 @@ func EventingCoreLogging(logLevel string) mf.Transformer {
 			}
 		}
 
-		// update logging level in zap config
-		if err := updateZapConfigLevel(cm.Data, newLoggingLevel.String()); err != nil {
+		var encoding string
+		if logging != nil {
+			encoding = logging.Format
+
+			for name, component := range logging.Components {
+				if component.Level == "" || slices.Contains(v1alpha1.KafkaDataPlaneComponents, name) {
+					continue
+				}
+
+				componentLevel, err := convertToZapLogLevel(component.Level)
+				if err != nil {
+					return fmt.Errorf("error parsing log level of %s: %w", name, err)
+				}
+				cm.Data["loglevel."+name] = componentLevel.String()
+			}
+		}
+
+		// update logging level and encoding in zap config
+		if err := updateZapConfig(cm.Data, newLoggingLevel.String(), encoding); err != nil {
 			return fmt.Errorf("error updating zap logging config: %w", err)
 		}
 
@@ -51,62 +81,305 @@ func EventingCoreLogging(logLevel string) mf.Transformer {
 	}
 }
 
-func KafkaLogging(logLevel string) mf.Transformer {
+// KafkaLogging configures the logback configuration in the kafka-config-logging ConfigMap used by the Kafka data
+// planes. The overrides of each data plane are written into a dedicated key of the ConfigMap, which is mounted as
+// config.xml into the data plane instead of the shared configuration.
+func KafkaLogging(logLevel string, logging *v1alpha1.EventMeshSpecLogging) mf.Transformer {
+	var format string
+	overrides := map[string]v1alpha1.EventMeshSpecComponentLogging{}
+	if logging != nil {
+		format = logging.Format
+		for name, component := range logging.Components {
+			if slices.Contains(v1alpha1.KafkaDataPlaneComponents, name) && (component.Level != "" || len(component.Loggers) > 0) {
+				overrides[name] = component
+			}
+		}
+	}
+
 	return func(u *unstructured.Unstructured) error {
-		if u.GetKind() != "ConfigMap" {
-			return nil
+		switch u.GetKind() {
+		case "ConfigMap":
+			if u.GetNamespace() != system.Namespace() || u.GetName() != kafkaLoggingConfigMap {
+				return nil
+			}
+
+			var cm = &corev1.ConfigMap{}
+			if err := scheme.Scheme.Convert(u, cm, nil); err != nil {
+				return fmt.Errorf("error converting unstructured to configmap: %w", err)
+			}
+
+			if err := updateKafkaLoggingConfig(cm.Data, logLevel, format, overrides); err != nil {
+				return fmt.Errorf("error updating kafka logging config: %w", err)
+			}
+
+			return scheme.Scheme.Convert(cm, u, nil)
+		case "Deployment", "StatefulSet":
+			if _, ok := overrides[u.GetName()]; !ok {
+				return nil
+			}
+
+			return mountKafkaLoggingConfig(u, componentLoggingConfigKey(u.GetName()))
 		}
 
-		if u.GetNamespace() != system.Namespace() || u.GetName() != "kafka-config-logging" {
-			return nil
+		return nil
+	}
+}
+
+func updateKafkaLoggingConfig(data map[string]string, logLevel, format string, overrides map[string]v1alpha1.EventMeshSpecComponentLogging) error {
+	configXML, exists := data[kafkaLoggingConfigKey]
+	if !exists {
+		return fmt.Errorf("%s not found in %s configmap", kafkaLoggingConfigKey, kafkaLoggingConfigMap)
+	}
+
+	updatedXML, err := updateLogbackConfig(configXML, logLevel, nil, format)
+	if err != nil {
+		return err
+	}
+	data[kafkaLoggingConfigKey] = updatedXML
+
+	for name, override := range overrides {
+		level := override.Level
+		if level == "" {
+			level = logLevel
 		}
 
-		var cm = &corev1.ConfigMap{}
-		if err := scheme.Scheme.Convert(u, cm, nil); err != nil {
-			return fmt.Errorf("error converting unstructured to configmap: %w", err)
+		componentXML, err := updateLogbackConfig(configXML, level, override.Loggers, format)
+		if err != nil {
+			return fmt.Errorf("error updating logging config of %s: %w", name, err)
 		}
+		data[componentLoggingConfigKey(name)] = componentXML
+	}
+
+	return nil
+}
+
+func componentLoggingConfigKey(component string) string {
+	return component + ".xml"
+}
 
-		if err := updateKafkaLoggingConfig(cm.Data, logLevel); err != nil {
-			return fmt.Errorf("error updating kafka logging config: %w", err)
+// mountKafkaLoggingConfig mounts the given key of the kafka-config-logging ConfigMap as config.xml into the workload
+func mountKafkaLoggingConfig(u *unstructured.Unstructured, key string) error {
+	volumes, found, err := unstructured.NestedSlice(u.Object, "spec", "template", "spec", "volumes")
+	if err != nil || !found {
+		return err
+	}
+
+	for _, volume := range volumes {
+		v, ok := volume.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		configMap, ok := v["configMap"].(map[string]interface{})
+		if !ok || configMap["name"] != kafkaLoggingConfigMap {
+			continue
 		}
 
-		return scheme.Scheme.Convert(cm, u, nil)
+		configMap["items"] = []interface{}{
+			map[string]interface{}{"key": key, "path": kafkaLoggingConfigKey},
+		}
 	}
+
+	return unstructured.SetNestedSlice(u.Object, volumes, "spec", "template", "spec", "volumes")
 }
 
-func updateKafkaLoggingConfig(data map[string]string, logLevel string) error {
-	configXML, exists := data["config.xml"]
-	if !exists {
-		return fmt.Errorf("config.xml not found in kafka-config-logging configmap")
+// xmlNode is a generic XML element, which keeps the elements, attributes and comments of the logback configuration,
+// which aren't modified, as they are. The comments of an element are merged and written before its child elements.
+type xmlNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Text    string     `xml:",chardata"`
+	Comment string     `xml:",comment"`
+	Nodes   []*xmlNode `xml:",any"`
+}
+
+func (n *xmlNode) attr(name string) string {
+	for _, attr := range n.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+
+	return ""
+}
+
+func (n *xmlNode) setAttr(name, value string) {
+	for i := range n.Attrs {
+		if n.Attrs[i].Name.Local == name {
+			n.Attrs[i].Value = value
+			return
+		}
+	}
+
+	n.Attrs = append(n.Attrs, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+}
+
+func (n *xmlNode) children(name string) []*xmlNode {
+	var children []*xmlNode
+	for _, node := range n.Nodes {
+		if node.XMLName.Local == name {
+			children = append(children, node)
+		}
+	}
+
+	return children
+}
+
+// trimText removes the whitespace between the elements, so the configuration can be indented again
+func (n *xmlNode) trimText() {
+	n.Text = strings.TrimSpace(n.Text)
+	for _, node := range n.Nodes {
+		node.trimText()
+	}
+}
+
+// restorePrefixes writes the names of the element, its attributes and children with their namespace prefixes again.
+// The decoder replaces the prefixes with the namespace URIs, which the encoder doesn't map back to the declared prefixes.
+func (n *xmlNode) restorePrefixes(prefixes map[string]string) {
+	for _, attr := range n.Attrs {
+		if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+			prefixes = maps.Clone(prefixes)
+			break
+		}
+	}
+	for _, attr := range n.Attrs {
+		switch {
+		case attr.Name.Space == "xmlns":
+			prefixes[attr.Value] = attr.Name.Local
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+			prefixes[attr.Value] = ""
+		}
+	}
+
+	n.XMLName = prefixedName(n.XMLName, prefixes)
+	for i := range n.Attrs {
+		n.Attrs[i].Name = prefixedName(n.Attrs[i].Name, prefixes)
+	}
+	for _, node := range n.Nodes {
+		node.restorePrefixes(prefixes)
+	}
+}
+
+// prefixedName returns the name with its namespace prefix as local name. Namespaces without declared prefix are
+// undeclared prefixes, which the decoder keeps as they are.
+func prefixedName(name xml.Name, prefixes map[string]string) xml.Name {
+	if name.Space == "" {
+		return name
+	}
+
+	prefix, ok := prefixes[name.Space]
+	if !ok {
+		prefix = name.Space
+	}
+	if prefix == "" {
+		return xml.Name{Local: name.Local}
+	}
+
+	return xml.Name{Local: prefix + ":" + name.Local}
+}
+
+// updateLogbackConfig sets the level of the root logger and of the given loggers in the logback configuration. Levels of
+// other loggers are kept. The format replaces the encoder of the console appenders, if it is set.
+func updateLogbackConfig(configXML, level string, loggers map[string]string, format string) (string, error) {
+	var config xmlNode
+	if err := xml.Unmarshal([]byte(configXML), &config); err != nil {
+		return "", fmt.Errorf("error parsing logback configuration: %w", err)
 	}
+	config.trimText()
+	config.restorePrefixes(map[string]string{"xmlns": "xmlns", "http://www.w3.org/XML/1998/namespace": "xml"})
 
-	logbackLevel := strings.ToUpper(logLevel)
-	updatedXML := configXML
+	roots := config.children("root")
+	if len(roots) == 0 {
+		return "", fmt.Errorf("no root logger found in logback configuration")
+	}
+	for _, root := range roots {
+		root.setAttr("level", logbackLevel(level))
+	}
 
-	rootLevelPattern := regexp.MustCompile(`(<root\s+[^>]*level=")[^"]+("(?:[^>]*>))`)
-	updatedXML = rootLevelPattern.ReplaceAllString(updatedXML, "${1}"+logbackLevel+"${2}")
+	names := make([]string, 0, len(loggers))
+	for name := range loggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-	loggerLevelPattern := regexp.MustCompile(`(<logger\s+[^>]*level=")[^"]+("(?:[^>]*>))`)
-	updatedXML = loggerLevelPattern.ReplaceAllString(updatedXML, "${1}"+logbackLevel+"${2}")
+	for _, name := range names {
+		logger := findLogger(&config, name)
+		if logger == nil {
+			logger = &xmlNode{XMLName: xml.Name{Local: "logger"}}
+			logger.setAttr("name", name)
+			config.Nodes = append(config.Nodes, logger)
+		}
+		logger.setAttr("level", logbackLevel(loggers[name]))
+	}
 
-	if updatedXML == configXML {
-		return fmt.Errorf("no logger or root level elements found in XML configuration")
+	if format != "" {
+		for _, appender := range config.children("appender") {
+			if appender.attr("class") == "ch.qos.logback.core.ConsoleAppender" {
+				setLogbackEncoder(appender, format)
+			}
+		}
+	}
+
+	out, err := xml.MarshalIndent(&config, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error writing logback configuration: %w", err)
+	}
+
+	return string(out) + "\n", nil
+}
+
+func findLogger(config *xmlNode, name string) *xmlNode {
+	for _, logger := range config.children("logger") {
+		if logger.attr("name") == name {
+			return logger
+		}
 	}
 
-	data["config.xml"] = updatedXML
 	return nil
 }
 
-func updateZapConfigLevel(data map[string]string, level string) error {
+// setLogbackEncoder replaces the encoder of the appender with one writing the given format
+func setLogbackEncoder(appender *xmlNode, format string) {
+	encoder := &xmlNode{XMLName: xml.Name{Local: "encoder"}}
+	switch format {
+	case v1alpha1.LogFormatJSON:
+		encoder.setAttr("class", "net.logstash.logback.encoder.LogstashEncoder")
+	case v1alpha1.LogFormatConsole:
+		encoder.setAttr("class", "ch.qos.logback.classic.encoder.PatternLayoutEncoder")
+		encoder.Nodes = []*xmlNode{{XMLName: xml.Name{Local: "pattern"}, Text: consoleLogPattern}}
+	}
+
+	nodes := make([]*xmlNode, 0, len(appender.Nodes)+1)
+	for _, node := range appender.Nodes {
+		if node.XMLName.Local != "encoder" && node.XMLName.Local != "layout" {
+			nodes = append(nodes, node)
+		}
+	}
+	appender.Nodes = append(nodes, encoder)
+}
+
+// logbackLevel converts the log level into the level of logback, which doesn't know fatal
+func logbackLevel(logLevel string) string {
+	if strings.ToLower(logLevel) == v1alpha1.LogLevelFatal {
+		return "ERROR"
+	}
+
+	return strings.ToUpper(logLevel)
+}
+
+func updateZapConfig(data map[string]string, level, encoding string) error {
 	zapConfigJSON, exists := data["zap-logger-config"]
 	if !exists {
+		if encoding == "" {
+			encoding = v1alpha1.LogFormatJSON
+		}
+
 		// Create a default zap config if it doesn't exist
 		defaultConfig := map[string]interface{}{
 			"level":            level,
 			"development":      false,
 			"outputPaths":      []string{"stdout"},
 			"errorOutputPaths": []string{"stderr"},
-			"encoding":         "json",
+			"encoding":         encoding,
 		}
 		configBytes, err := json.MarshalIndent(defaultConfig, "", "  ")
 		if err != nil {
@@ -123,6 +396,9 @@ func updateZapConfigLevel(data map[string]string, level string) error {
 	}
 
 	config["level"] = level
+	if encoding != "" {
+		config["encoding"] = encoding
+	}
 
 	// Marshal back to JSON
 	configBytes, err := json.MarshalIndent(config, "", "  ")
@@ -0,0 +1,350 @@
+package transform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+)
+
+const kafkaLoggingConfig = `<configuration>
+  <appender name="jsonConsoleAppender" class="ch.qos.logback.core.ConsoleAppender">
+    <encoder class="net.logstash.logback.encoder.LogstashEncoder"/>
+  </appender>
+  <appender name="async" class="ch.qos.logback.classic.AsyncAppender">
+      <appender-ref ref="jsonConsoleAppender" />
+      <neverBlock>true</neverBlock>
+  </appender>
+  <logger name="io.vertx" level="WARN"/>
+  <root level="INFO">
+    <appender-ref ref="async"/>
+  </root>
+</configuration>
+`
+
+func TestEventingCoreLogging(t *testing.T) {
+	t.Setenv("SYSTEM_NAMESPACE", "knative-eventing")
+
+	configMap := func(data map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "config-logging",
+				"namespace": "knative-eventing",
+			},
+			"data": data,
+		}}
+	}
+	zapConfig := func(level, encoding string) string {
+		return "{\n  \"encoding\": \"" + encoding + "\",\n  \"level\": \"" + level + "\"\n}"
+	}
+
+	tests := []struct {
+		name     string
+		logLevel string
+		logging  *v1alpha1.EventMeshSpecLogging
+		input    *unstructured.Unstructured
+		expected *unstructured.Unstructured
+	}{
+		{
+			name:     "log level is set for all components",
+			logLevel: v1alpha1.LogLevelWarn,
+			input: configMap(map[string]interface{}{
+				"zap-logger-config":   zapConfig("info", "json"),
+				"loglevel.controller": "info",
+				"loglevel.webhook":    "info",
+			}),
+			expected: configMap(map[string]interface{}{
+				"zap-logger-config":   zapConfig("warn", "json"),
+				"loglevel.controller": "warn",
+				"loglevel.webhook":    "warn",
+			}),
+		},
+		{
+			name:     "components and format are overridden",
+			logLevel: v1alpha1.LogLevelInfo,
+			logging: &v1alpha1.EventMeshSpecLogging{
+				Format: v1alpha1.LogFormatConsole,
+				Components: map[string]v1alpha1.EventMeshSpecComponentLogging{
+					"controller":              {Level: v1alpha1.LogLevelTrace},
+					"mt_broker_filter":        {Level: v1alpha1.LogLevelError},
+					"kafka-broker-dispatcher": {Level: v1alpha1.LogLevelDebug},
+				},
+			},
+			input: configMap(map[string]interface{}{
+				"zap-logger-config":   zapConfig("info", "json"),
+				"loglevel.controller": "info",
+				"loglevel.webhook":    "info",
+			}),
+			expected: configMap(map[string]interface{}{
+				"zap-logger-config":         zapConfig("info", "console"),
+				"loglevel.controller":       "debug",
+				"loglevel.webhook":          "info",
+				"loglevel.mt_broker_filter": "error",
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := EventingCoreLogging(tt.logLevel, tt.logging)(tt.input); err != nil {
+				t.Fatalf("EventingCoreLogging() = %v", err)
+			}
+			// added when the ConfigMap gets converted
+			unstructured.RemoveNestedField(tt.input.Object, "metadata", "creationTimestamp")
+
+			if diff := cmp.Diff(tt.expected.Object, tt.input.Object); diff != "" {
+				t.Errorf("EventingCoreLogging() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestKafkaLogging(t *testing.T) {
+	t.Setenv("SYSTEM_NAMESPACE", "knative-eventing")
+
+	configMap := func(data map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "kafka-config-logging",
+				"namespace": "knative-eventing",
+			},
+			"data": data,
+		}}
+	}
+	dispatcher := func(items ...interface{}) *unstructured.Unstructured {
+		volume := map[string]interface{}{"name": "kafka-config-logging"}
+		if len(items) > 0 {
+			volume["items"] = items
+		}
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "StatefulSet",
+			"metadata": map[string]interface{}{
+				"name":      "kafka-broker-dispatcher",
+				"namespace": "knative-eventing",
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"volumes": []interface{}{
+							map[string]interface{}{"name": "cache", "emptyDir": map[string]interface{}{}},
+							map[string]interface{}{"name": "kafka-broker-config-logging", "configMap": volume},
+						},
+					},
+				},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		logLevel string
+		logging  *v1alpha1.EventMeshSpecLogging
+		input    *unstructured.Unstructured
+		expected *unstructured.Unstructured
+	}{
+		{
+			name:     "root level is set and other loggers are kept",
+			logLevel: v1alpha1.LogLevelTrace,
+			input:    configMap(map[string]interface{}{"config.xml": kafkaLoggingConfig}),
+			expected: configMap(map[string]interface{}{"config.xml": `<configuration>
+  <appender name="jsonConsoleAppender" class="ch.qos.logback.core.ConsoleAppender">
+    <encoder class="net.logstash.logback.encoder.LogstashEncoder"></encoder>
+  </appender>
+  <appender name="async" class="ch.qos.logback.classic.AsyncAppender">
+    <appender-ref ref="jsonConsoleAppender"></appender-ref>
+    <neverBlock>true</neverBlock>
+  </appender>
+  <logger name="io.vertx" level="WARN"></logger>
+  <root level="TRACE">
+    <appender-ref ref="async"></appender-ref>
+  </root>
+</configuration>
+`}),
+		},
+		{
+			name:     "component loggers and format are written into a dedicated key",
+			logLevel: v1alpha1.LogLevelInfo,
+			logging: &v1alpha1.EventMeshSpecLogging{
+				Format: v1alpha1.LogFormatConsole,
+				Components: map[string]v1alpha1.EventMeshSpecComponentLogging{
+					"controller": {Level: v1alpha1.LogLevelDebug},
+					"kafka-broker-dispatcher": {
+						Level: v1alpha1.LogLevelFatal,
+						Loggers: map[string]string{
+							"io.vertx":         v1alpha1.LogLevelInfo,
+							"org.apache.kafka": v1alpha1.LogLevelDebug,
+						},
+					},
+				},
+			},
+			input: configMap(map[string]interface{}{"config.xml": kafkaLoggingConfig}),
+			expected: configMap(map[string]interface{}{
+				"config.xml": `<configuration>
+  <appender name="jsonConsoleAppender" class="ch.qos.logback.core.ConsoleAppender">
+    <encoder class="ch.qos.logback.classic.encoder.PatternLayoutEncoder">
+      <pattern>%d{ISO8601} %-5level %logger - %msg%n</pattern>
+    </encoder>
+  </appender>
+  <appender name="async" class="ch.qos.logback.classic.AsyncAppender">
+    <appender-ref ref="jsonConsoleAppender"></appender-ref>
+    <neverBlock>true</neverBlock>
+  </appender>
+  <logger name="io.vertx" level="WARN"></logger>
+  <root level="INFO">
+    <appender-ref ref="async"></appender-ref>
+  </root>
+</configuration>
+`,
+				"kafka-broker-dispatcher.xml": `<configuration>
+  <appender name="jsonConsoleAppender" class="ch.qos.logback.core.ConsoleAppender">
+    <encoder class="ch.qos.logback.classic.encoder.PatternLayoutEncoder">
+      <pattern>%d{ISO8601} %-5level %logger - %msg%n</pattern>
+    </encoder>
+  </appender>
+  <appender name="async" class="ch.qos.logback.classic.AsyncAppender">
+    <appender-ref ref="jsonConsoleAppender"></appender-ref>
+    <neverBlock>true</neverBlock>
+  </appender>
+  <logger name="io.vertx" level="INFO"></logger>
+  <root level="ERROR">
+    <appender-ref ref="async"></appender-ref>
+  </root>
+  <logger name="org.apache.kafka" level="DEBUG"></logger>
+</configuration>
+`,
+			}),
+		},
+		{
+			name:     "data plane with overrides mounts its dedicated key",
+			logLevel: v1alpha1.LogLevelInfo,
+			logging: &v1alpha1.EventMeshSpecLogging{
+				Components: map[string]v1alpha1.EventMeshSpecComponentLogging{
+					"kafka-broker-dispatcher": {Level: v1alpha1.LogLevelDebug},
+				},
+			},
+			input: dispatcher(),
+			expected: dispatcher(map[string]interface{}{
+				"key":  "kafka-broker-dispatcher.xml",
+				"path": "config.xml",
+			}),
+		},
+		{
+			name:     "data plane without overrides mounts the shared configuration",
+			logLevel: v1alpha1.LogLevelInfo,
+			logging: &v1alpha1.EventMeshSpecLogging{
+				Components: map[string]v1alpha1.EventMeshSpecComponentLogging{
+					"kafka-broker-receiver": {Level: v1alpha1.LogLevelDebug},
+				},
+			},
+			input:    dispatcher(),
+			expected: dispatcher(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := KafkaLogging(tt.logLevel, tt.logging)(tt.input); err != nil {
+				t.Fatalf("KafkaLogging() = %v", err)
+			}
+			// added when the ConfigMap gets converted
+			unstructured.RemoveNestedField(tt.input.Object, "metadata", "creationTimestamp")
+
+			if diff := cmp.Diff(tt.expected.Object, tt.input.Object); diff != "" {
+				t.Errorf("KafkaLogging() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestKafkaLoggingUnchangedLevel(t *testing.T) {
+	t.Setenv("SYSTEM_NAMESPACE", "knative-eventing")
+
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "kafka-config-logging",
+			"namespace": "knative-eventing",
+		},
+		"data": map[string]interface{}{"config.xml": `<configuration><root level="INFO"><appender-ref ref="jsonConsoleAppender"/></root></configuration>`},
+	}}
+
+	// a configuration, which has the requested level already, is valid
+	if err := KafkaLogging(v1alpha1.LogLevelInfo, nil)(u); err != nil {
+		t.Fatalf("KafkaLogging() = %v", err)
+	}
+
+	got, _, _ := unstructured.NestedString(u.Object, "data", "config.xml")
+	if !strings.Contains(got, `<root level="INFO">`) {
+		t.Errorf("KafkaLogging() changed the root level in %s, want INFO", got)
+	}
+}
+
+func TestKafkaLoggingKeepsCommentsAndNamespaces(t *testing.T) {
+	t.Setenv("SYSTEM_NAMESPACE", "knative-eventing")
+
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "kafka-config-logging",
+			"namespace": "knative-eventing",
+		},
+		"data": map[string]interface{}{"config.xml": `<configuration xmlns:xi="http://www.w3.org/2001/XInclude">
+  <!-- logs are written as JSON -->
+  <appender name="jsonConsoleAppender" class="ch.qos.logback.core.ConsoleAppender">
+    <encoder class="net.logstash.logback.encoder.LogstashEncoder"/>
+  </appender>
+  <xi:include href="extra.xml" xi:parse="xml"/>
+  <root level="INFO">
+    <appender-ref ref="jsonConsoleAppender"/>
+  </root>
+</configuration>
+`},
+	}}
+
+	if err := KafkaLogging(v1alpha1.LogLevelDebug, nil)(u); err != nil {
+		t.Fatalf("KafkaLogging() = %v", err)
+	}
+
+	want := `<configuration xmlns:xi="http://www.w3.org/2001/XInclude">
+  <!-- logs are written as JSON -->
+  <appender name="jsonConsoleAppender" class="ch.qos.logback.core.ConsoleAppender">
+    <encoder class="net.logstash.logback.encoder.LogstashEncoder"></encoder>
+  </appender>
+  <xi:include href="extra.xml" xi:parse="xml"></xi:include>
+  <root level="DEBUG">
+    <appender-ref ref="jsonConsoleAppender"></appender-ref>
+  </root>
+</configuration>
+`
+	got, _, _ := unstructured.NestedString(u.Object, "data", "config.xml")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("KafkaLogging() (-want, +got) = %v", diff)
+	}
+}
+
+func TestKafkaLoggingWithoutRoot(t *testing.T) {
+	t.Setenv("SYSTEM_NAMESPACE", "knative-eventing")
+
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "kafka-config-logging",
+			"namespace": "knative-eventing",
+		},
+		"data": map[string]interface{}{"config.xml": "<configuration></configuration>"},
+	}}
+
+	if err := KafkaLogging(v1alpha1.LogLevelInfo, nil)(u); err == nil {
+		t.Error("KafkaLogging() = nil, want error for a configuration without root logger")
+	}
+}
@@ -0,0 +1,138 @@
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"knative.dev/pkg/system"
+	"sigs.k8s.io/yaml"
+
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+)
+
+const (
+	namespacedBrokerResourcesConfigMap = "config-namespaced-broker-resources"
+	namespacedBrokerResourcesKey       = "resources"
+
+	namespacedBrokerReceiver   = "kafka-broker-receiver"
+	namespacedBrokerDispatcher = "kafka-broker-dispatcher"
+)
+
+// NamespacedBrokerResources renders the data plane configuration of KafkaNamespaced brokers into the
+// config-namespaced-broker-resources ConfigMap. The Kafka controller applies these resources in every namespace with
+// a KafkaNamespaced broker after the data plane copied from the system namespace, so only the configured fields of
+// the receiver and dispatcher are overridden. Resources already listed in the ConfigMap are kept.
+func NamespacedBrokerResources(spec *v1alpha1.NamespacedBrokerSpec) mf.Transformer {
+	if spec == nil || (spec.Receiver == nil && spec.Dispatcher == nil) {
+		return NoOp()
+	}
+
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() != "ConfigMap" {
+			return nil
+		}
+
+		if u.GetNamespace() != system.Namespace() || u.GetName() != namespacedBrokerResourcesConfigMap {
+			return nil
+		}
+
+		var cm = &corev1.ConfigMap{}
+		if err := scheme.Scheme.Convert(u, cm, nil); err != nil {
+			return fmt.Errorf("error converting unstructured to configmap: %w", err)
+		}
+
+		var workloads []map[string]interface{}
+		if spec.Receiver != nil {
+			workloads = append(workloads, dataPlaneResource("Deployment", namespacedBrokerReceiver, spec.Receiver))
+		}
+		if spec.Dispatcher != nil {
+			workloads = append(workloads, dataPlaneResource("StatefulSet", namespacedBrokerDispatcher, spec.Dispatcher))
+		}
+
+		var bundled []map[string]interface{}
+		if err := yaml.Unmarshal([]byte(cm.Data[namespacedBrokerResourcesKey]), &bundled); err != nil {
+			return fmt.Errorf("failed to unmarshal namespaced broker resources: %w", err)
+		}
+
+		resources := make([]map[string]interface{}, 0, len(bundled)+len(workloads))
+		for _, resource := range bundled {
+			if !containsResource(workloads, resource) {
+				resources = append(resources, resource)
+			}
+		}
+		resources = append(resources, workloads...)
+
+		data, err := json.MarshalIndent(resources, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal namespaced broker resources: %w", err)
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[namespacedBrokerResourcesKey] = string(data)
+
+		return scheme.Scheme.Convert(cm, u, nil)
+	}
+}
+
+// dataPlaneResource returns a partial workload, which only contains the fields set in the given spec
+func dataPlaneResource(kind, name string, spec *v1alpha1.DataPlaneSpec) map[string]interface{} {
+	podSpec := map[string]interface{}{}
+	if spec.Resources != nil {
+		podSpec["containers"] = []interface{}{
+			map[string]interface{}{
+				"name":      name,
+				"resources": spec.Resources,
+			},
+		}
+	}
+	if len(spec.NodeSelector) > 0 {
+		podSpec["nodeSelector"] = spec.NodeSelector
+	}
+	if len(spec.Tolerations) > 0 {
+		podSpec["tolerations"] = spec.Tolerations
+	}
+	if spec.Affinity != nil {
+		podSpec["affinity"] = spec.Affinity
+	}
+	if len(spec.TopologySpreadConstraints) > 0 {
+		podSpec["topologySpreadConstraints"] = spec.TopologySpreadConstraints
+	}
+
+	workloadSpec := map[string]interface{}{}
+	if spec.Replicas != nil {
+		workloadSpec["replicas"] = *spec.Replicas
+	}
+	if len(podSpec) > 0 {
+		workloadSpec["template"] = map[string]interface{}{
+			"spec": podSpec,
+		}
+	}
+
+	return map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name": name,
+		},
+		"spec": workloadSpec,
+	}
+}
+
+// containsResource checks whether a resource of the same kind and name as the given one is in the list
+func containsResource(resources []map[string]interface{}, resource map[string]interface{}) bool {
+	u := unstructured.Unstructured{Object: resource}
+	for _, r := range resources {
+		other := unstructured.Unstructured{Object: r}
+		if other.GetKind() == u.GetKind() && other.GetName() == u.GetName() {
+			return true
+		}
+	}
+
+	return false
+}
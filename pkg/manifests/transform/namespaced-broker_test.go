@@ -0,0 +1,194 @@
+package transform
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+)
+
+func TestNamespacedBrokerResources(t *testing.T) {
+	t.Setenv("SYSTEM_NAMESPACE", "knative-eventing")
+
+	tests := []struct {
+		name     string
+		spec     *v1alpha1.NamespacedBrokerSpec
+		expected string
+	}{
+		{
+			name:     "no spec keeps the bundled resources",
+			spec:     nil,
+			expected: "[\n]\n",
+		},
+		{
+			name: "receiver and dispatcher",
+			spec: &v1alpha1.NamespacedBrokerSpec{
+				Receiver: &v1alpha1.DataPlaneSpec{
+					Replicas: ptr.To[int32](2),
+					Resources: &corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					},
+				},
+				Dispatcher: &v1alpha1.DataPlaneSpec{
+					NodeSelector: map[string]string{"tenant": "a"},
+				},
+			},
+			expected: `[
+  {
+    "apiVersion": "apps/v1",
+    "kind": "Deployment",
+    "metadata": {
+      "name": "kafka-broker-receiver"
+    },
+    "spec": {
+      "replicas": 2,
+      "template": {
+        "spec": {
+          "containers": [
+            {
+              "name": "kafka-broker-receiver",
+              "resources": {
+                "limits": {
+                  "memory": "1Gi"
+                }
+              }
+            }
+          ]
+        }
+      }
+    }
+  },
+  {
+    "apiVersion": "apps/v1",
+    "kind": "StatefulSet",
+    "metadata": {
+      "name": "kafka-broker-dispatcher"
+    },
+    "spec": {
+      "template": {
+        "spec": {
+          "nodeSelector": {
+            "tenant": "a"
+          }
+        }
+      }
+    }
+  }
+]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata": map[string]interface{}{
+						"name":      "config-namespaced-broker-resources",
+						"namespace": "knative-eventing",
+					},
+					"data": map[string]interface{}{
+						"resources": "[\n]\n",
+					},
+				},
+			}
+
+			if err := NamespacedBrokerResources(test.spec)(u); err != nil {
+				t.Fatalf("NamespacedBrokerResources() = %v", err)
+			}
+
+			got, _, _ := unstructured.NestedString(u.Object, "data", "resources")
+			if diff := cmp.Diff(test.expected, got); diff != "" {
+				t.Errorf("resources (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestNamespacedBrokerResourcesBundledTemplate(t *testing.T) {
+	t.Setenv("SYSTEM_NAMESPACE", "knative-eventing")
+
+	manifest, err := mf.NewManifest("../../../cmd/operator/kodata/eventing-kafka-broker/1.19.3/eventing-kafka-controller.yaml")
+	if err != nil {
+		t.Fatalf("NewManifest() = %v", err)
+	}
+	manifest = manifest.Filter(mf.ByKind("ConfigMap"), mf.ByName("config-namespaced-broker-resources"))
+	if len(manifest.Resources()) != 1 {
+		t.Fatalf("got %d config-namespaced-broker-resources ConfigMaps, want 1", len(manifest.Resources()))
+	}
+
+	// an additional resource listed in the ConfigMap, as added by a later release or an override
+	extra := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name": "tenant-config",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		bundled  []map[string]interface{}
+		expected []map[string]interface{}
+	}{
+		{
+			name: "shipped template",
+			expected: []map[string]interface{}{
+				dataPlaneResource("StatefulSet", "kafka-broker-dispatcher", &v1alpha1.DataPlaneSpec{Replicas: ptr.To[int32](3)}),
+			},
+		},
+		{
+			name: "listed resources are kept and the workload is replaced",
+			bundled: []map[string]interface{}{
+				extra,
+				dataPlaneResource("StatefulSet", "kafka-broker-dispatcher", &v1alpha1.DataPlaneSpec{Replicas: ptr.To[int32](1)}),
+			},
+			expected: []map[string]interface{}{
+				extra,
+				dataPlaneResource("StatefulSet", "kafka-broker-dispatcher", &v1alpha1.DataPlaneSpec{Replicas: ptr.To[int32](3)}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := manifest.Resources()[0].DeepCopy()
+			if test.bundled != nil {
+				data, err := json.Marshal(test.bundled)
+				if err != nil {
+					t.Fatalf("failed to marshal resources: %v", err)
+				}
+				if err := unstructured.SetNestedField(u.Object, string(data), "data", "resources"); err != nil {
+					t.Fatalf("failed to set resources: %v", err)
+				}
+			}
+
+			transformer := NamespacedBrokerResources(&v1alpha1.NamespacedBrokerSpec{
+				Dispatcher: &v1alpha1.DataPlaneSpec{Replicas: ptr.To[int32](3)},
+			})
+			if err := transformer(u); err != nil {
+				t.Fatalf("NamespacedBrokerResources() = %v", err)
+			}
+
+			data, _, _ := unstructured.NestedString(u.Object, "data", "resources")
+			var got, want []map[string]interface{}
+			if err := json.Unmarshal([]byte(data), &got); err != nil {
+				t.Fatalf("resources are not a JSON list: %v", err)
+			}
+			// round trip the expected resources to compare the decoded JSON types
+			expected, _ := json.Marshal(test.expected)
+			_ = json.Unmarshal(expected, &want)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("resources (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package transform
+
+import (
+	"strconv"
+
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/pkg/system"
+
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+)
+
+// publishQuantilesEnv enables publishing the latency quantiles of requests in the Kafka data planes
+const publishQuantilesEnv = "METRICS_PUBLISH_QUANTILES"
+
+// Observability writes the observability configuration into the config-observability ConfigMap. The ConfigMap is
+// shipped with eventing core and mounted by the eventing-kafka-broker components as well, so it configures all
+// components at once. The request metrics are set on the Kafka data planes directly, as they are configured via
+// their environment. It must run before ConfigMapOverride and WorkloadsOverride, so overrides take precedence.
+func Observability(observability *v1alpha1.EventMeshSpecObservability) mf.Transformer {
+	if observability == nil {
+		return NoOp()
+	}
+
+	config := map[string]string{}
+	for key, value := range map[string]string{
+		"metrics-protocol":      observability.MetricsProtocol,
+		"metrics-endpoint":      observability.MetricsEndpoint,
+		"tracing-protocol":      observability.TracingProtocol,
+		"tracing-endpoint":      observability.TracingEndpoint,
+		"tracing-sampling-rate": observability.TracingSamplingRate,
+	} {
+		if value != "" {
+			config[key] = value
+		}
+	}
+
+	observabilityConfig := ConfigMapMultipleValues("config-observability", system.Namespace(), config, true)
+	requestMetrics := NoOp()
+	if observability.RequestMetrics != nil {
+		requestMetrics = containerEnvValue(publishQuantilesEnv, strconv.FormatBool(*observability.RequestMetrics))
+	}
+
+	return func(u *unstructured.Unstructured) error {
+		if err := observabilityConfig(u); err != nil {
+			return err
+		}
+		return requestMetrics(u)
+	}
+}
+
+// containerEnvValue sets the value of the environment variable in all containers of Deployments and StatefulSets,
+// which define it already
+func containerEnvValue(name, value string) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() != "Deployment" && u.GetKind() != "StatefulSet" {
+			return nil
+		}
+
+		containers, found, err := unstructured.NestedSlice(u.Object, "spec", "template", "spec", "containers")
+		if err != nil || !found {
+			return err
+		}
+
+		changed := false
+		for _, container := range containers {
+			c, ok := container.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			env, _ := c["env"].([]interface{})
+			for _, e := range env {
+				if envVar, ok := e.(map[string]interface{}); ok && envVar["name"] == name {
+					envVar["value"] = value
+					changed = true
+				}
+			}
+		}
+
+		if !changed {
+			return nil
+		}
+
+		return unstructured.SetNestedSlice(u.Object, containers, "spec", "template", "spec", "containers")
+	}
+}
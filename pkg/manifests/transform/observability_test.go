@@ -0,0 +1,107 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+)
+
+func TestObservability(t *testing.T) {
+	t.Setenv("SYSTEM_NAMESPACE", "knative-eventing")
+
+	configMap := func(data map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "config-observability",
+				"namespace": "knative-eventing",
+			},
+			"data": data,
+		}}
+	}
+	dispatcher := func(publishQuantiles string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "StatefulSet",
+			"metadata": map[string]interface{}{
+				"name":      "kafka-broker-dispatcher",
+				"namespace": "knative-eventing",
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{
+								"name": "kafka-broker-dispatcher",
+								"env": []interface{}{
+									map[string]interface{}{"name": "METRICS_PUBLISH_QUANTILES", "value": publishQuantiles},
+									map[string]interface{}{"name": "METRICS_JVM_ENABLED", "value": "false"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name          string
+		observability *v1alpha1.EventMeshSpecObservability
+		input         *unstructured.Unstructured
+		expected      *unstructured.Unstructured
+	}{
+		{
+			name:     "not configured",
+			input:    configMap(map[string]interface{}{"_example": "example"}),
+			expected: configMap(map[string]interface{}{"_example": "example"}),
+		},
+		{
+			name: "configured settings are written into config-observability",
+			observability: &v1alpha1.EventMeshSpecObservability{
+				MetricsProtocol:     v1alpha1.ObservabilityProtocolPrometheus,
+				TracingProtocol:     v1alpha1.ObservabilityProtocolHTTPProtobuf,
+				TracingEndpoint:     "http://otel-collector.observability:4318/v1/traces",
+				TracingSamplingRate: "0.1",
+			},
+			input: configMap(map[string]interface{}{"_example": "example"}),
+			expected: configMap(map[string]interface{}{
+				"_example":              "example",
+				"metrics-protocol":      "prometheus",
+				"tracing-protocol":      "http/protobuf",
+				"tracing-endpoint":      "http://otel-collector.observability:4318/v1/traces",
+				"tracing-sampling-rate": "0.1",
+			}),
+		},
+		{
+			name:          "request metrics are enabled in the Kafka data planes",
+			observability: &v1alpha1.EventMeshSpecObservability{RequestMetrics: ptr.To(true)},
+			input:         dispatcher("false"),
+			expected:      dispatcher("true"),
+		},
+		{
+			name:          "request metrics are left to their default, when not set",
+			observability: &v1alpha1.EventMeshSpecObservability{MetricsProtocol: v1alpha1.ObservabilityProtocolPrometheus},
+			input:         dispatcher("false"),
+			expected:      dispatcher("false"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Observability(tt.observability)(tt.input); err != nil {
+				t.Fatalf("Observability() = %v", err)
+			}
+			// added when the ConfigMap gets converted
+			unstructured.RemoveNestedField(tt.input.Object, "metadata", "creationTimestamp")
+
+			if diff := cmp.Diff(tt.expected.Object, tt.input.Object); diff != "" {
+				t.Errorf("Observability() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const versionLabel = "app.kubernetes.io/version"
+
+// PostInstallJobName gives post-install jobs a name per version, so that they are created only once per upgrade and
+// don't clash with the (immutable) jobs of previous upgrades. Jobs with a generated name get the version as suffix of
+// their generateName. The TTL of the jobs is removed, as the operator tracks them to completion and cleans them up on
+// its own.
+func PostInstallJobName() mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() != "Job" {
+			return nil
+		}
+
+		version := u.GetLabels()[versionLabel]
+		if version == "" {
+			return fmt.Errorf("post-install job %s%s has no %s label", u.GetName(), u.GetGenerateName(), versionLabel)
+		}
+		suffix := strings.ReplaceAll(version, ".", "-")
+
+		if u.GetName() == "" {
+			u.SetName(u.GetGenerateName() + suffix)
+			u.SetGenerateName("")
+		} else {
+			u.SetName(u.GetName() + "-" + suffix)
+		}
+
+		unstructured.RemoveNestedField(u.Object, "spec", "ttlSecondsAfterFinished")
+
+		return nil
+	}
+}
@@ -0,0 +1,93 @@
+package manifests
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coreos/go-semver/semver"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+)
+
+// ErrUnsupportedUpgrade is returned, when the installed version can't be upgraded or downgraded to the requested
+// release
+var ErrUnsupportedUpgrade = errors.New("unsupported upgrade")
+
+// PlanRelease returns the release to install for the EventMesh. Knative only supports upgrades by one minor version at
+// a time, so if the requested release is further ahead of the installed version, the bundled intermediate releases are
+// installed one after another. The next step is only taken once the deployments of the installed release are
+// available and its post-install jobs completed. Downgrades and minor version skips without intermediate releases
+// return ErrUnsupportedUpgrade, unless the EventMesh is annotated with AllowUnsupportedUpgradeAnnotation. Rollbacks
+// via RollbackToRevisionAnnotation may downgrade minor versions, as they return to a previously installed revision.
+func PlanRelease(em *v1alpha1.EventMesh) (*Release, error) {
+	target, err := ResolveRelease(em.Spec.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	installedVersion := em.Status.Version
+	if installedVersion == "" {
+		// installations of older operators only recorded the component versions
+		installedVersion = em.Status.EventingVersion
+	}
+	if installedVersion == "" || em.Annotations[v1alpha1.AllowUnsupportedUpgradeAnnotation] == "true" {
+		return target, nil
+	}
+
+	installed, err := semver.NewVersion(installedVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse installed version %q: %w", installedVersion, err)
+	}
+
+	releases, err := AvailableReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, rollback := em.Annotations[v1alpha1.RollbackToRevisionAnnotation]; rollback &&
+		target.Eventing.Major == installed.Major && target.Eventing.Minor < installed.Minor {
+		return target, nil
+	}
+
+	installedAvailable := em.Status.GetCondition(v1alpha1.EventMeshConditionDeploymentsAvailable).IsTrue() && !em.Status.IsPostInstallPending()
+
+	return planUpgrade(installed, target, releases, installedAvailable)
+}
+
+// planUpgrade returns the next release on the way from the installed version to the target release
+func planUpgrade(installed *semver.Version, target *Release, releases []Release, installedAvailable bool) (*Release, error) {
+	switch {
+	case target.Eventing.Major != installed.Major:
+		return nil, fmt.Errorf("%w from %s to %s: major version changes are not supported", ErrUnsupportedUpgrade, installed, target.Eventing)
+	case target.Eventing.Minor < installed.Minor:
+		return nil, fmt.Errorf("%w from %s to %s: downgrades are not supported", ErrUnsupportedUpgrade, installed, target.Eventing)
+	case target.Eventing.Minor <= installed.Minor+1:
+		return target, nil
+	}
+
+	// the target is more than one minor version ahead. Stay on the installed minor version until it is available
+	// before stepping to the next one
+	if !installedAvailable {
+		if current := latestPatchRelease(releases, installed.Major, installed.Minor); current != nil {
+			return current, nil
+		}
+	}
+
+	if next := latestPatchRelease(releases, installed.Major, installed.Minor+1); next != nil {
+		return next, nil
+	}
+
+	return nil, fmt.Errorf("%w from %s to %s: minor versions can't be skipped and release %d.%d is not bundled", ErrUnsupportedUpgrade, installed, target.Eventing, installed.Major, installed.Minor+1)
+}
+
+// latestPatchRelease returns the release with the highest patch version of the given minor version or nil if there is
+// none. releases must be sorted ascending.
+func latestPatchRelease(releases []Release, major, minor int64) *Release {
+	var latest *Release
+	for i := range releases {
+		if releases[i].Eventing.Major == major && releases[i].Eventing.Minor == minor {
+			latest = &releases[i]
+		}
+	}
+
+	return latest
+}
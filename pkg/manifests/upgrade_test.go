@@ -0,0 +1,131 @@
+package manifests
+
+import (
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+)
+
+func TestPlanRelease(t *testing.T) {
+	setupKoData(t, map[string][]string{
+		eventingBundle:            {"1.16.0", "1.18.0", "1.18.1", "1.19.2", "1.20.0"},
+		eventingKafkaBrokerBundle: {"1.16.1", "1.18.3", "1.19.3", "1.20.1"},
+	})
+
+	tests := []struct {
+		name        string
+		version     string
+		installed   string
+		available   bool
+		annotations map[string]string
+		want        string
+		wantErr     error
+	}{
+		{
+			name:    "fresh installation",
+			version: "1.20",
+			want:    "1.20.0",
+		},
+		{
+			name:      "patch upgrade",
+			version:   "1.18",
+			installed: "1.18.0",
+			want:      "1.18.1",
+		},
+		{
+			name:      "minor upgrade",
+			version:   "1.20",
+			installed: "1.19.2",
+			want:      "1.20.0",
+		},
+		{
+			name:      "patch downgrade",
+			version:   "1.18.0",
+			installed: "1.18.1",
+			want:      "1.18.0",
+		},
+		{
+			name:      "step through intermediate release",
+			version:   "1.20",
+			installed: "1.18.1",
+			available: true,
+			want:      "1.19.2",
+		},
+		{
+			name:      "wait for installed release before next step",
+			version:   "1.20",
+			installed: "1.18.0",
+			available: false,
+			want:      "1.18.1",
+		},
+		{
+			name:      "skip without intermediate release",
+			version:   "1.18",
+			installed: "1.16.0",
+			available: true,
+			wantErr:   ErrUnsupportedUpgrade,
+		},
+		{
+			name:      "downgrade",
+			version:   "1.18",
+			installed: "1.19.2",
+			wantErr:   ErrUnsupportedUpgrade,
+		},
+		{
+			name:        "downgrade on rollback",
+			version:     "1.18",
+			installed:   "1.19.2",
+			annotations: map[string]string{v1alpha1.RollbackToRevisionAnnotation: "1"},
+			want:        "1.18.1",
+		},
+		{
+			name:        "major downgrade on rollback",
+			version:     "1.16",
+			installed:   "2.0.0",
+			annotations: map[string]string{v1alpha1.RollbackToRevisionAnnotation: "1"},
+			wantErr:     ErrUnsupportedUpgrade,
+		},
+		{
+			name:        "downgrade with override",
+			version:     "1.18",
+			installed:   "1.19.2",
+			annotations: map[string]string{v1alpha1.AllowUnsupportedUpgradeAnnotation: "true"},
+			want:        "1.18.1",
+		},
+		{
+			name:        "skip with override",
+			version:     "1.18",
+			installed:   "1.16.0",
+			annotations: map[string]string{v1alpha1.AllowUnsupportedUpgradeAnnotation: "true"},
+			want:        "1.18.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			em := &v1alpha1.EventMesh{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Spec:       v1alpha1.EventMeshSpec{Version: tt.version},
+				Status:     v1alpha1.EventMeshStatus{Version: tt.installed},
+			}
+			if tt.available {
+				em.Status.MarkDeploymentsAvailable()
+			}
+
+			release, err := PlanRelease(em)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PlanRelease() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if release.Version() != tt.want {
+				t.Errorf("PlanRelease() = %s, want %s", release.Version(), tt.want)
+			}
+		})
+	}
+}
@@ -5,8 +5,10 @@ import (
 
 	"github.com/coreos/go-semver/semver"
 	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"knative.dev/pkg/reconciler"
 	"knative.dev/pkg/system"
 )
 
@@ -64,7 +66,33 @@ func getVersions(manifests mf.Manifest, deploymentLister appsv1listers.Deploymen
 	return manifestVersion, instanceVersion, nil
 }
 
-func isUpgrade(manifests mf.Manifest, deploymentLister appsv1listers.DeploymentLister) (bool, error) {
+// isUpgrade returns true if the manifests are at least one minor version ahead of the installed version. The installed
+// version is the version recorded in the EventMesh status. If no version is recorded (e.g. on installations of an
+// older operator), it falls back to the versions of the deployments.
+func isUpgrade(manifests mf.Manifest, installedVersion string, deploymentLister appsv1listers.DeploymentLister) (bool, error) {
+	if installedVersion == "" {
+		return isUpgradeFromDeployments(manifests, deploymentLister)
+	}
+
+	instanceVersion, err := semver.NewVersion(installedVersion)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse installed version %q: %w", installedVersion, err)
+	}
+
+	manifestDeployments := manifests.Filter(mf.ByKind("Deployment")).Resources()
+	if len(manifestDeployments) == 0 {
+		return false, fmt.Errorf("could not find deployments in manifests")
+	}
+
+	manifestVersion, err := parseVersionFromLabels(manifestDeployments[len(manifestDeployments)-1].GetLabels())
+	if err != nil {
+		return false, fmt.Errorf("failed to parse version from manifests: %w", err)
+	}
+
+	return isMinorUpgrade(instanceVersion, manifestVersion), nil
+}
+
+func isUpgradeFromDeployments(manifests mf.Manifest, deploymentLister appsv1listers.DeploymentLister) (bool, error) {
 	manifestVersion, instanceVersion, err := getVersions(manifests, deploymentLister)
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -75,8 +103,23 @@ func isUpgrade(manifests mf.Manifest, deploymentLister appsv1listers.DeploymentL
 		return false, fmt.Errorf("failed to get versions: %w", err)
 	}
 
-	manifestVersion.Patch = 0
-	instanceVersion.Patch = 0
+	return isMinorUpgrade(instanceVersion, manifestVersion), nil
+}
+
+// upgradeDetectedEvent returns the event reporting the upgrade of the component from the installed to the target
+// version. The installed version is unknown for installations of older operators.
+func upgradeDetectedEvent(component string, installedVersion string, target *semver.Version) reconciler.Event {
+	if installedVersion == "" {
+		return reconciler.NewEvent(corev1.EventTypeNormal, "UpgradeDetected", "Upgrading %s to %s", component, target)
+	}
+	return reconciler.NewEvent(corev1.EventTypeNormal, "UpgradeDetected", "Upgrading %s from %s to %s", component, installedVersion, target)
+}
+
+// isMinorUpgrade returns true if the target version is at least one minor version ahead of the current version
+func isMinorUpgrade(current, target *semver.Version) bool {
+	c, t := *current, *target
+	c.Patch = 0
+	t.Patch = 0
 
-	return instanceVersion.LessThan(*manifestVersion), nil
+	return c.LessThan(t)
 }
@@ -293,3 +293,79 @@ func TestParseVersionFromLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestIsUpgrade(t *testing.T) {
+	tests := []struct {
+		name             string
+		manifests        mf.Manifest
+		installedVersion string
+		deployments      []*appsv1.Deployment
+		want             bool
+		wantErr          bool
+	}{
+		{
+			name: "recorded version with minor upgrade",
+			manifests: createManifest([]unstructured.Unstructured{
+				createDeployment("test-deployment", "1.19.0"),
+			}),
+			installedVersion: "1.18.3",
+			want:             true,
+		},
+		{
+			name: "recorded version with patch upgrade",
+			manifests: createManifest([]unstructured.Unstructured{
+				createDeployment("test-deployment", "1.19.2"),
+			}),
+			installedVersion: "1.19.1",
+			want:             false,
+		},
+		{
+			name: "recorded version is preferred over deployment version",
+			manifests: createManifest([]unstructured.Unstructured{
+				createDeployment("test-deployment", "1.19.0"),
+			}),
+			installedVersion: "1.19.0",
+			deployments: []*appsv1.Deployment{
+				createTypedDeployment("test-deployment", "1.18.0"),
+			},
+			want: false,
+		},
+		{
+			name: "no recorded version falls back to deployment version",
+			manifests: createManifest([]unstructured.Unstructured{
+				createDeployment("test-deployment", "1.19.0"),
+			}),
+			deployments: []*appsv1.Deployment{
+				createTypedDeployment("test-deployment", "1.18.0"),
+			},
+			want: true,
+		},
+		{
+			name: "no recorded version and no deployment installed",
+			manifests: createManifest([]unstructured.Unstructured{
+				createDeployment("test-deployment", "1.19.0"),
+			}),
+			want: false,
+		},
+		{
+			name: "invalid recorded version",
+			manifests: createManifest([]unstructured.Unstructured{
+				createDeployment("test-deployment", "1.19.0"),
+			}),
+			installedVersion: "invalid",
+			wantErr:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := isUpgrade(tt.manifests, tt.installedVersion, createDeploymentLister(tt.deployments))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("isUpgrade() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("isUpgrade() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
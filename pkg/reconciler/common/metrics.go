@@ -0,0 +1,81 @@
+package common
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"knative.dev/pkg/observability/attributekey"
+)
+
+const scopeName = "knative.dev/eventmesh-operator/pkg/reconciler/common"
+
+// StageAttr is the name of the stage the metrics are recorded for
+var StageAttr = attributekey.String("kn.eventmesh.stage")
+
+type metrics struct {
+	stageDuration metric.Float64Histogram
+	stageErrors   metric.Int64Counter
+}
+
+var stageMetrics = newMetrics(otel.GetMeterProvider())
+
+func newMetrics(provider metric.MeterProvider) *metrics {
+	var (
+		m   metrics
+		err error
+	)
+
+	meter := provider.Meter(scopeName)
+
+	m.stageDuration, err = meter.Float64Histogram(
+		"kn.eventmesh.stage.duration",
+		metric.WithDescription("The duration of a reconcile stage."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	m.stageErrors, err = meter.Int64Counter(
+		"kn.eventmesh.stage.errors",
+		metric.WithDescription("The number of reconcile stages, which failed with an error."),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return &m
+}
+
+func (m *metrics) recordStage(ctx context.Context, stage string, d time.Duration, err error) {
+	attrs := metric.WithAttributes(StageAttr.With(stage))
+
+	m.stageDuration.Record(ctx, d.Seconds(), attrs)
+	if err != nil && !IsDeploymentsNotReadyError(err) {
+		m.stageErrors.Add(ctx, 1, attrs)
+	}
+}
+
+// closureSuffix matches the suffix of the names of anonymous functions and method values
+var closureSuffix = regexp.MustCompile(`(\.func\d+)+(\.\d+)*$|-fm$`)
+
+// stageName returns the name of the function implementing the stage without its import path, e.g.
+// "manifests.Transform". Stages returned by the same function share its name.
+func stageName(stage Stage) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(stage).Pointer())
+	if fn == nil {
+		return "unknown"
+	}
+
+	name := fn.Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	return closureSuffix.ReplaceAllString(name, "")
+}
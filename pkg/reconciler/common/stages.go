@@ -2,6 +2,7 @@ package common
 
 import (
 	"context"
+	"time"
 
 	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
 	"knative.dev/eventmesh-operator/pkg/manifests"
@@ -17,7 +18,11 @@ type Stages []Stage
 func (stages Stages) Execute(ctx context.Context, em *v1alpha1.EventMesh) error {
 	m := &manifests.Manifests{}
 	for _, stage := range stages {
-		if err := stage(ctx, m, em); err != nil {
+		start := time.Now()
+		err := stage(ctx, m, em)
+		stageMetrics.recordStage(ctx, stageName(stage), time.Since(start), err)
+
+		if err != nil {
 			if IsDeploymentsNotReadyError(err) {
 				return nil
 			}
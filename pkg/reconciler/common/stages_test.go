@@ -0,0 +1,124 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/eventmesh-operator/pkg/manifests"
+)
+
+func TestStageName(t *testing.T) {
+	tests := []struct {
+		name  string
+		stage Stage
+		want  string
+	}{
+		{
+			name:  "function",
+			stage: noopStage,
+			want:  "common.noopStage",
+		},
+		{
+			name:  "closure",
+			stage: failingStage(errors.New("failed")),
+			want:  "common.failingStage",
+		},
+		{
+			name:  "method value",
+			stage: (&stageOwner{}).stage,
+			want:  "common.(*stageOwner).stage",
+		},
+		{
+			name:  "stage of another package",
+			stage: manifests.Transform,
+			want:  "manifests.Transform",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stageName(tt.stage); got != tt.want {
+				t.Errorf("stageName() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteRecordsStageMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	defer func(m *metrics) { stageMetrics = m }(stageMetrics)
+	stageMetrics = newMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	stages := Stages{
+		noopStage,
+		failingStage(DeploymentsNotReadyError{}),
+	}
+	if err := stages.Execute(context.Background(), &v1alpha1.EventMesh{}); err != nil {
+		t.Fatalf("Execute() = %v", err)
+	}
+
+	stages = Stages{
+		noopStage,
+		failingStage(errors.New("failed")),
+		noopStage,
+	}
+	if err := stages.Execute(context.Background(), &v1alpha1.EventMesh{}); err == nil {
+		t.Fatal("Execute() = nil, want error")
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() = %v", err)
+	}
+
+	durations := map[string]uint64{}
+	errorCounts := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					stage, _ := dp.Attributes.Value("kn.eventmesh.stage")
+					durations[stage.AsString()] += dp.Count
+				}
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					stage, _ := dp.Attributes.Value("kn.eventmesh.stage")
+					errorCounts[stage.AsString()] += dp.Value
+				}
+			}
+		}
+	}
+
+	wantDurations := map[string]uint64{"common.noopStage": 2, "common.failingStage": 2}
+	if diff := cmp.Diff(wantDurations, durations); diff != "" {
+		t.Errorf("stage durations (-want, +got) = %v", diff)
+	}
+
+	// deployments, which are not ready yet, are not counted as errors
+	wantErrors := map[string]int64{"common.failingStage": 1}
+	if diff := cmp.Diff(wantErrors, errorCounts); diff != "" {
+		t.Errorf("stage errors (-want, +got) = %v", diff)
+	}
+}
+
+func noopStage(context.Context, *manifests.Manifests, *v1alpha1.EventMesh) error {
+	return nil
+}
+
+func failingStage(err error) Stage {
+	return func(context.Context, *manifests.Manifests, *v1alpha1.EventMesh) error {
+		return err
+	}
+}
+
+type stageOwner struct{}
+
+func (*stageOwner) stage(context.Context, *manifests.Manifests, *v1alpha1.EventMesh) error {
+	return nil
+}
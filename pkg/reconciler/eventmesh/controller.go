@@ -3,20 +3,31 @@ package eventmesh
 import (
 	"context"
 
-	"github.com/go-logr/zapr"
-	mfc "github.com/manifestival/client-go-client"
-	mf "github.com/manifestival/manifestival"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
 	eventmeshinformer "knative.dev/eventmesh-operator/pkg/client/injection/informers/operator/v1alpha1/eventmesh"
 	eventmeshreconciler "knative.dev/eventmesh-operator/pkg/client/injection/reconciler/operator/v1alpha1/eventmesh"
+	operatorv1alpha1listers "knative.dev/eventmesh-operator/pkg/client/listers/operator/v1alpha1"
+	statefulsetinformer "knative.dev/eventmesh-operator/pkg/injection/namespacedkube/informers/apps/v1/statefulset"
+	hpainformer "knative.dev/eventmesh-operator/pkg/injection/namespacedkube/informers/autoscaling/v2/horizontalpodautoscaler"
+	configmapinformer "knative.dev/eventmesh-operator/pkg/injection/namespacedkube/informers/core/v1/configmap"
 	"knative.dev/eventmesh-operator/pkg/manifests"
 	"knative.dev/eventmesh-operator/pkg/scaler"
 	crdinformer "knative.dev/pkg/client/injection/apiextensions/informers/apiextensions/v1/customresourcedefinition"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	mutatingwebhookinformer "knative.dev/pkg/client/injection/kube/informers/admissionregistration/v1/mutatingwebhookconfiguration"
+	validatingwebhookinformer "knative.dev/pkg/client/injection/kube/informers/admissionregistration/v1/validatingwebhookconfiguration"
 	deploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
+	jobinformer "knative.dev/pkg/client/injection/kube/informers/batch/v1/job"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection"
+	secretinformer "knative.dev/pkg/injection/clients/namespacedkube/informers/core/v1/secret"
+	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/system"
 )
 
 // NewController initializes the controller and is called by the generated code
@@ -29,36 +40,109 @@ func NewController(
 
 	eventMeshInformer := eventmeshinformer.Get(ctx)
 	deploymentInformer := deploymentinformer.Get(ctx)
+	statefulSetInformer := statefulsetinformer.Get(ctx)
 	crdInformer := crdinformer.Get(ctx)
+	secretInformer := secretinformer.Get(ctx)
+	jobInformer := jobinformer.Get(ctx)
+	configMapInformer := configmapinformer.Get(ctx)
 	scaler := scaler.New(ctx)
 
-	mfclient, err := mfc.NewClient(injection.GetConfig(ctx))
+	// the releases are bundled with the operator, so they are read once at startup
+	if _, err := manifests.AvailableReleases(); err != nil {
+		logger.Fatalw("Error reading the bundled releases", zap.Error(err))
+	}
+
+	applier, err := manifests.NewApplier(injection.GetConfig(ctx))
 	if err != nil {
-		logger.Fatalw("Error creating client from injected config", zap.Error(err))
+		logger.Fatalw("Error creating applier from injected config", zap.Error(err))
 	}
-	mflogger := zapr.NewLogger(logger.Named("manifestival").Desugar())
-	manifest, _ := mf.ManifestFrom(mf.Slice{}, mf.UseClient(mfclient), mf.UseLogger(mflogger))
 
 	eventingParser := manifests.NewEventingParser(crdInformer.Lister(), deploymentInformer.Lister())
 	kafkaBrokerParser := manifests.NewKafkaBrokerParser(crdInformer.Lister(), deploymentInformer.Lister())
 
 	r := &Reconciler{
-		eventMeshLister:   eventMeshInformer.Lister(),
-		deploymentLister:  deploymentInformer.Lister(),
-		crdLister:         crdInformer.Lister(),
-		manifest:          manifest,
-		scaler:            scaler,
-		eventingParser:    eventingParser,
-		kafkaBrokerParser: kafkaBrokerParser,
+		eventMeshLister:    eventMeshInformer.Lister(),
+		deploymentLister:   deploymentInformer.Lister(),
+		statefulSetLister:  statefulSetInformer.Lister(),
+		secretLister:       secretInformer.Lister(),
+		crdLister:          crdInformer.Lister(),
+		applier:            applier,
+		scaler:             scaler,
+		eventingParser:     eventingParser,
+		kafkaBrokerParser:  kafkaBrokerParser,
+		driftTracker:       newDriftTracker(),
+		jobLister:          jobInformer.Lister(),
+		jobClient:          kubeclient.Get(ctx).BatchV1(),
+		configMapLister:    configMapInformer.Lister(),
+		configMapClient:    kubeclient.Get(ctx).CoreV1(),
+		postInstallRetries: newPostInstallRetries(),
 	}
 
 	impl := eventmeshreconciler.NewImpl(ctx, r)
+	r.enqueueAfter = impl.EnqueueAfter
 	globalResync := func(interface{}) {
 		impl.GlobalResync(eventMeshInformer.Informer())
 	}
 
 	eventMeshInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+	// a duplicate EventMesh takes over, when the primary one gets deleted
+	eventMeshInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: globalResync,
+	})
 	crdInformer.Informer().AddEventHandler(scaler.CRDEventHandler(ctx, globalResync))
+	secretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: isReferencedAuthSecret(eventMeshInformer.Lister()),
+		Handler:    controller.HandleAll(globalResync),
+	})
+
+	// track the post-install jobs to completion
+	jobInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("EventMesh")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// re-reconcile the owning EventMesh, when managed resources are changed by hand
+	driftInformers := map[string]cache.SharedIndexInformer{
+		"Deployment":                     deploymentInformer.Informer(),
+		"StatefulSet":                    statefulSetInformer.Informer(),
+		"ConfigMap":                      configMapInformer.Informer(),
+		"HorizontalPodAutoscaler":        hpainformer.Get(ctx).Informer(),
+		"MutatingWebhookConfiguration":   mutatingwebhookinformer.Get(ctx).Informer(),
+		"ValidatingWebhookConfiguration": validatingwebhookinformer.Get(ctx).Informer(),
+	}
+	for kind, informer := range driftInformers {
+		informer.AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("EventMesh")),
+			Handler:    driftEventHandler(ctx, kind, r.driftTracker, impl),
+		})
+	}
 
 	return impl
 }
+
+// isReferencedAuthSecret returns a filter, which only lets secrets pass, which are referenced as Kafka auth secret
+// from an EventMesh
+func isReferencedAuthSecret(eventMeshLister operatorv1alpha1listers.EventMeshLister) func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		secret, err := kmeta.DeletionHandlingAccessor(obj)
+		if err != nil || secret.GetNamespace() != system.Namespace() {
+			return false
+		}
+
+		eventMeshes, err := eventMeshLister.List(labels.Everything())
+		if err != nil {
+			return false
+		}
+
+		for _, em := range eventMeshes {
+			if isAuthSecretOf(em, secret.GetName()) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func isAuthSecretOf(em *v1alpha1.EventMesh, secretName string) bool {
+	return em.Spec.Kafka.AuthSecretRef != nil && em.Spec.Kafka.AuthSecretRef.Name == secretName
+}
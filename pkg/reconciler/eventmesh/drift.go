@@ -0,0 +1,259 @@
+package eventmesh
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	mf "github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/eventmesh-operator/pkg/manifests"
+	"knative.dev/eventmesh-operator/pkg/utils"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+)
+
+// lastAppliedConfigAnnotation is the annotation in which the operator stores the last applied configuration
+const lastAppliedConfigAnnotation = corev1.LastAppliedConfigAnnotation
+
+// driftTracker remembers the managed resources, which were changed by hand, per EventMesh until the drift got
+// corrected by a reconcile
+type driftTracker struct {
+	mu      sync.Mutex
+	drifted map[string]map[v1alpha1.DriftedResource]struct{}
+}
+
+func newDriftTracker() *driftTracker {
+	return &driftTracker{
+		drifted: map[string]map[v1alpha1.DriftedResource]struct{}{},
+	}
+}
+
+// add records the given resource as drifted for the EventMesh with the given name
+func (dt *driftTracker) add(eventMesh string, resource v1alpha1.DriftedResource) {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	if dt.drifted[eventMesh] == nil {
+		dt.drifted[eventMesh] = map[v1alpha1.DriftedResource]struct{}{}
+	}
+	dt.drifted[eventMesh][resource] = struct{}{}
+}
+
+// pop returns the drifted resources of the EventMesh with the given name sorted by kind, namespace and name and
+// forgets about them
+func (dt *driftTracker) pop(eventMesh string) []v1alpha1.DriftedResource {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	resources := make([]v1alpha1.DriftedResource, 0, len(dt.drifted[eventMesh]))
+	for resource := range dt.drifted[eventMesh] {
+		resources = append(resources, resource)
+	}
+	delete(dt.drifted, eventMesh)
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].String() < resources[j].String()
+	})
+
+	return resources
+}
+
+// driftEventHandler returns an event handler, which records changes of managed resources, which were not done by the
+// operator, and enqueues the owning EventMesh. Updates are considered as drift, when the resource does not match its
+// last applied configuration anymore. Deletions are always considered as drift.
+func driftEventHandler(ctx context.Context, kind string, tracker *driftTracker, impl *controller.Impl) cache.ResourceEventHandler {
+	logger := logging.FromContext(ctx).With("kind", kind)
+
+	record := func(obj interface{}) {
+		object, err := kmeta.DeletionHandlingAccessor(obj)
+		if err != nil {
+			return
+		}
+
+		owner := metav1.GetControllerOf(object)
+		if owner == nil {
+			return
+		}
+
+		logger.Infof("Detected drift of %s/%s", object.GetNamespace(), object.GetName())
+		tracker.add(owner.Name, v1alpha1.DriftedResource{
+			Kind:      kind,
+			Namespace: object.GetNamespace(),
+			Name:      object.GetName(),
+		})
+		impl.EnqueueControllerOf(object)
+	}
+
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, newObj interface{}) {
+			drifted, err := isDrifted(newObj)
+			if err != nil {
+				logger.Warnw("Failed to check resource for drift", "error", err)
+				return
+			}
+			if drifted {
+				record(newObj)
+			}
+		},
+		DeleteFunc: record,
+	}
+}
+
+// isDrifted returns true, if the given object does not contain all fields of its last applied configuration anymore.
+// Fields, which were added by other controllers (e.g. status, defaults or the caBundle of webhooks), are not
+// considered as drift.
+func isDrifted(obj interface{}) (bool, error) {
+	runtimeObj, ok := obj.(runtime.Object)
+	if !ok {
+		return false, nil
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(runtimeObj)
+	if err != nil {
+		return false, err
+	}
+	u := &unstructured.Unstructured{Object: content}
+
+	lastApplied, ok := u.GetAnnotations()[lastAppliedConfigAnnotation]
+	if !ok {
+		// not applied by the operator
+		return false, nil
+	}
+
+	var desired map[string]interface{}
+	if err := json.Unmarshal([]byte(lastApplied), &desired); err != nil {
+		return false, err
+	}
+
+	// normalize the live object the same way as the last applied configuration (e.g. numbers to float64)
+	raw, err := json.Marshal(u.Object)
+	if err != nil {
+		return false, err
+	}
+	var live map[string]interface{}
+	if err := json.Unmarshal(raw, &live); err != nil {
+		return false, err
+	}
+
+	// the status is owned by the controllers of the resources
+	delete(desired, "status")
+
+	return !containsFields(live, desired), nil
+}
+
+// containsFields returns true if all fields of desired exist with the same values in live. Maps may contain additional
+// keys in live, lists must have the same length.
+func containsFields(live, desired interface{}) bool {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range d {
+			// the annotation itself is not part of the comparison
+			if key == lastAppliedConfigAnnotation || value == nil {
+				continue
+			}
+			if !containsFields(l[key], value) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			return false
+		}
+		for i := range d {
+			if !containsFields(l[i], d[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(live, desired) || equalQuantities(live, desired)
+	}
+}
+
+// equalQuantities returns true if live is a quantity with the same value as desired. The API server normalizes
+// quantities, e.g. cpu: 0.5 to 500m, so their applied form may differ from the live one.
+func equalQuantities(live, desired interface{}) bool {
+	l, ok := live.(string)
+	if !ok {
+		return false
+	}
+	liveQuantity, err := resource.ParseQuantity(l)
+	if err != nil {
+		return false
+	}
+
+	var d string
+	switch v := desired.(type) {
+	case string:
+		d = v
+	case float64:
+		d = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return false
+	}
+	desiredQuantity, err := resource.ParseQuantity(d)
+	if err != nil {
+		return false
+	}
+
+	return liveQuantity.Cmp(desiredQuantity) == 0
+}
+
+// correctDrift reports the drifted resources, which got reverted by the install stage, in the status and as event.
+// Resources, which are not applied anymore (e.g. as they were deleted by the operator itself), are ignored. The
+// reported resources are cleared, once a reconcile found no drift.
+func (r *Reconciler) correctDrift(ctx context.Context, m *manifests.Manifests, em *v1alpha1.EventMesh) error {
+	em.Status.DriftedResources = nil
+
+	drifted := r.driftTracker.pop(em.Name)
+	if len(drifted) == 0 {
+		return nil
+	}
+
+	corrected := make([]v1alpha1.DriftedResource, 0, len(drifted))
+	names := make([]string, 0, len(drifted))
+	for _, resource := range drifted {
+		applied := m.ToApply.Filter(mf.ByKind(resource.Kind), mf.ByName(resource.Name), byNamespace(resource.Namespace))
+		if len(applied.Resources()) == 0 {
+			continue
+		}
+
+		corrected = append(corrected, resource)
+		names = append(names, resource.String())
+	}
+
+	if len(corrected) == 0 {
+		return nil
+	}
+
+	logging.FromContext(ctx).Infof("Reverted manual changes of %s", strings.Join(names, ", "))
+	utils.RecordEvent(ctx, em, reconciler.NewEvent(corev1.EventTypeNormal, "DriftCorrected", "Reverted manual changes of %s", strings.Join(names, ", ")))
+	em.Status.DriftedResources = corrected
+
+	return nil
+}
+
+func byNamespace(namespace string) mf.Predicate {
+	return func(u *unstructured.Unstructured) bool {
+		return u.GetNamespace() == namespace
+	}
+}
@@ -0,0 +1,179 @@
+package eventmesh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	mf "github.com/manifestival/manifestival"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/eventmesh-operator/pkg/manifests"
+	"knative.dev/pkg/controller"
+)
+
+func TestIsDrifted(t *testing.T) {
+	lastApplied := `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"kafka-controller","namespace":"knative-eventing","labels":{"app":"kafka-controller"}},"spec":{"replicas":1,"template":{"spec":{"containers":[{"name":"controller","image":"controller:1.19"}]}}}}`
+	lastAppliedWithRequests := `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"kafka-controller","namespace":"knative-eventing","labels":{"app":"kafka-controller"}},"spec":{"replicas":1,"template":{"spec":{"containers":[{"name":"controller","image":"controller:1.19","resources":{"requests":{"cpu":0.5,"memory":"1024Mi"}}}]}}}}`
+
+	tests := []struct {
+		name       string
+		deployment *appsv1.Deployment
+		want       bool
+	}{
+		{
+			name:       "matches last applied configuration",
+			deployment: createDeployment(lastApplied, 1, "controller:1.19", nil),
+			want:       false,
+		},
+		{
+			name:       "additional fields from other controllers",
+			deployment: createDeployment(lastApplied, 1, "controller:1.19", map[string]string{"kubectl.kubernetes.io/restartedAt": "now"}),
+			want:       false,
+		},
+		{
+			name:       "scaled down by hand",
+			deployment: createDeployment(lastApplied, 0, "controller:1.19", nil),
+			want:       true,
+		},
+		{
+			name:       "image changed by hand",
+			deployment: createDeployment(lastApplied, 1, "controller:latest", nil),
+			want:       true,
+		},
+		{
+			name: "quantities normalized by the API server",
+			deployment: withRequests(createDeployment(lastAppliedWithRequests, 1, "controller:1.19", nil), corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			}),
+			want: false,
+		},
+		{
+			name: "quantity changed by hand",
+			deployment: withRequests(createDeployment(lastAppliedWithRequests, 1, "controller:1.19", nil), corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			}),
+			want: true,
+		},
+		{
+			name:       "not applied by the operator",
+			deployment: createDeployment("", 0, "controller:latest", nil),
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := isDrifted(tt.deployment)
+			if err != nil {
+				t.Fatalf("isDrifted() returned an error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("isDrifted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDriftTracker(t *testing.T) {
+	tracker := newDriftTracker()
+
+	tracker.add("eventmesh", v1alpha1.DriftedResource{Kind: "Deployment", Namespace: "knative-eventing", Name: "kafka-controller"})
+	tracker.add("eventmesh", v1alpha1.DriftedResource{Kind: "ConfigMap", Namespace: "knative-eventing", Name: "config-features"})
+	tracker.add("eventmesh", v1alpha1.DriftedResource{Kind: "ConfigMap", Namespace: "knative-eventing", Name: "config-features"})
+
+	got := tracker.pop("eventmesh")
+	if len(got) != 2 {
+		t.Fatalf("pop() returned %d resources, want 2", len(got))
+	}
+	if got[0].String() != "ConfigMap/knative-eventing/config-features" {
+		t.Errorf("pop() returned %s first, want resources sorted", got[0])
+	}
+
+	if got := tracker.pop("eventmesh"); len(got) != 0 {
+		t.Errorf("pop() returned %d resources after pop, want 0", len(got))
+	}
+}
+
+func TestCorrectDrift(t *testing.T) {
+	ctx := controller.WithEventRecorder(context.Background(), record.NewFakeRecorder(10))
+	r := &Reconciler{driftTracker: newDriftTracker()}
+
+	deployment := v1alpha1.DriftedResource{Kind: "Deployment", Namespace: "knative-eventing", Name: "kafka-controller"}
+	applied, err := mf.ManifestFrom(mf.Slice([]unstructured.Unstructured{{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "kafka-controller",
+			"namespace": "knative-eventing",
+		},
+	}}}))
+	if err != nil {
+		t.Fatalf("ManifestFrom() = %v", err)
+	}
+	m := &manifests.Manifests{ToApply: applied}
+	em := &v1alpha1.EventMesh{ObjectMeta: metav1.ObjectMeta{Name: "eventmesh"}}
+
+	r.driftTracker.add("eventmesh", deployment)
+	if err := r.correctDrift(ctx, m, em); err != nil {
+		t.Fatalf("correctDrift() = %v", err)
+	}
+	if diff := cmp.Diff([]v1alpha1.DriftedResource{deployment}, em.Status.DriftedResources); diff != "" {
+		t.Errorf("DriftedResources (-want, +got) = %v", diff)
+	}
+
+	// the drifted resources are cleared, once a reconcile finds no drift
+	if err := r.correctDrift(ctx, m, em); err != nil {
+		t.Fatalf("correctDrift() = %v", err)
+	}
+	if len(em.Status.DriftedResources) != 0 {
+		t.Errorf("DriftedResources = %v, want none", em.Status.DriftedResources)
+	}
+}
+
+func withRequests(d *appsv1.Deployment, requests corev1.ResourceList) *appsv1.Deployment {
+	d.Spec.Template.Spec.Containers[0].Resources.Requests = requests
+	return d
+}
+
+func createDeployment(lastApplied string, replicas int32, image string, annotations map[string]string) *appsv1.Deployment {
+	if lastApplied != "" {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[lastAppliedConfigAnnotation] = lastApplied
+	}
+
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "kafka-controller",
+			Namespace:   "knative-eventing",
+			Labels:      map[string]string{"app": "kafka-controller"},
+			Annotations: annotations,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(replicas),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "controller",
+						Image: image,
+					}},
+				},
+			},
+		},
+	}
+}
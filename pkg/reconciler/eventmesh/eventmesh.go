@@ -18,7 +18,9 @@ package eventmesh
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"time"
 
 	mf "github.com/manifestival/manifestival"
 	"go.uber.org/zap"
@@ -27,8 +29,14 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	batchv1client "k8s.io/client-go/kubernetes/typed/batch/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/ptr"
 	"knative.dev/eventing/pkg/apis/feature"
 	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
 	eventmeshreconciler "knative.dev/eventmesh-operator/pkg/client/injection/reconciler/operator/v1alpha1/eventmesh"
@@ -37,32 +45,78 @@ import (
 	"knative.dev/eventmesh-operator/pkg/manifests/transform"
 	"knative.dev/eventmesh-operator/pkg/reconciler/common"
 	"knative.dev/eventmesh-operator/pkg/scaler"
+	"knative.dev/eventmesh-operator/pkg/utils"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/reconciler"
 	"knative.dev/pkg/system"
 )
 
+// upgradeStepInterval is the interval, in which the availability of an intermediate release is checked during
+// step-by-step upgrades
+const upgradeStepInterval = 30 * time.Second
+
 type Reconciler struct {
-	eventMeshLister   operatorv1alpha1listers.EventMeshLister
-	deploymentLister  appsv1listers.DeploymentLister
-	scaler            *scaler.Scaler
-	manifest          mf.Manifest
-	crdLister         apiextensionsv1.CustomResourceDefinitionLister
-	eventingParser    manifests.Parser
-	kafkaBrokerParser manifests.Parser
+	eventMeshLister    operatorv1alpha1listers.EventMeshLister
+	deploymentLister   appsv1listers.DeploymentLister
+	statefulSetLister  appsv1listers.StatefulSetLister
+	secretLister       corev1listers.SecretLister
+	scaler             *scaler.Scaler
+	applier            *manifests.Applier
+	crdLister          apiextensionsv1.CustomResourceDefinitionLister
+	eventingParser     manifests.Parser
+	kafkaBrokerParser  manifests.Parser
+	driftTracker       *driftTracker
+	jobLister          batchv1listers.JobLister
+	jobClient          batchv1client.JobsGetter
+	configMapLister    corev1listers.ConfigMapLister
+	configMapClient    corev1client.ConfigMapsGetter
+	postInstallRetries *postInstallRetries
+	// enqueueAfter enqueues the EventMesh again after the given delay
+	enqueueAfter func(obj interface{}, after time.Duration)
 }
 
-// Check that our Reconciler implements eventmeshreconciler.Interface
-var _ eventmeshreconciler.Interface = (*Reconciler)(nil)
+// Check that our Reconciler implements eventmeshreconciler.Interface and eventmeshreconciler.Finalizer
+var (
+	_ eventmeshreconciler.Interface = (*Reconciler)(nil)
+	_ eventmeshreconciler.Finalizer = (*Reconciler)(nil)
+
+	// Check that the Scaler provides the scale targets
+	_ ScaleTargets = (*scaler.Scaler)(nil)
+)
 
 func (r *Reconciler) ReconcileKind(ctx context.Context, em *v1alpha1.EventMesh) reconciler.Event {
+	// only the finalizer blocks the deletion
+	em.Status.MarkDeletionAllowed()
+
+	// apply a previous revision instead of the spec, while a rollback is requested
+	rollback, err := r.rollbackSpec(em)
+	if err != nil {
+		if stderrors.Is(err, errUnknownRevision) {
+			em.Status.MarkInstallFailed("RollbackFailed", "Failed to roll back: %v", err)
+			reconcilerMetrics.recordInstallFailure(ctx, em)
+			return precheckFailedEvent(em)
+		}
+		return fmt.Errorf("could not get rollback revision: %w", err)
+	}
+	if rollback != nil {
+		logging.FromContext(ctx).Infof("Rolling back to revision %s", em.Annotations[v1alpha1.RollbackToRevisionAnnotation])
+		spec := em.Spec
+		em.Spec = *rollback
+		defer func() {
+			em.Spec = spec
+		}()
+	}
+
 	precheckOk, err := r.runPrechecks(ctx, em)
 	if err != nil {
 		return fmt.Errorf("could not run prechecks: %w", err)
 	}
 	if !precheckOk {
 		// prechecks failed and they set the status on their own
-		return nil
+		reconcilerMetrics.recordInstallFailure(ctx, em)
+		return precheckFailedEvent(em)
 	}
 
 	stages := common.Stages{
@@ -73,23 +127,42 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, em *v1alpha1.EventMesh)
 		manifests.AppendFromParser(ctx, r.kafkaBrokerParser),
 
 		// apply scaling
-		r.applyScaling,
+		ApplyScaling(r.scaler, WorkloadListers{Deployments: r.deploymentLister, StatefulSets: r.statefulSetLister}, r.enqueueAfter),
 
 		// add owner reference transformer
-		r.addOwnerReference,
+		AddOwnerReference,
 
 		// run transformers
 		manifests.Transform,
 
-		// install (delete + apply + post-install on upgrade)
-		manifests.Install(r.manifest),
+		// install (delete + server-side apply + post-install on upgrade)
+		manifests.Install(r.applier),
+
+		// delay scale downs from the applied scale targets
+		ScalingApplied(r.scaler),
+
+		// report reverted manual changes
+		r.correctDrift,
+
+		// record installed versions and components
+		r.updateInventory,
+
+		// track post-install jobs to completion
+		r.checkPostInstall,
 
 		// wait for ready
 		r.checkDeployments,
 	}
 
 	err = stages.Execute(ctx, em)
+	if outcome, ok := revisionOutcome(em, err); ok {
+		if recordErr := r.recordRevision(ctx, em, outcome); recordErr != nil && err == nil {
+			return fmt.Errorf("failed to record revision: %w", recordErr)
+		}
+	}
 	if err != nil {
+		em.Status.MarkInstallFailed("InstallFailed", "Failed to install: %v", err)
+		reconcilerMetrics.recordInstallFailure(ctx, em)
 		if common.IsNonRecoverableError(err) {
 			// it doesn't make sense to retrigger a reconcile on non-recoverable errors
 			return nil
@@ -99,7 +172,23 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, em *v1alpha1.EventMesh)
 
 	em.Status.MarkInstallSucceeded()
 
-	return nil
+	return r.continueUpgrade(ctx, em)
+}
+
+// continueUpgrade requeues the EventMesh, while it is upgraded step by step through intermediate releases, so that the
+// next release gets installed once the current one is available
+func (r *Reconciler) continueUpgrade(ctx context.Context, em *v1alpha1.EventMesh) reconciler.Event {
+	target, err := manifests.ResolveRelease(em.Spec.Version)
+	if err != nil {
+		return fmt.Errorf("failed to resolve release: %w", err)
+	}
+
+	if em.Status.Version == target.Version() {
+		return nil
+	}
+
+	logging.FromContext(ctx).Infof("Installed intermediate release %s on the upgrade to %s", em.Status.Version, target.Version())
+	return controller.NewRequeueAfter(upgradeStepInterval)
 }
 
 func (r *Reconciler) hasForeignEventingInstalled(ctx context.Context, em *v1alpha1.EventMesh) (bool, error) {
@@ -130,55 +219,183 @@ func (r *Reconciler) hasForeignEventingInstalled(ctx context.Context, em *v1alph
 	return true, nil
 }
 
-func (r *Reconciler) applyScaling(ctx context.Context, manifests *manifests.Manifests, em *v1alpha1.EventMesh) error {
-	if err := r.applyScalingForIMC(ctx, manifests, em); err != nil {
-		return fmt.Errorf("failed to apply Scaling for IMC: %w", err)
+// ScaleTargets provides the number of replicas the components of the scale rules should be scaled to, according to the
+// scaling policy of the EventMesh, and the remaining time, after which a delayed scale down is applied. Scale downs are
+// delayed from the current replicas of the workloads, until ScaleApplied records the applied scale targets.
+type ScaleTargets interface {
+	ScaleTarget(em *v1alpha1.EventMesh, rule *scaler.Rule, current func() (int64, bool)) (int64, time.Duration, error)
+	ScaleApplied(em *v1alpha1.EventMesh)
+}
+
+// WorkloadListers are the listers of the scalable workloads. They are used to report scale changes.
+type WorkloadListers struct {
+	Deployments  appsv1listers.DeploymentLister
+	StatefulSets appsv1listers.StatefulSetLister
+}
+
+// replicas returns the replicas of the given workload in the system namespace
+func (l WorkloadListers) replicas(kind, name string) (*int32, error) {
+	switch kind {
+	case "StatefulSet":
+		ss, err := l.StatefulSets.StatefulSets(system.Namespace()).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		return ss.Spec.Replicas, nil
+	default:
+		d, err := l.Deployments.Deployments(system.Namespace()).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		return d.Spec.Replicas, nil
 	}
+}
+
+// ApplyScaling returns a stage, which scales the components of the scale rules to the targets of the given
+// ScaleTargets. Scale changes are reported as events, when workloads are scaled to or from zero. The EventMesh is
+// enqueued again via enqueueAfter, once a delayed scale down is due.
+func ApplyScaling(targets ScaleTargets, workloads WorkloadListers, enqueueAfter func(obj interface{}, after time.Duration)) common.Stage {
+	return func(ctx context.Context, manifests *manifests.Manifests, em *v1alpha1.EventMesh) error {
+		var nextScaleDown time.Duration
+		for _, rule := range scaler.Rules {
+			// disabled components are deleted anyway and don't need to be scaled
+			if !rule.Enabled(em.Spec.Components) {
+				continue
+			}
 
-	if err := r.applyScalingForMTBroker(ctx, manifests, em); err != nil {
-		return fmt.Errorf("failed to apply Scaling for MT broker: %w", err)
+			scaleDownIn, err := applyScalingRule(ctx, targets, workloads, rule, manifests, em)
+			if err != nil {
+				return fmt.Errorf("failed to apply scaling for %s: %w", rule.Component, err)
+			}
+			if scaleDownIn > 0 && (nextScaleDown == 0 || scaleDownIn < nextScaleDown) {
+				nextScaleDown = scaleDownIn
+			}
+		}
+
+		if nextScaleDown > 0 {
+			enqueueAfter(em, nextScaleDown)
+		}
+
+		return nil
 	}
+}
 
-	return nil
+// applyScalingRule scales the HPAs, Deployments and StatefulSets of the rule to the scale target of its component.
+// Returns the remaining time, after which a delayed scale down of the component is applied.
+func applyScalingRule(ctx context.Context, targets ScaleTargets, workloads WorkloadListers, rule *scaler.Rule, manifests *manifests.Manifests, em *v1alpha1.EventMesh) (time.Duration, error) {
+	logger := logging.FromContext(ctx).With("scaler", rule.Component)
+
+	scaleTarget, scaleDownIn, err := targets.ScaleTarget(em, rule, func() (int64, bool) {
+		return ruleReplicas(workloads, rule, logger)
+	})
+	if stderrors.Is(err, scaler.ErrNotSynced) {
+		logger.Infow("Keeping the replicas of the components, until the objects can be counted", zap.Error(err))
+		keepScaling(workloads, rule, manifests, em, logger)
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get scale target for %s components: %w", rule.Component, err)
+	}
+	if scaleDownIn > 0 {
+		logger.Infof("Keeping components for %s at %d replicas, they are scaled down in %s", rule.Objects, scaleTarget, scaleDownIn.Round(time.Second))
+	}
+
+	logger.Debugf("Scaling components for %s to %d", rule.Objects, scaleTarget)
+
+	for _, hpa := range rule.HPAs {
+		if addHPATransformerIfNeeded(hpa.Name, hpa.Deployment, system.Namespace(), scaleTarget, manifests, em, logger) {
+			reportScaleChange(ctx, workloads, em, "Deployment", hpa.Deployment, scaleTarget, rule.Objects)
+		}
+	}
+
+	for _, workload := range scaledWorkloads(rule) {
+		if addScaleTransformerIfNeeded(workload.kind, workload.name, system.Namespace(), scaleTarget, rule.ScaleToZeroOnly, manifests, em, logger) {
+			reportScaleChange(ctx, workloads, em, workload.kind, workload.name, scaleTarget, rule.Objects)
+		}
+	}
+
+	return scaleDownIn, nil
 }
 
-func (r *Reconciler) applyScalingForIMC(ctx context.Context, manifests *manifests.Manifests, em *v1alpha1.EventMesh) error {
-	logger := logging.FromContext(ctx).With("scaler", "imc")
+// keepScaling keeps the workloads of the rule at their current replicas, while the scale target is unknown. HPAs are
+// only kept deleted, if their deployment is scaled to zero, as they keep the replicas of their deployment otherwise.
+// Workloads, which are not installed yet, are left to their defaults.
+func keepScaling(workloads WorkloadListers, rule *scaler.Rule, manifests *manifests.Manifests, em *v1alpha1.EventMesh, logger *zap.SugaredLogger) {
+	for _, hpa := range rule.HPAs {
+		if replicas, ok := currentReplicas(workloads, "Deployment", hpa.Deployment, logger); ok && replicas == 0 {
+			addHPATransformerIfNeeded(hpa.Name, hpa.Deployment, system.Namespace(), 0, manifests, em, logger)
+		}
+	}
 
-	imcScaleTarget, err := r.scaler.IMCScaleTarget()
-	if err != nil {
-		return fmt.Errorf("failed to get scale target for IMC components: %w", err)
+	for _, workload := range scaledWorkloads(rule) {
+		if replicas, ok := currentReplicas(workloads, workload.kind, workload.name, logger); ok {
+			addScaleTransformerIfNeeded(workload.kind, workload.name, system.Namespace(), replicas, rule.ScaleToZeroOnly, manifests, em, logger)
+		}
 	}
+}
 
-	logger.Debugf("Scaling in-memory channel components to %d", imcScaleTarget)
+// ScalingApplied returns a stage, which records the scale targets as applied, once the manifests were installed
+func ScalingApplied(targets ScaleTargets) common.Stage {
+	return func(ctx context.Context, manifests *manifests.Manifests, em *v1alpha1.EventMesh) error {
+		targets.ScaleApplied(em)
+		return nil
+	}
+}
 
-	// scale imc deployments directly as they are not managed via HPA
-	addDeploymentScaleTransformerIfNeeded("imc-dispatcher", system.Namespace(), imcScaleTarget, manifests, em, logger)
-	addDeploymentScaleTransformerIfNeeded("imc-controller", system.Namespace(), imcScaleTarget, manifests, em, logger)
+// ruleReplicas returns the highest replicas of the workloads of the rule. Returns false, if none of them is installed
+// yet.
+func ruleReplicas(workloads WorkloadListers, rule *scaler.Rule, logger *zap.SugaredLogger) (int64, bool) {
+	var replicas int64
+	installed := false
+	for _, hpa := range rule.HPAs {
+		if r, ok := currentReplicas(workloads, "Deployment", hpa.Deployment, logger); ok {
+			replicas, installed = max(replicas, r), true
+		}
+	}
+	for _, workload := range scaledWorkloads(rule) {
+		if r, ok := currentReplicas(workloads, workload.kind, workload.name, logger); ok {
+			replicas, installed = max(replicas, r), true
+		}
+	}
 
-	return nil
+	return replicas, installed
 }
 
-func (r *Reconciler) applyScalingForMTBroker(ctx context.Context, manifests *manifests.Manifests, em *v1alpha1.EventMesh) error {
-	logger := logging.FromContext(ctx).With("scaler", "mt-broker")
+type scaledWorkload struct {
+	kind string
+	name string
+}
 
-	mtBrokerScaleTarget, err := r.scaler.MTBrokerScaleTarget()
-	if err != nil {
-		return fmt.Errorf("failed to get scale target for MT Broker components: %w", err)
+// scaledWorkloads returns the Deployments and StatefulSets of the rule, which are scaled directly
+func scaledWorkloads(rule *scaler.Rule) []scaledWorkload {
+	ret := make([]scaledWorkload, 0, len(rule.Deployments)+len(rule.StatefulSets))
+	for _, name := range rule.Deployments {
+		ret = append(ret, scaledWorkload{kind: "Deployment", name: name})
+	}
+	for _, name := range rule.StatefulSets {
+		ret = append(ret, scaledWorkload{kind: "StatefulSet", name: name})
 	}
 
-	logger.Debugf("Scaling mt broker components to %d", mtBrokerScaleTarget)
+	return ret
+}
 
-	addHPATransformerIfNeeded("broker-filter-hpa", "mt-broker-filter", system.Namespace(), mtBrokerScaleTarget, manifests, em, logger)
-	addHPATransformerIfNeeded("broker-ingress-hpa", "mt-ingress-filter", system.Namespace(), mtBrokerScaleTarget, manifests, em, logger)
-	// mt-broker controller is not managed via HPA therefor scale deployment directly
-	addDeploymentScaleTransformerIfNeeded("mt-broker-controller", system.Namespace(), mtBrokerScaleTarget, manifests, em, logger)
+// currentReplicas returns the replicas of the workload. Returns false, if the workload is not installed yet.
+func currentReplicas(workloads WorkloadListers, kind, name string, logger *zap.SugaredLogger) (int64, bool) {
+	replicas, err := workloads.replicas(kind, name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Warnw("Failed to get workload to keep its replicas", zap.String("kind", kind), zap.String("name", name), zap.Error(err))
+		}
+		return 0, false
+	}
 
-	return nil
+	return int64(ptr.Deref(replicas, 1)), true
 }
 
-func addHPATransformerIfNeeded(hpaName, deploymentName, namespace string, scaleTarget int64, manifests *manifests.Manifests, em *v1alpha1.EventMesh, logger *zap.SugaredLogger) {
-	if !em.Spec.Overrides.Workloads.GetByName(deploymentName).HasAtLeastOneWithReplicasSet() {
+// addHPATransformerIfNeeded scales the deployment via its HPA or directly, if it is scaled to zero. Returns false, if
+// the replicas of the deployment are overridden and it is not scaled.
+func addHPATransformerIfNeeded(hpaName, deploymentName, namespace string, scaleTarget int64, manifests *manifests.Manifests, em *v1alpha1.EventMesh, logger *zap.SugaredLogger) bool {
+	if !em.Spec.Overrides.GetWorkloads().GetByName(deploymentName).HasAtLeastOneWithReplicasSet() {
 
 		if scaleTarget == 0 {
 			// we can't scale to 0 with HPA unless HPAScaleToZero feature gate is enabled. --> remove HPA and scale deployment instead
@@ -204,24 +421,78 @@ func addHPATransformerIfNeeded(hpaName, deploymentName, namespace string, scaleT
 		}
 	} else {
 		logger.Debugf("Skipping to adjust %s HPA to %d replicas, as a workload override for its deployment (%s) exists which sets the replicas already", hpaName, scaleTarget, deploymentName)
+		return false
 	}
+
+	return true
 }
 
-func addDeploymentScaleTransformerIfNeeded(deploymentName, namespace string, scaleTarget int64, manifests *manifests.Manifests, em *v1alpha1.EventMesh, logger *zap.SugaredLogger) {
-	if !em.Spec.Overrides.Workloads.GetByName(deploymentName).HasAtLeastOneWithReplicasSet() {
+// addScaleTransformerIfNeeded scales the Deployment or StatefulSet directly. If scaleToZeroOnly is set, the replicas
+// are only set to scale to zero. Once they are not applied anymore, they are defaulted again and managed by the
+// component itself (e.g. the Kafka controller for the dispatchers). Returns false, if the replicas of the workload are
+// overridden and it is not scaled.
+func addScaleTransformerIfNeeded(kind, name, namespace string, scaleTarget int64, scaleToZeroOnly bool, manifests *manifests.Manifests, em *v1alpha1.EventMesh, logger *zap.SugaredLogger) bool {
+	if em.Spec.Overrides.GetWorkloads().GetByName(name).HasAtLeastOneWithReplicasSet() {
+		logger.Debugf("Skipping to scale %s %s to %d, as a workload override for it exists which sets the replicas already", kind, name, scaleTarget)
+		return false
+	}
+
+	if scaleTarget == 0 || !scaleToZeroOnly {
 		manifests.AddTransformers(
-			transform.Scale(schema.FromAPIVersionAndKind("apps/v1", "Deployment"),
-				deploymentName,
+			transform.Scale(schema.FromAPIVersionAndKind("apps/v1", kind),
+				name,
 				namespace,
 				scaleTarget))
-	} else {
-		logger.Debugf("Skipping to scale %s deployment to %d, as a workload override for this deployment exists which sets the replicas already", deploymentName, scaleTarget)
 	}
+
+	return true
+}
+
+// reportScaleChange records an event, when the workload gets scaled to or from zero. Workloads, which are not
+// installed yet, are not reported.
+func reportScaleChange(ctx context.Context, workloads WorkloadListers, em *v1alpha1.EventMesh, kind, name string, scaleTarget int64, objects string) {
+	current, err := workloads.replicas(kind, name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logging.FromContext(ctx).Warnw("Failed to get workload to report scale changes", zap.String("kind", kind), zap.String("name", name), zap.Error(err))
+		}
+		return
+	}
+
+	replicas := ptr.Deref(current, 1)
+	switch {
+	case scaleTarget == 0 && replicas > 0:
+		utils.RecordEvent(ctx, em, reconciler.NewEvent(corev1.EventTypeNormal, "Scaled", "Scaled %s to 0, no %s", name, objects))
+	case scaleTarget > 0 && replicas == 0:
+		utils.RecordEvent(ctx, em, reconciler.NewEvent(corev1.EventTypeNormal, "Scaled", "Scaled %s to %d for %s", name, scaleTarget, objects))
+	}
+}
+
+// precheckFailedEvent returns the warning event reporting the failed precheck from the status
+func precheckFailedEvent(em *v1alpha1.EventMesh) reconciler.Event {
+	for _, conditionType := range []apis.ConditionType{v1alpha1.EventMeshConditionPrimary, v1alpha1.EventMeshConditionInstallSucceeded, v1alpha1.EventMeshConditionAuthConfigured} {
+		if condition := em.Status.GetCondition(conditionType); condition.IsFalse() {
+			return reconciler.NewEvent(corev1.EventTypeWarning, condition.Reason, "%s", condition.Message)
+		}
+	}
+
+	return nil
 }
 
 // runPrechecks runs some prechecks before applying the manifests.
 // returns true if all checks were passed or false if at least one check failed
 func (r *Reconciler) runPrechecks(ctx context.Context, em *v1alpha1.EventMesh) (bool, error) {
+	// check if this is the only (or primary) EventMesh in the cluster
+	eventMeshes, err := r.eventMeshLister.List(labels.Everything())
+	if err != nil {
+		return false, fmt.Errorf("could not list EventMeshes: %v", err)
+	}
+	if primary := utils.PrimaryEventMesh(eventMeshes); primary != nil && primary.Name != em.Name {
+		em.Status.MarkDuplicate(primary.Name)
+		return false, nil
+	}
+	em.Status.MarkPrimary()
+
 	// check if eventing is installed already and not owned by EM
 	alreadyInstalled, err := r.hasForeignEventingInstalled(ctx, em)
 	if err != nil {
@@ -232,6 +503,19 @@ func (r *Reconciler) runPrechecks(ctx context.Context, em *v1alpha1.EventMesh) (
 		return false, nil
 	}
 
+	// check if the requested version is bundled with the operator and can be upgraded to
+	if _, err := manifests.PlanRelease(em); err != nil {
+		if stderrors.Is(err, manifests.ErrUnknownVersion) {
+			em.Status.MarkInstallFailed("UnknownVersion", "Version %s is not available in this operator release", em.Spec.Version)
+			return false, nil
+		}
+		if stderrors.Is(err, manifests.ErrUnsupportedUpgrade) {
+			em.Status.MarkInstallFailed("UnsupportedUpgrade", "%v. Set the annotation %s: \"true\" to enforce it", err, v1alpha1.AllowUnsupportedUpgradeAnnotation)
+			return false, nil
+		}
+		return false, fmt.Errorf("could not resolve release: %v", err)
+	}
+
 	// check if transport-encryption is enabled and required cert-manager is installed
 	featuresFlags, err := em.Spec.Features.GetEventingFeatureFlags()
 	if err != nil {
@@ -249,10 +533,58 @@ func (r *Reconciler) runPrechecks(ctx context.Context, em *v1alpha1.EventMesh) (
 		}
 	}
 
+	// check if Kafka autoscaling is enabled and the required KEDA CRDs are installed
+	if em.Spec.Components.IsKafkaEnabled() && em.Spec.Kafka.IsKafkaAutoscalingEnabled() {
+		kedaInstalled, err := utils.IsKEDAInstalled(r.crdLister)
+		if err != nil {
+			return false, fmt.Errorf("could not determine whether KEDA is installed: %v", err)
+		}
+		if !kedaInstalled {
+			em.Status.MarkInstallFailed("KEDARequired", "Kafka autoscaling is enabled, but KEDA seems not to be installed")
+			return false, nil
+		}
+	}
+
+	// check if the referenced Kafka auth secret exists and is valid
+	authOk, err := r.checkKafkaAuth(em)
+	if err != nil {
+		return false, fmt.Errorf("could not check Kafka authentication: %v", err)
+	}
+	if !authOk {
+		return false, nil
+	}
+
 	// all checks passed
 	return true, nil
 }
 
+// checkKafkaAuth validates the Kafka auth secret referenced in the EventMesh and sets the AuthConfigured condition.
+// returns true if no secret is referenced, the Kafka components are disabled or the referenced secret is valid
+func (r *Reconciler) checkKafkaAuth(em *v1alpha1.EventMesh) (bool, error) {
+	secretRef := em.Spec.Kafka.AuthSecretRef
+	if secretRef == nil || !em.Spec.Components.IsKafkaEnabled() {
+		em.Status.MarkAuthNotRequired()
+		return true, nil
+	}
+
+	secret, err := r.secretLister.Secrets(system.Namespace()).Get(secretRef.Name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			em.Status.MarkAuthFailed("AuthSecretNotFound", "Kafka auth secret %s/%s does not exist", system.Namespace(), secretRef.Name)
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get secret %s/%s: %w", system.Namespace(), secretRef.Name, err)
+	}
+
+	if err := utils.ValidateKafkaAuthSecret(secret); err != nil {
+		em.Status.MarkAuthFailed("AuthSecretInvalid", "Kafka auth secret %s/%s is invalid: %v", system.Namespace(), secretRef.Name, err)
+		return false, nil
+	}
+
+	em.Status.MarkAuthConfigured()
+	return true, nil
+}
+
 func (r *Reconciler) hasCertManagerInstalled() (bool, error) {
 	// TODO: migrate usages to use util.IsCertmanagerInstalled() instead
 
@@ -267,7 +599,8 @@ func (r *Reconciler) hasCertManagerInstalled() (bool, error) {
 	return true, nil
 }
 
-func (r *Reconciler) addOwnerReference(ctx context.Context, manifests *manifests.Manifests, em *v1alpha1.EventMesh) error {
+// AddOwnerReference adds a transformer, which sets the EventMesh as owner of the resources
+func AddOwnerReference(ctx context.Context, manifests *manifests.Manifests, em *v1alpha1.EventMesh) error {
 	// TODO: fix (somehow the GVK of the em are empty)
 	emCopy := em.DeepCopy()
 	emCopy.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind("EventMesh"))
@@ -276,6 +609,30 @@ func (r *Reconciler) addOwnerReference(ctx context.Context, manifests *manifests
 	return nil
 }
 
+func (r *Reconciler) updateInventory(ctx context.Context, m *manifests.Manifests, em *v1alpha1.EventMesh) error {
+	release, err := manifests.PlanRelease(em)
+	if err != nil {
+		return fmt.Errorf("failed to resolve release: %w", err)
+	}
+
+	eventingVersion, err := m.ComponentVersion(manifests.ComponentEventingCore)
+	if err != nil {
+		return fmt.Errorf("failed to get eventing version: %w", err)
+	}
+
+	ekbVersion, err := m.ComponentVersion(manifests.ComponentKafkaController)
+	if err != nil {
+		return fmt.Errorf("failed to get eventing-kafka-broker version: %w", err)
+	}
+
+	em.Status.Version = release.Version()
+	em.Status.EventingVersion = eventingVersion
+	em.Status.EventingKafkaBrokerVersion = ekbVersion
+	em.Status.Components = m.Inventory()
+
+	return nil
+}
+
 func (r *Reconciler) checkDeployments(ctx context.Context, manifests *manifests.Manifests, em *v1alpha1.EventMesh) error {
 	var nonReadyDeployments []string
 	for _, u := range manifests.ToApply.Filter(mf.ByKind("Deployment")).Resources() {
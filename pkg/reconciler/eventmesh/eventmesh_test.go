@@ -0,0 +1,126 @@
+package eventmesh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	operatorv1alpha1listers "knative.dev/eventmesh-operator/pkg/client/listers/operator/v1alpha1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+)
+
+func TestReportScaleChange(t *testing.T) {
+	tests := []struct {
+		name        string
+		replicas    *int32
+		scaleTarget int64
+		wantEvents  []string
+	}{
+		{
+			name:        "scaled to zero",
+			replicas:    ptr.To[int32](1),
+			scaleTarget: 0,
+			wantEvents:  []string{"Normal Scaled Scaled imc-dispatcher to 0, no InMemoryChannels"},
+		},
+		{
+			name:        "scaled from zero",
+			replicas:    ptr.To[int32](0),
+			scaleTarget: 1,
+			wantEvents:  []string{"Normal Scaled Scaled imc-dispatcher to 1 for InMemoryChannels"},
+		},
+		{
+			name:        "defaulted replicas scaled to zero",
+			scaleTarget: 0,
+			wantEvents:  []string{"Normal Scaled Scaled imc-dispatcher to 0, no InMemoryChannels"},
+		},
+		{
+			name:        "unchanged",
+			replicas:    ptr.To[int32](0),
+			scaleTarget: 0,
+		},
+		{
+			name:        "scaled by HPA",
+			replicas:    ptr.To[int32](3),
+			scaleTarget: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(context.Background(), recorder)
+
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			_ = indexer.Add(&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "imc-dispatcher", Namespace: system.Namespace()},
+				Spec:       appsv1.DeploymentSpec{Replicas: tt.replicas},
+			})
+
+			workloads := WorkloadListers{Deployments: appsv1listers.NewDeploymentLister(indexer)}
+			reportScaleChange(ctx, workloads, &v1alpha1.EventMesh{}, "Deployment", "imc-dispatcher", tt.scaleTarget, "InMemoryChannels")
+			// deployments, which are not installed yet, are not reported
+			reportScaleChange(ctx, workloads, &v1alpha1.EventMesh{}, "Deployment", "imc-controller", tt.scaleTarget, "InMemoryChannels")
+
+			if diff := cmp.Diff(tt.wantEvents, recordedEvents(recorder)); diff != "" {
+				t.Errorf("events (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestPrecheckFailedEvent(t *testing.T) {
+	em := &v1alpha1.EventMesh{}
+	if event := precheckFailedEvent(em); event != nil {
+		t.Errorf("precheckFailedEvent() = %v, want nil", event)
+	}
+
+	em.Status.MarkAuthFailed("AuthSecretNotFound", "Kafka auth secret %s does not exist", "auth")
+	want := reconciler.NewEvent(corev1.EventTypeWarning, "AuthSecretNotFound", "%s", "Kafka auth secret auth does not exist")
+	if event := precheckFailedEvent(em); !reconciler.EventIs(event, want) || event.Error() != want.Error() {
+		t.Errorf("precheckFailedEvent() = %v, want %v", event, want)
+	}
+
+	em.Status.MarkInstallFailed("CertManagerRequired", "cert-manager seems not to be installed")
+	want = reconciler.NewEvent(corev1.EventTypeWarning, "CertManagerRequired", "%s", "cert-manager seems not to be installed")
+	if event := precheckFailedEvent(em); !reconciler.EventIs(event, want) || event.Error() != want.Error() {
+		t.Errorf("precheckFailedEvent() = %v, want %v", event, want)
+	}
+}
+
+func TestRunPrechecksDuplicate(t *testing.T) {
+	primary := &v1alpha1.EventMesh{ObjectMeta: metav1.ObjectMeta{Name: "eventmesh", CreationTimestamp: metav1.Unix(1, 0)}}
+	duplicate := &v1alpha1.EventMesh{ObjectMeta: metav1.ObjectMeta{Name: "another", CreationTimestamp: metav1.Unix(2, 0)}}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	_ = indexer.Add(primary)
+	_ = indexer.Add(duplicate)
+	r := &Reconciler{eventMeshLister: operatorv1alpha1listers.NewEventMeshLister(indexer)}
+
+	ok, err := r.runPrechecks(context.Background(), duplicate)
+	if ok || err != nil {
+		t.Fatalf("runPrechecks() = %v, %v, want false", ok, err)
+	}
+
+	cond := duplicate.Status.GetCondition(v1alpha1.EventMeshConditionPrimary)
+	if !cond.IsFalse() || cond.Reason != v1alpha1.DuplicateReason {
+		t.Errorf("Primary = %v, want false with reason %s", cond, v1alpha1.DuplicateReason)
+	}
+	if cond := duplicate.Status.GetCondition(v1alpha1.EventMeshConditionInstallSucceeded); cond.IsFalse() {
+		t.Errorf("InstallSucceeded = %v, want it not failed by the duplicate", cond)
+	}
+
+	want := reconciler.NewEvent(corev1.EventTypeWarning, v1alpha1.DuplicateReason, "%s", "Only one EventMesh is supported per cluster and EventMesh eventmesh exists already")
+	if event := precheckFailedEvent(duplicate); !reconciler.EventIs(event, want) || event.Error() != want.Error() {
+		t.Errorf("precheckFailedEvent() = %v, want %v", event, want)
+	}
+}
@@ -0,0 +1,110 @@
+package eventmesh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/eventmesh-operator/pkg/manifests"
+	"knative.dev/eventmesh-operator/pkg/reconciler/common"
+	"knative.dev/eventmesh-operator/pkg/utils"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+)
+
+// deletionBlockedRequeueDelay is the delay after which a blocked deletion is checked again
+const deletionBlockedRequeueDelay = 30 * time.Second
+
+// FinalizeKind uninstalls the components of the EventMesh according to its deletion policy
+func (r *Reconciler) FinalizeKind(ctx context.Context, em *v1alpha1.EventMesh) reconciler.Event {
+	logger := logging.FromContext(ctx)
+
+	installedEm := installedEventMesh(em)
+	if installedEm == nil {
+		logger.Info("EventMesh did not install any release, skipping uninstall")
+		return nil
+	}
+
+	installed, err := r.isInstalledBy(ctx, em)
+	if err != nil {
+		return err
+	}
+	if !installed {
+		logger.Info("EventMesh did not install any components, skipping uninstall")
+		return nil
+	}
+
+	deletionPolicy := em.Spec.GetDeletionPolicy()
+	if deletionPolicy == v1alpha1.DeletionPolicyBlockIfInUse {
+		inUse, err := r.scaler.ObjectsInUse()
+		if err != nil {
+			return fmt.Errorf("could not check for resources in use: %w", err)
+		}
+		if len(inUse) > 0 {
+			em.Status.MarkDeletionBlocked("Deletion is blocked by existing %s", strings.Join(inUse, ", "))
+			return controller.NewRequeueAfter(deletionBlockedRequeueDelay)
+		}
+	}
+
+	stages := common.Stages{
+		// load manifests
+		manifests.AppendFromParser(ctx, r.eventingParser),
+		manifests.AppendFromParser(ctx, r.kafkaBrokerParser),
+
+		// run transformers
+		manifests.Transform,
+
+		// uninstall
+		manifests.Uninstall(r.applier, deletionPolicy == v1alpha1.DeletionPolicyKeepCRDs),
+	}
+
+	if err := stages.Execute(ctx, installedEm); err != nil {
+		return fmt.Errorf("failed to uninstall: %w", err)
+	}
+
+	// the deletions of the uninstall are no drift
+	r.driftTracker.pop(em.Name)
+
+	return nil
+}
+
+// installedEventMesh returns a copy of the EventMesh, which requests the installed release, so that the uninstall
+// removes the manifests of the installed release, even if the requested version is unknown or can't be upgraded to.
+// It returns nil, if no release was installed.
+func installedEventMesh(em *v1alpha1.EventMesh) *v1alpha1.EventMesh {
+	version := em.Status.Version
+	if version == "" {
+		// installations of older operators only recorded the component versions
+		version = em.Status.EventingVersion
+	}
+	if version == "" {
+		return nil
+	}
+
+	installed := em.DeepCopy()
+	installed.Spec.Version = version
+	return installed
+}
+
+// isInstalledBy returns true if the components were installed by the given EventMesh. Duplicate EventMeshes and
+// EventMeshes, which found a foreign eventing installation, did not install anything and must not uninstall it.
+func (r *Reconciler) isInstalledBy(ctx context.Context, em *v1alpha1.EventMesh) (bool, error) {
+	eventMeshes, err := r.eventMeshLister.List(labels.Everything())
+	if err != nil {
+		return false, fmt.Errorf("could not list EventMeshes: %w", err)
+	}
+	if primary := utils.PrimaryEventMesh(eventMeshes); primary != nil && primary.Name != em.Name {
+		return false, nil
+	}
+
+	foreignInstalled, err := r.hasForeignEventingInstalled(ctx, em)
+	if err != nil {
+		return false, fmt.Errorf("could not determine whether eventing is installed by the EventMesh: %w", err)
+	}
+
+	return !foreignInstalled, nil
+}
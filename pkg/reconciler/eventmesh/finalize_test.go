@@ -0,0 +1,67 @@
+package eventmesh
+
+import (
+	"context"
+	"testing"
+
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/eventmesh-operator/pkg/manifests"
+)
+
+func TestFinalizeKindNothingInstalled(t *testing.T) {
+	em := &v1alpha1.EventMesh{Spec: v1alpha1.EventMeshSpec{Version: "0.1"}}
+
+	// nothing was installed, so the reconciler must not be needed to uninstall
+	r := &Reconciler{}
+	if err := r.FinalizeKind(context.Background(), em); err != nil {
+		t.Errorf("FinalizeKind() = %v, want nil", err)
+	}
+}
+
+func TestInstalledEventMesh(t *testing.T) {
+	t.Setenv("KO_DATA_PATH", "../../../cmd/operator/kodata")
+
+	tests := []struct {
+		name        string
+		status      v1alpha1.EventMeshStatus
+		wantVersion string
+	}{{
+		name: "nothing installed",
+	}, {
+		name:        "installed release",
+		status:      v1alpha1.EventMeshStatus{Version: "1.19.2"},
+		wantVersion: "1.19.2",
+	}, {
+		name:        "installed by an older operator",
+		status:      v1alpha1.EventMeshStatus{EventingVersion: "1.19.2"},
+		wantVersion: "1.19.2",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			em := &v1alpha1.EventMesh{
+				Spec:   v1alpha1.EventMeshSpec{Version: "0.1"},
+				Status: tt.status,
+			}
+
+			installed := installedEventMesh(em)
+			if tt.wantVersion == "" {
+				if installed != nil {
+					t.Errorf("installedEventMesh() = %v, want nil", installed)
+				}
+				return
+			}
+
+			release, err := manifests.PlanRelease(installed)
+			if err != nil {
+				t.Fatalf("PlanRelease() = %v", err)
+			}
+			if release.Version() != tt.wantVersion {
+				t.Errorf("PlanRelease() = %s, want %s", release.Version(), tt.wantVersion)
+			}
+			if em.Spec.Version != "0.1" {
+				t.Errorf("Spec.Version = %s, want the EventMesh unchanged", em.Spec.Version)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package eventmesh
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/observability/attributekey"
+)
+
+const scopeName = "knative.dev/eventmesh-operator/pkg/reconciler/eventmesh"
+
+// ReasonAttr is the reason of the Primary or InstallSucceeded condition
+var ReasonAttr = attributekey.String("kn.eventmesh.install.reason")
+
+type metrics struct {
+	installFailures metric.Int64Counter
+}
+
+var reconcilerMetrics = newMetrics(otel.GetMeterProvider())
+
+func newMetrics(provider metric.MeterProvider) *metrics {
+	var (
+		m   metrics
+		err error
+	)
+
+	meter := provider.Meter(scopeName)
+
+	m.installFailures, err = meter.Int64Counter(
+		"kn.eventmesh.install.failures",
+		metric.WithDescription("The number of reconciles, which refused or failed to install the EventMesh."),
+		metric.WithUnit("{failure}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return &m
+}
+
+// recordInstallFailure counts the failed install by the reason of the Primary or InstallSucceeded condition
+func (m *metrics) recordInstallFailure(ctx context.Context, em *v1alpha1.EventMesh) {
+	for _, conditionType := range []apis.ConditionType{v1alpha1.EventMeshConditionPrimary, v1alpha1.EventMeshConditionInstallSucceeded} {
+		if condition := em.Status.GetCondition(conditionType); condition.IsFalse() {
+			m.installFailures.Add(ctx, 1, metric.WithAttributes(ReasonAttr.With(condition.Reason)))
+			return
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package eventmesh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+)
+
+func TestRecordInstallFailure(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	m := newMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	succeeded := &v1alpha1.EventMesh{}
+	succeeded.Status.MarkInstallSucceeded()
+	m.recordInstallFailure(context.Background(), succeeded)
+
+	for _, reason := range []string{"InstallFailed", "InstallFailed", "UnknownVersion"} {
+		failed := &v1alpha1.EventMesh{}
+		failed.Status.MarkInstallFailed(reason, "failed")
+		m.recordInstallFailure(context.Background(), failed)
+	}
+
+	duplicate := &v1alpha1.EventMesh{}
+	duplicate.Status.MarkDuplicate("eventmesh")
+	m.recordInstallFailure(context.Background(), duplicate)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() = %v", err)
+	}
+
+	failures := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, metric := range sm.Metrics {
+			if data, ok := metric.Data.(metricdata.Sum[int64]); ok {
+				for _, dp := range data.DataPoints {
+					reason, _ := dp.Attributes.Value("kn.eventmesh.install.reason")
+					failures[reason.AsString()] += dp.Value
+				}
+			}
+		}
+	}
+
+	// successful installs are not counted
+	want := map[string]int64{"InstallFailed": 2, "UnknownVersion": 1, v1alpha1.DuplicateReason: 1}
+	if diff := cmp.Diff(want, failures); diff != "" {
+		t.Errorf("install failures (-want, +got) = %v", diff)
+	}
+}
@@ -0,0 +1,229 @@
+package eventmesh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	mf "github.com/manifestival/manifestival"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/eventmesh-operator/pkg/manifests"
+	"knative.dev/eventmesh-operator/pkg/utils"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+)
+
+const (
+	// postInstallRetryBaseDelay is the delay before a failed post-install job is retried the first time. The delay
+	// doubles with each retry up to postInstallRetryMaxDelay.
+	postInstallRetryBaseDelay = time.Minute
+	postInstallRetryMaxDelay  = 30 * time.Minute
+
+	// postInstallJobRetention is how long completed post-install jobs are kept for inspection
+	postInstallJobRetention = time.Hour
+)
+
+// postInstallRetries counts the retries of failed post-install jobs per job
+type postInstallRetries struct {
+	mu       sync.Mutex
+	attempts map[string]int
+}
+
+func newPostInstallRetries() *postInstallRetries {
+	return &postInstallRetries{
+		attempts: map[string]int{},
+	}
+}
+
+// next returns the delay before the next retry of the given job
+func (pr *postInstallRetries) next(job string) time.Duration {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+
+	return retryDelay(pr.attempts[job])
+}
+
+// retried records a retry of the given job
+func (pr *postInstallRetries) retried(job string) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+
+	pr.attempts[job]++
+}
+
+// reset forgets about the retries of the given job
+func (pr *postInstallRetries) reset(job string) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+
+	delete(pr.attempts, job)
+}
+
+// retryDelay returns the delay before the retry after the given number of attempts
+func retryDelay(attempts int) time.Duration {
+	delay := postInstallRetryBaseDelay
+	for i := 0; i < attempts && delay < postInstallRetryMaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, postInstallRetryMaxDelay)
+}
+
+// checkPostInstall tracks the post-install jobs to completion and reports their state in the PostInstallSucceeded
+// condition. Failed jobs are deleted with backoff, so that the next reconcile creates them again. Completed jobs are
+// cleaned up after their retention period.
+func (r *Reconciler) checkPostInstall(ctx context.Context, m *manifests.Manifests, em *v1alpha1.EventMesh) error {
+	logger := logging.FromContext(ctx)
+
+	jobManifests := m.PostInstall.Filter(mf.ByKind("Job")).Resources()
+	if len(jobManifests) == 0 {
+		if !em.Status.GetCondition(v1alpha1.EventMeshConditionPostInstallSucceeded).IsTrue() {
+			em.Status.MarkPostInstallNotRequired()
+		}
+		return r.cleanupPostInstallJobs(ctx, em)
+	}
+
+	// events are only recorded when the state of the post-install jobs changes
+	previous := em.Status.GetCondition(v1alpha1.EventMeshConditionPostInstallSucceeded)
+
+	var running []string
+	var failed []*batchv1.Job
+	for _, u := range jobManifests {
+		job, err := r.jobLister.Jobs(u.GetNamespace()).Get(u.GetName())
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// just created and not in the informer cache yet
+				running = append(running, u.GetName())
+				continue
+			}
+			return fmt.Errorf("failed to get job %s/%s: %w", u.GetNamespace(), u.GetName(), err)
+		}
+
+		switch {
+		case jobCondition(job, batchv1.JobFailed) != nil:
+			failed = append(failed, job)
+		case jobCondition(job, batchv1.JobComplete) != nil:
+			r.postInstallRetries.reset(jobKey(job))
+		default:
+			running = append(running, job.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		condition := jobCondition(failed[0], batchv1.JobFailed)
+		em.Status.MarkPostInstallFailed(condition.Reason, "Job %s failed: %s", failed[0].Name, condition.Message)
+		if !previous.IsFalse() {
+			utils.RecordEvent(ctx, em, reconciler.NewEvent(corev1.EventTypeWarning, "PostInstallFailed", "Post-install job %s failed: %s", failed[0].Name, condition.Message))
+		}
+
+		for _, job := range failed {
+			if err := r.retryPostInstallJob(ctx, em, job); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(running) > 0 {
+		logger.Debugf("Waiting for post-install jobs %v", running)
+		em.Status.MarkPostInstallRunning(running)
+		if previous == nil || previous.Reason != v1alpha1.PostInstallRunningReason {
+			utils.RecordEvent(ctx, em, reconciler.NewEvent(corev1.EventTypeNormal, "PostInstallStarted", "Started post-install jobs %s", strings.Join(running, ", ")))
+		}
+		return nil
+	}
+
+	em.Status.MarkPostInstallSucceeded()
+	if !previous.IsTrue() {
+		utils.RecordEvent(ctx, em, reconciler.NewEvent(corev1.EventTypeNormal, "PostInstallSucceeded", "Post-install jobs completed"))
+	}
+	return r.cleanupPostInstallJobs(ctx, em)
+}
+
+// retryPostInstallJob deletes the failed job once its backoff passed. The job gets created again by the next
+// reconcile, which is triggered by the deletion.
+func (r *Reconciler) retryPostInstallJob(ctx context.Context, em *v1alpha1.EventMesh, job *batchv1.Job) error {
+	failedAt := jobCondition(job, batchv1.JobFailed).LastTransitionTime.Time
+	if wait := time.Until(failedAt.Add(r.postInstallRetries.next(jobKey(job)))); wait > 0 {
+		r.enqueueAfter(em, wait)
+		return nil
+	}
+
+	logging.FromContext(ctx).Infof("Retrying failed post-install job %s", jobKey(job))
+	if err := r.deleteJob(ctx, job); err != nil {
+		return err
+	}
+	r.postInstallRetries.retried(jobKey(job))
+
+	return nil
+}
+
+// cleanupPostInstallJobs deletes the completed jobs of the EventMesh, once their retention period passed
+func (r *Reconciler) cleanupPostInstallJobs(ctx context.Context, em *v1alpha1.EventMesh) error {
+	jobs, err := r.jobLister.Jobs(system.Namespace()).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	for _, job := range jobs {
+		if !metav1.IsControlledBy(job, em) {
+			continue
+		}
+
+		completed := jobCondition(job, batchv1.JobComplete)
+		if completed == nil {
+			continue
+		}
+
+		completedAt := completed.LastTransitionTime.Time
+		if job.Status.CompletionTime != nil {
+			completedAt = job.Status.CompletionTime.Time
+		}
+		if wait := time.Until(completedAt.Add(postInstallJobRetention)); wait > 0 {
+			r.enqueueAfter(em, wait)
+			continue
+		}
+
+		logging.FromContext(ctx).Infof("Cleaning up completed post-install job %s", jobKey(job))
+		if err := r.deleteJob(ctx, job); err != nil {
+			return err
+		}
+		r.postInstallRetries.reset(jobKey(job))
+	}
+
+	return nil
+}
+
+func (r *Reconciler) deleteJob(ctx context.Context, job *batchv1.Job) error {
+	// the pods of the job are removed with it
+	propagation := metav1.DeletePropagationBackground
+	err := r.jobClient.Jobs(job.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete job %s: %w", jobKey(job), err)
+	}
+
+	return nil
+}
+
+// jobCondition returns the condition of the given type, if it is true, or nil otherwise
+func jobCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) *batchv1.JobCondition {
+	for i := range job.Status.Conditions {
+		if job.Status.Conditions[i].Type == conditionType && job.Status.Conditions[i].Status == corev1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
+func jobKey(job *batchv1.Job) string {
+	return job.Namespace + "/" + job.Name
+}
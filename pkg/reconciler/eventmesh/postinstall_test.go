@@ -0,0 +1,260 @@
+package eventmesh
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	mf "github.com/manifestival/manifestival"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	batchv1client "k8s.io/client-go/kubernetes/typed/batch/v1"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/eventmesh-operator/pkg/manifests"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/system"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: time.Minute},
+		{attempts: 1, want: 2 * time.Minute},
+		{attempts: 3, want: 8 * time.Minute},
+		{attempts: 10, want: postInstallRetryMaxDelay},
+	}
+
+	for _, tt := range tests {
+		if got := retryDelay(tt.attempts); got != tt.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestCheckPostInstall(t *testing.T) {
+	em := &v1alpha1.EventMesh{
+		ObjectMeta: metav1.ObjectMeta{Name: "eventmesh", UID: "eventmesh-uid"},
+	}
+	longAgo := metav1.NewTime(time.Now().Add(-2 * postInstallJobRetention))
+
+	tests := []struct {
+		name         string
+		status       func(*v1alpha1.EventMeshStatus)
+		jobs         []*batchv1.Job
+		postInstall  []string
+		wantStatus   corev1.ConditionStatus
+		wantReason   string
+		wantDeleted  []string
+		wantRequeued bool
+		wantEvents   []string
+	}{
+		{
+			name:       "no upgrade",
+			wantStatus: corev1.ConditionTrue,
+			wantReason: "PostInstallNotRequired",
+		},
+		{
+			name:        "job not created yet",
+			postInstall: []string{"migration"},
+			wantStatus:  corev1.ConditionUnknown,
+			wantReason:  v1alpha1.PostInstallRunningReason,
+			wantEvents:  []string{"Normal PostInstallStarted Started post-install jobs migration"},
+		},
+		{
+			name:        "job running",
+			jobs:        []*batchv1.Job{createJob(em, "migration", "", metav1.Now())},
+			postInstall: []string{"migration"},
+			wantStatus:  corev1.ConditionUnknown,
+			wantReason:  v1alpha1.PostInstallRunningReason,
+			wantEvents:  []string{"Normal PostInstallStarted Started post-install jobs migration"},
+		},
+		{
+			name: "job still running",
+			status: func(status *v1alpha1.EventMeshStatus) {
+				status.MarkPostInstallRunning([]string{"migration"})
+			},
+			jobs:        []*batchv1.Job{createJob(em, "migration", "", metav1.Now())},
+			postInstall: []string{"migration"},
+			wantStatus:  corev1.ConditionUnknown,
+			wantReason:  v1alpha1.PostInstallRunningReason,
+		},
+		{
+			name:         "job failed recently",
+			jobs:         []*batchv1.Job{createJob(em, "migration", batchv1.JobFailed, metav1.Now())},
+			postInstall:  []string{"migration"},
+			wantStatus:   corev1.ConditionFalse,
+			wantReason:   "BackoffLimitExceeded",
+			wantRequeued: true,
+			wantEvents:   []string{"Warning PostInstallFailed Post-install job migration failed: Job has reached the specified backoff limit"},
+		},
+		{
+			name:        "job failed before backoff",
+			jobs:        []*batchv1.Job{createJob(em, "migration", batchv1.JobFailed, longAgo)},
+			postInstall: []string{"migration"},
+			wantStatus:  corev1.ConditionFalse,
+			wantReason:  "BackoffLimitExceeded",
+			wantDeleted: []string{"migration"},
+			wantEvents:  []string{"Warning PostInstallFailed Post-install job migration failed: Job has reached the specified backoff limit"},
+		},
+		{
+			name: "jobs completed",
+			jobs: []*batchv1.Job{
+				createJob(em, "migration", batchv1.JobComplete, metav1.Now()),
+				createJob(em, "old-migration", batchv1.JobComplete, longAgo),
+			},
+			postInstall:  []string{"migration"},
+			wantStatus:   corev1.ConditionTrue,
+			wantDeleted:  []string{"old-migration"},
+			wantRequeued: true,
+			wantEvents:   []string{"Normal PostInstallSucceeded Post-install jobs completed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(context.Background(), recorder)
+			em := em.DeepCopy()
+			if tt.status != nil {
+				tt.status(&em.Status)
+			}
+
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, job := range tt.jobs {
+				_ = indexer.Add(job)
+			}
+			jobClient := &fakeJobClient{}
+
+			requeued := false
+			r := &Reconciler{
+				jobLister:          batchv1listers.NewJobLister(indexer),
+				jobClient:          jobClient,
+				postInstallRetries: newPostInstallRetries(),
+				enqueueAfter: func(interface{}, time.Duration) {
+					requeued = true
+				},
+			}
+
+			m := &manifests.Manifests{}
+			for _, name := range tt.postInstall {
+				u := &unstructured.Unstructured{}
+				u.SetAPIVersion("batch/v1")
+				u.SetKind("Job")
+				u.SetNamespace(system.Namespace())
+				u.SetName(name)
+				m.AddToPostInstall(mustManifest(t, u))
+			}
+
+			if err := r.checkPostInstall(ctx, m, em); err != nil {
+				t.Fatalf("checkPostInstall() = %v", err)
+			}
+
+			condition := em.Status.GetCondition(v1alpha1.EventMeshConditionPostInstallSucceeded)
+			if condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Errorf("condition = %s/%s, want %s/%s", condition.Status, condition.Reason, tt.wantStatus, tt.wantReason)
+			}
+
+			if requeued != tt.wantRequeued {
+				t.Errorf("requeued = %v, want %v", requeued, tt.wantRequeued)
+			}
+
+			if diff := cmp.Diff(tt.wantDeleted, jobClient.deleted); diff != "" {
+				t.Errorf("deleted jobs (-want, +got) = %v", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantEvents, recordedEvents(recorder)); diff != "" {
+				t.Errorf("events (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+// recordedEvents returns the events recorded so far
+func recordedEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func createJob(owner *v1alpha1.EventMesh, name string, conditionType batchv1.JobConditionType, transition metav1.Time) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: system.Namespace(),
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: v1alpha1.SchemeGroupVersion.String(),
+				Kind:       "EventMesh",
+				Name:       owner.Name,
+				UID:        types.UID(owner.UID),
+				Controller: ptr.To(true),
+			}},
+		},
+	}
+
+	switch conditionType {
+	case batchv1.JobFailed:
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type:               batchv1.JobFailed,
+			Status:             corev1.ConditionTrue,
+			Reason:             "BackoffLimitExceeded",
+			Message:            "Job has reached the specified backoff limit",
+			LastTransitionTime: transition,
+		}}
+	case batchv1.JobComplete:
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type:               batchv1.JobComplete,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: transition,
+		}}
+		job.Status.CompletionTime = &transition
+	}
+
+	return job
+}
+
+func mustManifest(t *testing.T, resources ...*unstructured.Unstructured) mf.Manifest {
+	t.Helper()
+
+	slice := make([]unstructured.Unstructured, 0, len(resources))
+	for _, u := range resources {
+		slice = append(slice, *u)
+	}
+
+	manifest, err := mf.ManifestFrom(mf.Slice(slice))
+	if err != nil {
+		t.Fatalf("failed to create manifest: %v", err)
+	}
+
+	return manifest
+}
+
+// fakeJobClient records the deleted jobs
+type fakeJobClient struct {
+	batchv1client.JobInterface
+	deleted []string
+}
+
+func (c *fakeJobClient) Jobs(string) batchv1client.JobInterface {
+	return c
+}
+
+func (c *fakeJobClient) Delete(_ context.Context, name string, _ metav1.DeleteOptions) error {
+	c.deleted = append(c.deleted, name)
+	return nil
+}
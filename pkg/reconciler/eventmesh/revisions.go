@@ -0,0 +1,231 @@
+package eventmesh
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/eventmesh-operator/pkg/manifests"
+	"knative.dev/eventmesh-operator/pkg/scaler"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/system"
+)
+
+// errUnknownRevision is returned, when the revision requested for a rollback is not recorded
+var errUnknownRevision = errors.New("unknown revision")
+
+// revisionsConfigMapName returns the name of the ConfigMap in the system namespace, which keeps the specs of the
+// applied revisions
+func revisionsConfigMapName(em *v1alpha1.EventMesh) string {
+	return em.Name + "-revisions"
+}
+
+// specHash returns a short hash of the given spec
+func specHash(spec *v1alpha1.EventMeshSpec) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal spec: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16], nil
+}
+
+// rollbackSpec returns the spec of the revision requested via the rollback annotation or nil if no rollback is
+// requested
+func (r *Reconciler) rollbackSpec(em *v1alpha1.EventMesh) (*v1alpha1.EventMeshSpec, error) {
+	value, ok := em.Annotations[v1alpha1.RollbackToRevisionAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	revision, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: must be a revision number", errUnknownRevision, value)
+	}
+
+	cm, err := r.configMapLister.ConfigMaps(system.Namespace()).Get(revisionsConfigMapName(em))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w %d: no revisions recorded", errUnknownRevision, revision)
+		}
+		return nil, fmt.Errorf("failed to get revisions: %w", err)
+	}
+
+	data, ok := cm.Data[strconv.FormatInt(revision, 10)]
+	if !ok {
+		return nil, fmt.Errorf("%w %d: not in the revision history", errUnknownRevision, revision)
+	}
+
+	spec := &v1alpha1.EventMeshSpec{}
+	if err := json.Unmarshal([]byte(data), spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec of revision %d: %w", revision, err)
+	}
+
+	return spec, nil
+}
+
+// revisionOutcome returns the outcome of the reconcile with the given error and whether the outcome is known.
+// Transient errors (e.g. timeouts or informers, which are not synced yet) are retried, so they don't decide the outcome.
+func revisionOutcome(em *v1alpha1.EventMesh, err error) (string, bool) {
+	switch {
+	case err != nil && isTransientError(err):
+		return "", false
+	case err != nil:
+		return v1alpha1.RevisionOutcomeFailed, true
+	case em.Status.GetCondition(v1alpha1.EventMeshConditionDeploymentsAvailable).IsTrue():
+		return v1alpha1.RevisionOutcomeAvailable, true
+	default:
+		return v1alpha1.RevisionOutcomeProgressing, true
+	}
+}
+
+// isTransientError returns whether the error is likely to disappear on a retry
+func isTransientError(err error) bool {
+	return errors.Is(err, scaler.ErrNotSynced) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		apierrors.IsConflict(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsInternalError(err) ||
+		apierrors.IsUnexpectedServerError(err) ||
+		// kinds of CRDs, which were just applied, are not served yet
+		meta.IsNoMatchError(err) ||
+		utilnet.IsConnectionRefused(err) ||
+		utilnet.IsConnectionReset(err) ||
+		utilnet.IsProbableEOF(err)
+}
+
+// recordRevision records the applied spec and release in the revision history. Applying the latest revision again
+// only updates its outcome, applying a previous revision (e.g. on a rollback) moves it to the top. The history is
+// pruned to the revision history limit.
+func (r *Reconciler) recordRevision(ctx context.Context, em *v1alpha1.EventMesh, outcome string) error {
+	if em.Spec.GetRevisionHistoryLimit() == 0 {
+		em.Status.Revisions = nil
+		return nil
+	}
+
+	hash, err := specHash(&em.Spec)
+	if err != nil {
+		return err
+	}
+
+	version := em.Status.Version
+	if outcome == v1alpha1.RevisionOutcomeFailed {
+		// the installed version was not updated, so record the release, which should have been installed
+		if release, err := manifests.PlanRelease(em); err == nil {
+			version = release.Version()
+		}
+	}
+
+	revisions := em.Status.Revisions
+	if len(revisions) > 0 && revisions[0].SpecHash == hash && revisions[0].Version == version {
+		// only changes are recorded, so that recording doesn't trigger new reconciles
+		if revisions[0].Outcome != outcome {
+			revisions[0].Outcome = outcome
+			revisions[0].Timestamp = metav1.Now()
+		}
+		return nil
+	}
+
+	revision := v1alpha1.Revision{
+		Revision:  nextRevision(revisions),
+		SpecHash:  hash,
+		Version:   version,
+		Timestamp: metav1.Now(),
+		Outcome:   outcome,
+	}
+	if i := slices.IndexFunc(revisions, func(rev v1alpha1.Revision) bool {
+		return rev.SpecHash == hash && rev.Version == version
+	}); i >= 0 {
+		revision.Revision = revisions[i].Revision
+		revisions = slices.Delete(slices.Clone(revisions), i, i+1)
+	}
+
+	revisions = append([]v1alpha1.Revision{revision}, revisions...)
+	if limit := em.Spec.GetRevisionHistoryLimit(); len(revisions) > limit {
+		revisions = revisions[:limit]
+	}
+	em.Status.Revisions = revisions
+
+	logging.FromContext(ctx).Infof("Recorded revision %d (version %s, outcome %s)", revision.Revision, version, outcome)
+
+	return r.storeRevisionSpecs(ctx, em, revision.Revision)
+}
+
+// storeRevisionSpecs stores the spec of the EventMesh as the given revision and removes the specs of the revisions,
+// which are not in the history anymore
+func (r *Reconciler) storeRevisionSpecs(ctx context.Context, em *v1alpha1.EventMesh, revision int64) error {
+	spec, err := json.Marshal(&em.Spec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec: %w", err)
+	}
+
+	existing, err := r.configMapLister.ConfigMaps(system.Namespace()).Get(revisionsConfigMapName(em))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get revisions: %w", err)
+	}
+
+	data := map[string]string{}
+	if existing != nil {
+		for _, rev := range em.Status.Revisions {
+			key := strconv.FormatInt(rev.Revision, 10)
+			if value, ok := existing.Data[key]; ok {
+				data[key] = value
+			}
+		}
+	}
+	data[strconv.FormatInt(revision, 10)] = string(spec)
+
+	if existing == nil {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            revisionsConfigMapName(em),
+				Namespace:       system.Namespace(),
+				OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(em)},
+			},
+			Data: data,
+		}
+		if _, err := r.configMapClient.ConfigMaps(cm.Namespace).Create(ctx, cm, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create revisions: %w", err)
+		}
+		return nil
+	}
+
+	if maps.Equal(existing.Data, data) {
+		return nil
+	}
+
+	cm := existing.DeepCopy()
+	cm.Data = data
+	if _, err := r.configMapClient.ConfigMaps(cm.Namespace).Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update revisions: %w", err)
+	}
+
+	return nil
+}
+
+// nextRevision returns the number of the next revision
+func nextRevision(revisions []v1alpha1.Revision) int64 {
+	var latest int64
+	for _, rev := range revisions {
+		latest = max(latest, rev.Revision)
+	}
+
+	return latest + 1
+}
@@ -0,0 +1,160 @@
+package eventmesh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/eventmesh-operator/pkg/scaler"
+)
+
+func TestRecordRevision(t *testing.T) {
+	ctx := context.Background()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	r := &Reconciler{
+		configMapLister: corev1listers.NewConfigMapLister(indexer),
+		configMapClient: &fakeConfigMapClient{indexer: indexer},
+	}
+
+	em := &v1alpha1.EventMesh{
+		ObjectMeta: metav1.ObjectMeta{Name: "eventmesh", UID: "eventmesh-uid"},
+		Spec: v1alpha1.EventMeshSpec{
+			LogLevel:             v1alpha1.LogLevelInfo,
+			RevisionHistoryLimit: ptr.To[int32](2),
+		},
+		Status: v1alpha1.EventMeshStatus{Version: "1.19.2"},
+	}
+
+	record := func(outcome string) {
+		t.Helper()
+		if err := r.recordRevision(ctx, em, outcome); err != nil {
+			t.Fatalf("recordRevision() = %v", err)
+		}
+	}
+	assertRevisions := func(want ...int64) {
+		t.Helper()
+		var got []int64
+		for _, rev := range em.Status.Revisions {
+			got = append(got, rev.Revision)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("revisions (-want, +got) = %v", diff)
+		}
+	}
+
+	record(v1alpha1.RevisionOutcomeProgressing)
+	record(v1alpha1.RevisionOutcomeAvailable)
+	assertRevisions(1)
+	if got := em.Status.Revisions[0].Outcome; got != v1alpha1.RevisionOutcomeAvailable {
+		t.Errorf("outcome = %s, want %s", got, v1alpha1.RevisionOutcomeAvailable)
+	}
+
+	em.Spec.LogLevel = v1alpha1.LogLevelDebug
+	record(v1alpha1.RevisionOutcomeAvailable)
+	assertRevisions(2, 1)
+
+	// rolling back moves the previous revision to the top
+	rollback, err := r.rollbackSpec(withRollbackAnnotation(em, "1"))
+	if err != nil {
+		t.Fatalf("rollbackSpec() = %v", err)
+	}
+	if rollback.LogLevel != v1alpha1.LogLevelInfo {
+		t.Errorf("rollbackSpec() log level = %s, want %s", rollback.LogLevel, v1alpha1.LogLevelInfo)
+	}
+	em.Spec = *rollback
+	record(v1alpha1.RevisionOutcomeAvailable)
+	assertRevisions(1, 2)
+
+	// the history is pruned to the limit
+	em.Spec.LogLevel = v1alpha1.LogLevelWarn
+	record(v1alpha1.RevisionOutcomeFailed)
+	assertRevisions(3, 1)
+
+	if _, err := r.rollbackSpec(withRollbackAnnotation(em, "2")); !errors.Is(err, errUnknownRevision) {
+		t.Errorf("rollbackSpec() of pruned revision = %v, want %v", err, errUnknownRevision)
+	}
+	if _, err := r.rollbackSpec(withRollbackAnnotation(em, "latest")); !errors.Is(err, errUnknownRevision) {
+		t.Errorf("rollbackSpec() of invalid revision = %v, want %v", err, errUnknownRevision)
+	}
+}
+
+func withRollbackAnnotation(em *v1alpha1.EventMesh, revision string) *v1alpha1.EventMesh {
+	em = em.DeepCopy()
+	em.Annotations = map[string]string{v1alpha1.RollbackToRevisionAnnotation: revision}
+	return em
+}
+
+// fakeConfigMapClient writes the ConfigMaps to the indexer of the lister
+type fakeConfigMapClient struct {
+	corev1client.ConfigMapInterface
+	indexer cache.Indexer
+}
+
+func (c *fakeConfigMapClient) ConfigMaps(string) corev1client.ConfigMapInterface {
+	return c
+}
+
+func (c *fakeConfigMapClient) Create(_ context.Context, cm *corev1.ConfigMap, _ metav1.CreateOptions) (*corev1.ConfigMap, error) {
+	return cm, c.indexer.Add(cm)
+}
+
+func (c *fakeConfigMapClient) Update(_ context.Context, cm *corev1.ConfigMap, _ metav1.UpdateOptions) (*corev1.ConfigMap, error) {
+	return cm, c.indexer.Update(cm)
+}
+
+func TestRevisionOutcome(t *testing.T) {
+	available := &v1alpha1.EventMesh{}
+	available.Status.MarkDeploymentsAvailable()
+
+	tests := []struct {
+		name    string
+		em      *v1alpha1.EventMesh
+		err     error
+		want    string
+		wantSet bool
+	}{{
+		name:    "available",
+		em:      available,
+		want:    v1alpha1.RevisionOutcomeAvailable,
+		wantSet: true,
+	}, {
+		name:    "progressing",
+		em:      &v1alpha1.EventMesh{},
+		want:    v1alpha1.RevisionOutcomeProgressing,
+		wantSet: true,
+	}, {
+		name:    "invalid resource",
+		em:      available,
+		err:     fmt.Errorf("failed to apply manifests: %w", apierrors.NewInvalid(schema.GroupKind{Kind: "Deployment"}, "kafka-controller", nil)),
+		want:    v1alpha1.RevisionOutcomeFailed,
+		wantSet: true,
+	}, {
+		name: "timeout",
+		em:   available,
+		err:  fmt.Errorf("failed to apply manifests: %w", apierrors.NewServerTimeout(schema.GroupResource{Resource: "deployments"}, "apply", 1)),
+	}, {
+		name: "informer not synced",
+		em:   available,
+		err:  fmt.Errorf("failed to count brokers: %w", scaler.ErrNotSynced),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := revisionOutcome(tt.em, tt.err)
+			if got != tt.want || ok != tt.wantSet {
+				t.Errorf("revisionOutcome() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantSet)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package eventmesh
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/eventmesh-operator/pkg/manifests"
+	"knative.dev/eventmesh-operator/pkg/scaler"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/system"
+)
+
+func TestApplyScaling(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	ctx := controller.WithEventRecorder(context.Background(), recorder)
+
+	workloads := []*unstructured.Unstructured{
+		workload("Deployment", "imc-dispatcher"),
+		workload("Deployment", "kafka-broker-receiver"),
+		workload("StatefulSet", "kafka-broker-dispatcher"),
+		workload("Deployment", "kafka-channel-receiver"),
+		workload("StatefulSet", "kafka-channel-dispatcher"),
+		workload("Deployment", "kafka-sink-receiver"),
+		workload("StatefulSet", "kafka-source-dispatcher"),
+	}
+	m := &manifests.Manifests{}
+	m.AddToApply(mustManifest(t, workloads...))
+
+	em := &v1alpha1.EventMesh{
+		Spec: v1alpha1.EventMeshSpec{
+			Scaling: &v1alpha1.EventMeshSpecScaling{ObjectsPerReplica: ptr.To[int32](100)},
+			Overrides: &v1alpha1.EventMeshSpecOverrides{
+				Workloads: v1alpha1.WorkloadOverrides{{Name: "kafka-sink-receiver", Replicas: ptr.To[int32](2)}},
+			},
+		},
+	}
+
+	statefulSets := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	_ = statefulSets.Add(&appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-source-dispatcher", Namespace: system.Namespace()},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](1)},
+	})
+	listers := WorkloadListers{
+		Deployments:  appsv1listers.NewDeploymentLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+		StatefulSets: appsv1listers.NewStatefulSetLister(statefulSets),
+	}
+
+	targets := staticScaleTargets{scaler.ComponentIMC: 250, scaler.ComponentKafkaBroker: 1}
+	if err := ApplyScaling(targets, listers, func(interface{}, time.Duration) {
+		t.Error("enqueueAfter() called without delayed scale down")
+	})(ctx, m, em); err != nil {
+		t.Fatalf("ApplyScaling() = %v", err)
+	}
+	if err := m.TransformToApply(); err != nil {
+		t.Fatalf("TransformToApply() = %v", err)
+	}
+
+	got := map[string]any{}
+	for _, u := range m.ToApply.Resources() {
+		replicas, found, _ := unstructured.NestedInt64(u.Object, "spec", "replicas")
+		if found {
+			got[u.GetName()] = replicas
+		} else {
+			got[u.GetName()] = nil
+		}
+	}
+
+	want := map[string]any{
+		// scaled by the scaling policy
+		"imc-dispatcher": int64(3),
+		// in use, so the replicas are not set
+		"kafka-broker-receiver":   nil,
+		"kafka-broker-dispatcher": nil,
+		// unused
+		"kafka-channel-receiver":   int64(0),
+		"kafka-channel-dispatcher": int64(0),
+		"kafka-source-dispatcher":  int64(0),
+		// unused, but the replicas are overridden
+		"kafka-sink-receiver": nil,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("replicas (-want, +got) = %v", diff)
+	}
+
+	wantEvents := []string{"Normal Scaled Scaled kafka-source-dispatcher to 0, no KafkaSources"}
+	if diff := cmp.Diff(wantEvents, recordedEvents(recorder)); diff != "" {
+		t.Errorf("events (-want, +got) = %v", diff)
+	}
+}
+
+func TestApplyScalingNotSynced(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	ctx := controller.WithEventRecorder(context.Background(), recorder)
+
+	hpa := func(name string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("autoscaling/v2")
+		u.SetKind("HorizontalPodAutoscaler")
+		u.SetNamespace(system.Namespace())
+		u.SetName(name)
+		return u
+	}
+	m := &manifests.Manifests{}
+	m.AddToApply(mustManifest(t,
+		workload("Deployment", "imc-dispatcher"),
+		workload("Deployment", "imc-controller"),
+		hpa("broker-filter-hpa"),
+		workload("Deployment", "mt-broker-filter"),
+		hpa("broker-ingress-hpa"),
+		workload("Deployment", "mt-broker-ingress"),
+		workload("Deployment", "kafka-channel-receiver"),
+		workload("StatefulSet", "kafka-source-dispatcher"),
+	))
+
+	deployments := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for name, replicas := range map[string]int32{"imc-dispatcher": 3, "mt-broker-filter": 2, "mt-broker-ingress": 0, "kafka-channel-receiver": 2} {
+		_ = deployments.Add(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: system.Namespace()},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(replicas)},
+		})
+	}
+	statefulSets := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	_ = statefulSets.Add(&appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-source-dispatcher", Namespace: system.Namespace()},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](0)},
+	})
+	listers := WorkloadListers{
+		Deployments:  appsv1listers.NewDeploymentLister(deployments),
+		StatefulSets: appsv1listers.NewStatefulSetLister(statefulSets),
+	}
+
+	if err := ApplyScaling(notSyncedScaleTargets{}, listers, func(interface{}, time.Duration) {
+		t.Error("enqueueAfter() called without delayed scale down")
+	})(ctx, m, &v1alpha1.EventMesh{}); err != nil {
+		t.Fatalf("ApplyScaling() = %v", err)
+	}
+	if err := m.TransformToApply(); err != nil {
+		t.Fatalf("TransformToApply() = %v", err)
+	}
+
+	got := map[string]any{}
+	for _, u := range m.ToApply.Resources() {
+		replicas, found, _ := unstructured.NestedInt64(u.Object, "spec", "replicas")
+		if found {
+			got[u.GetName()] = replicas
+		} else {
+			got[u.GetName()] = nil
+		}
+	}
+
+	want := map[string]any{
+		// the current replicas are kept
+		"imc-dispatcher":          int64(3),
+		"kafka-source-dispatcher": int64(0),
+		// not installed yet
+		"imc-controller": nil,
+		// scaled by its HPA
+		"broker-filter-hpa": nil,
+		"mt-broker-filter":  nil,
+		// scaled to zero, so its HPA is kept deleted
+		"mt-broker-ingress": int64(0),
+		// in use, so the replicas are not set
+		"kafka-channel-receiver": nil,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("replicas (-want, +got) = %v", diff)
+	}
+
+	if events := recordedEvents(recorder); len(events) > 0 {
+		t.Errorf("got events %v, want none", events)
+	}
+}
+
+func TestRuleReplicas(t *testing.T) {
+	deployments := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for name, replicas := range map[string]int32{"mt-broker-filter": 4, "mt-broker-ingress": 2} {
+		_ = deployments.Add(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: system.Namespace()},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(replicas)},
+		})
+	}
+	listers := WorkloadListers{
+		Deployments:  appsv1listers.NewDeploymentLister(deployments),
+		StatefulSets: appsv1listers.NewStatefulSetLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+	}
+	logger := logging.FromContext(context.Background())
+
+	mtBroker := &scaler.Rule{
+		HPAs: []scaler.HPA{
+			{Name: "broker-filter-hpa", Deployment: "mt-broker-filter"},
+			{Name: "broker-ingress-hpa", Deployment: "mt-broker-ingress"},
+		},
+		Deployments: []string{"mt-broker-controller"},
+	}
+	if replicas, installed := ruleReplicas(listers, mtBroker, logger); replicas != 4 || !installed {
+		t.Errorf("ruleReplicas() = %d, %v, want the highest replicas 4, true", replicas, installed)
+	}
+
+	kafkaSource := &scaler.Rule{StatefulSets: []string{"kafka-source-dispatcher"}}
+	if _, installed := ruleReplicas(listers, kafkaSource, logger); installed {
+		t.Error("ruleReplicas() = installed, want false for workloads, which are not installed")
+	}
+}
+
+// notSyncedScaleTargets fails to return scale targets, as the informers are not synced
+type notSyncedScaleTargets struct{}
+
+func (notSyncedScaleTargets) ScaleTarget(*v1alpha1.EventMesh, *scaler.Rule, func() (int64, bool)) (int64, time.Duration, error) {
+	return 0, 0, scaler.ErrNotSynced
+}
+
+func (notSyncedScaleTargets) ScaleApplied(*v1alpha1.EventMesh) {}
+
+// staticScaleTargets returns the scale targets for the given number of objects per component
+type staticScaleTargets map[string]int
+
+func (s staticScaleTargets) ScaleTarget(em *v1alpha1.EventMesh, rule *scaler.Rule, _ func() (int64, bool)) (int64, time.Duration, error) {
+	return rule.ScaleTargetFor(em.Spec.Scaling, s[rule.Component]), 0, nil
+}
+
+func (staticScaleTargets) ScaleApplied(*v1alpha1.EventMesh) {}
+
+func workload(kind, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("apps/v1")
+	u.SetKind(kind)
+	u.SetNamespace(system.Namespace())
+	u.SetName(name)
+	return u
+}
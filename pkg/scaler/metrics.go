@@ -0,0 +1,44 @@
+package scaler
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"knative.dev/pkg/observability/attributekey"
+)
+
+const scopeName = "knative.dev/eventmesh-operator/pkg/scaler"
+
+// ComponentAttr is the scaled component
+var ComponentAttr = attributekey.String("kn.eventmesh.scaler.component")
+
+type metrics struct {
+	scaleTarget metric.Int64Gauge
+}
+
+var scalerMetrics = newMetrics(otel.GetMeterProvider())
+
+func newMetrics(provider metric.MeterProvider) *metrics {
+	var (
+		m   metrics
+		err error
+	)
+
+	meter := provider.Meter(scopeName)
+
+	m.scaleTarget, err = meter.Int64Gauge(
+		"kn.eventmesh.scaler.scale_target",
+		metric.WithDescription("The number of replicas the component is scaled to."),
+		metric.WithUnit("{replica}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return &m
+}
+
+func (m *metrics) recordScaleTarget(component string, target int64) {
+	m.scaleTarget.Record(context.Background(), target, metric.WithAttributes(ComponentAttr.With(component)))
+}
@@ -0,0 +1,284 @@
+package scaler
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/eventing/pkg/apis/eventing"
+	v1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/eventmesh-operator/pkg/dynamicinformer"
+)
+
+// the scaled components
+const (
+	ComponentIMC          = "imc"
+	ComponentMTBroker     = "mt-broker"
+	ComponentKafkaBroker  = "kafka-broker"
+	ComponentKafkaChannel = "kafka-channel"
+	ComponentKafkaSink    = "kafka-sink"
+	ComponentKafkaSource  = "kafka-source"
+)
+
+const (
+	brokerCRDName       = "brokers.eventing.knative.dev"
+	imcCRDName          = "inmemorychannels.messaging.knative.dev"
+	kafkaChannelCRDName = "kafkachannels.messaging.knative.dev"
+	kafkaSinkCRDName    = "kafkasinks.eventing.knative.dev"
+	kafkaSourceCRDName  = "kafkasources.sources.knative.dev"
+	triggerCRDName      = "triggers.eventing.knative.dev"
+)
+
+// Rules are the scale rules of all scalable components. Rules, which count objects of the same CRD, share an informer.
+// The rules only count the objects and filter them by their annotations, so only the metadata of the objects is watched.
+var Rules = []*Rule{
+	{
+		Component:    ComponentIMC,
+		Objects:      "InMemoryChannels",
+		CRDName:      imcCRDName,
+		Informer:     InformerFor(dynamicinformer.ForMetadata()),
+		Enabled:      (*v1alpha1.EventMeshSpecComponents).IsInMemoryChannelEnabled,
+		Proportional: true,
+		// imc deployments are scaled directly as they are not managed via HPA
+		Deployments: []string{"imc-dispatcher", "imc-controller"},
+	},
+	{
+		Component:    ComponentMTBroker,
+		Objects:      "MTChannelBasedBrokers",
+		CRDName:      brokerCRDName,
+		Informer:     InformerFor(dynamicinformer.ForMetadata()),
+		Filter:       ObjectFilter(brokerClassFilter(eventing.MTChannelBrokerClassValue)),
+		Enabled:      (*v1alpha1.EventMeshSpecComponents).IsMTChannelBrokerEnabled,
+		Proportional: true,
+		HPAs: []HPA{
+			{Name: "broker-filter-hpa", Deployment: "mt-broker-filter"},
+			{Name: "broker-ingress-hpa", Deployment: "mt-broker-ingress"},
+		},
+		// mt-broker controller is not managed via HPA therefor scale deployment directly
+		Deployments: []string{"mt-broker-controller"},
+	},
+	{
+		// only brokers of the Kafka class use the shared data plane. The Kafka controller creates a data plane in the
+		// namespace of each KafkaNamespaced broker, so they don't keep the shared one scaled up.
+		Component:       ComponentKafkaBroker,
+		Objects:         "Kafka Brokers",
+		CRDName:         brokerCRDName,
+		Informer:        InformerFor(dynamicinformer.ForMetadata()),
+		Filter:          ObjectFilter(brokerClassFilter(v1alpha1.BrokerClassKafka)),
+		Enabled:         (*v1alpha1.EventMeshSpecComponents).IsKafkaBrokerEnabled,
+		ScaleToZeroOnly: true,
+		Deployments:     []string{"kafka-broker-receiver"},
+		StatefulSets:    []string{"kafka-broker-dispatcher"},
+	},
+	{
+		Component:       ComponentKafkaChannel,
+		Objects:         "KafkaChannels",
+		CRDName:         kafkaChannelCRDName,
+		Informer:        InformerFor(dynamicinformer.ForMetadata()),
+		Enabled:         (*v1alpha1.EventMeshSpecComponents).IsKafkaChannelEnabled,
+		ScaleToZeroOnly: true,
+		Deployments:     []string{"kafka-channel-receiver"},
+		StatefulSets:    []string{"kafka-channel-dispatcher"},
+	},
+	{
+		Component:       ComponentKafkaSink,
+		Objects:         "KafkaSinks",
+		CRDName:         kafkaSinkCRDName,
+		Informer:        InformerFor(dynamicinformer.ForMetadata()),
+		Enabled:         (*v1alpha1.EventMeshSpecComponents).IsKafkaSinkEnabled,
+		ScaleToZeroOnly: true,
+		Deployments:     []string{"kafka-sink-receiver"},
+	},
+	{
+		Component:       ComponentKafkaSource,
+		Objects:         "KafkaSources",
+		CRDName:         kafkaSourceCRDName,
+		Informer:        InformerFor(dynamicinformer.ForMetadata()),
+		Enabled:         (*v1alpha1.EventMeshSpecComponents).IsKafkaSourceEnabled,
+		ScaleToZeroOnly: true,
+		StatefulSets:    []string{"kafka-source-dispatcher"},
+	},
+}
+
+// InUse are the objects, which block the deletion of an EventMesh with the BlockIfInUse deletion policy. Objects of the
+// CRD of a rule share the informer of the rule.
+var InUse = []*InUseObjects{
+	{Objects: "Brokers", CRDName: brokerCRDName, Informer: InformerFor(dynamicinformer.ForMetadata())},
+	{Objects: "Triggers", CRDName: triggerCRDName, Informer: InformerFor(dynamicinformer.ForMetadata())},
+	{Objects: "InMemoryChannels", CRDName: imcCRDName, Informer: InformerFor(dynamicinformer.ForMetadata())},
+	{Objects: "KafkaChannels", CRDName: kafkaChannelCRDName, Informer: InformerFor(dynamicinformer.ForMetadata())},
+}
+
+// InUseObjects are the objects of a CRD, which are only counted to block the deletion of an EventMesh
+type InUseObjects struct {
+	// Objects describes the counted objects in the status
+	Objects string
+	// CRDName is the name of the CRD of the counted objects
+	CRDName string
+	// Informer creates the informer for the objects of the CRD, unless a rule of the same CRD created it already
+	Informer func(crdName string) ObjectInformer
+}
+
+// Rule declares how a component is scaled by the number of objects of a CRD
+type Rule struct {
+	// Component is the name of the scaled component, e.g. in metrics
+	Component string
+	// Objects describes the counted objects in logs and events
+	Objects string
+	// CRDName is the name of the CRD of the counted objects
+	CRDName string
+	// Informer creates the informer for the objects of the CRD. Only the informer of the first rule is created for
+	// rules of the same CRD.
+	Informer func(crdName string) ObjectInformer
+	// Filter lets only the objects pass, which are counted by the rule. All objects are counted, if it is not set.
+	Filter func(obj interface{}) bool
+	// Enabled returns whether the component is enabled
+	Enabled func(components *v1alpha1.EventMeshSpecComponents) bool
+
+	// Proportional defines whether the component is scaled according to the scaling policy. Otherwise, it is only
+	// scaled to zero or one replica.
+	Proportional bool
+	// ScaleToZeroOnly defines whether the replicas are only set to scale the component to zero. Otherwise, they are
+	// left to their defaults and their own autoscaling (e.g. of the Kafka controller).
+	ScaleToZeroOnly bool
+
+	// Deployments are the Deployments in the system namespace, which are scaled directly
+	Deployments []string
+	// StatefulSets are the StatefulSets in the system namespace, which are scaled directly
+	StatefulSets []string
+	// HPAs are the HPAs in the system namespace, whose Deployments are scaled via the HPA
+	HPAs []HPA
+}
+
+// HPA is a HorizontalPodAutoscaler and the Deployment it scales
+type HPA struct {
+	Name       string
+	Deployment string
+}
+
+// ScaleTargetFor returns the scale target of the component of the rule for the given number of objects
+func (r *Rule) ScaleTargetFor(scaling *v1alpha1.EventMeshSpecScaling, objects int) int64 {
+	if !r.Proportional {
+		scaling = nil
+	}
+
+	return ScaleTargetFor(scaling, objects)
+}
+
+// count returns the number of objects counted by the rule
+func (r *Rule) count(objs []interface{}) int {
+	if r.Filter == nil {
+		return len(objs)
+	}
+
+	var count int
+	for _, obj := range objs {
+		if r.Filter(obj) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// ObjectInformer watches the objects of a CRD, which are counted by scale rules. It is created via InformerFor.
+type ObjectInformer interface {
+	// list returns the objects of the informer or nil, if the informer is not synced
+	list() ([]interface{}, error)
+	// state returns the state of the informer and the error, which made it fail
+	state() (dynamicinformer.State, error)
+	// start starts the informer in the background, unless it is started already, and registers the event handler
+	// created by the given function. onSynced is called, when the informer is synced.
+	start(ctx context.Context, handlerFunc HandlerFunc, onSynced func())
+	// restart starts the informer again, e.g. when the versions of its CRD changed
+	restart(ctx context.Context, handlerFunc HandlerFunc, onSynced func())
+	// stop stops the informer
+	stop(ctx context.Context)
+}
+
+// HandlerFunc creates the event handler of an ObjectInformer from functions, which list the objects of the informer and
+// return, whether it is synced
+type HandlerFunc func(list func() ([]interface{}, error), hasSynced func() bool) cache.ResourceEventHandler
+
+// InformerFor returns a function, which creates an ObjectInformer with the given factory
+func InformerFor[T any, Lister dynamicinformer.SimpleLister[T]](factoryFunc dynamicinformer.FactoryFunc[T, Lister]) func(crdName string) ObjectInformer {
+	return func(crdName string) ObjectInformer {
+		return &objectInformer[T, Lister]{informer: dynamicinformer.New(crdName, factoryFunc)}
+	}
+}
+
+// ObjectFilter adapts a filter of typed objects to the Filter of a Rule
+func ObjectFilter[T any](filter func(obj *T) bool) func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		typed, ok := obj.(*T)
+		return ok && filter(typed)
+	}
+}
+
+// objectInformer adapts a DynamicInformer to the ObjectInformer interface
+type objectInformer[T any, Lister dynamicinformer.SimpleLister[T]] struct {
+	informer *dynamicinformer.DynamicInformer[T, Lister]
+}
+
+func (i *objectInformer[T, Lister]) list() ([]interface{}, error) {
+	lister := i.informer.Lister().Load()
+	if lister == nil || any(*lister) == nil {
+		// no lister registered so far (probably because the CRD is not installed yet)
+		return nil, nil
+	}
+
+	return listObjects[T](*lister)
+}
+
+func (i *objectInformer[T, Lister]) state() (dynamicinformer.State, error) {
+	return i.informer.State()
+}
+
+func (i *objectInformer[T, Lister]) start(ctx context.Context, handlerFunc HandlerFunc, onSynced func()) {
+	i.informer.Start(ctx, i.eventHandlerFunc(handlerFunc), onSynced)
+}
+
+func (i *objectInformer[T, Lister]) restart(ctx context.Context, handlerFunc HandlerFunc, onSynced func()) {
+	i.informer.Restart(ctx, i.eventHandlerFunc(handlerFunc), onSynced)
+}
+
+func (i *objectInformer[T, Lister]) stop(ctx context.Context) {
+	i.informer.Stop(ctx)
+}
+
+func (i *objectInformer[T, Lister]) eventHandlerFunc(handlerFunc HandlerFunc) dynamicinformer.EventHandlerFunc[T, Lister] {
+	return func(ctx context.Context, informer dynamicinformer.Informer[Lister]) cache.ResourceEventHandler {
+		list := func() ([]interface{}, error) {
+			return listObjects[T](informer.Lister())
+		}
+		hasSynced := func() bool {
+			return informer.Informer() != nil && informer.Informer().HasSynced()
+		}
+
+		return handlerFunc(list, hasSynced)
+	}
+}
+
+// listObjects lists all objects of the lister
+func listObjects[T any](lister dynamicinformer.SimpleLister[T]) ([]interface{}, error) {
+	objs, err := lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]interface{}, 0, len(objs))
+	for _, obj := range objs {
+		ret = append(ret, obj)
+	}
+
+	return ret, nil
+}
+
+// brokerClassFilter returns a filter, which lets only brokers of the given class pass
+func brokerClassFilter(class string) func(*metav1.PartialObjectMetadata) bool {
+	return func(broker *metav1.PartialObjectMetadata) bool {
+		return broker.Annotations[v1.BrokerClassAnnotationKey] == class
+	}
+}
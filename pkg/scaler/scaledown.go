@@ -0,0 +1,99 @@
+package scaler
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// scaleDowns remembers per EventMesh and component, since when its scale target is below the applied one, to delay
+// scaling it down. The state is kept in memory only. After a restart, the current replicas of the workloads of a
+// component are taken as its applied scale target, so that the delay starts over instead of being skipped.
+type scaleDowns struct {
+	mu         sync.Mutex
+	components map[scaleDownKey]*scaleDown
+	now        func() time.Time
+}
+
+type scaleDownKey struct {
+	eventMesh types.NamespacedName
+	component string
+}
+
+type scaleDown struct {
+	// applied is the scale target, which was applied most recently
+	applied int64
+	// pending is the scale target returned by the latest reconcile. It becomes the applied one, once the reconcile
+	// applied it.
+	pending *int64
+	// since is the time the scale target dropped below the applied one or zero, if it didn't
+	since time.Time
+}
+
+func newScaleDowns() *scaleDowns {
+	return &scaleDowns{
+		components: map[scaleDownKey]*scaleDown{},
+		now:        time.Now,
+	}
+}
+
+// delay returns the scale target to apply for the component and the remaining time, after which the component gets
+// scaled down. Scale targets below the applied one are only applied after the delay passed. current returns the
+// replicas of the workloads of the component and false, if they are not installed. It is used as the applied scale
+// target, as long as none was applied yet. The replicas of components, which are only scaled to zero, are left to
+// their own autoscaling, so only scaling them to zero is delayed.
+func (d *scaleDowns) delay(key scaleDownKey, scaleTarget int64, delay time.Duration, scaleToZeroOnly bool, current func() (int64, bool)) (int64, time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state, ok := d.components[key]
+	if !ok {
+		applied, installed := current()
+		if !installed {
+			applied = scaleTarget
+		}
+		if scaleToZeroOnly && applied > 1 {
+			applied = 1
+		}
+		state = &scaleDown{applied: applied}
+		d.components[key] = state
+	}
+
+	if scaleTarget >= state.applied || delay <= 0 {
+		state.pending = &scaleTarget
+		state.since = time.Time{}
+		return scaleTarget, 0
+	}
+
+	now := d.now()
+	if state.since.IsZero() {
+		state.since = now
+	}
+
+	if remaining := state.since.Add(delay).Sub(now); remaining > 0 {
+		state.pending = nil
+		return state.applied, remaining
+	}
+
+	state.pending = &scaleTarget
+	return scaleTarget, 0
+}
+
+// applied records the pending scale targets of the components of the EventMesh as applied
+func (d *scaleDowns) applied(eventMesh types.NamespacedName) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for key, state := range d.components {
+		if key.eventMesh != eventMesh || state.pending == nil {
+			continue
+		}
+
+		if *state.pending != state.applied {
+			state.applied = *state.pending
+			state.since = time.Time{}
+		}
+		state.pending = nil
+	}
+}
@@ -2,118 +2,192 @@ package scaler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
 
 	"go.uber.org/zap"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-
-	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
-	"knative.dev/eventing/pkg/apis/eventing"
-	v1 "knative.dev/eventing/pkg/apis/eventing/v1"
-	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
-	eventinginformers "knative.dev/eventing/pkg/client/informers/externalversions"
-	eventingclient "knative.dev/eventing/pkg/client/injection/client"
-	eventingv1listers "knative.dev/eventing/pkg/client/listers/eventing/v1"
-	messagingv1listers "knative.dev/eventing/pkg/client/listers/messaging/v1"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
 	"knative.dev/eventmesh-operator/pkg/dynamicinformer"
-	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 )
 
-const (
-	brokerCRDName = "brokers.eventing.knative.dev"
-	imcCRDName    = "inmemorychannels.messaging.knative.dev"
-)
+// ErrNotSynced is returned by ScaleTarget, while the informer of the counted objects is started, but not synced yet. The
+// objects can't be counted then, so the components are neither scaled up nor down. The EventMesh is reconciled again,
+// once the informer is synced.
+var ErrNotSynced = errors.New("informer is not synced")
 
 type Scaler struct {
 	logger *zap.SugaredLogger
 
-	dynamicIMCInformer   *dynamicinformer.DynamicInformer[messagingv1.InMemoryChannel, messagingv1listers.InMemoryChannelLister]
-	dynamicBrokerInfomer *dynamicinformer.DynamicInformer[v1.Broker, eventingv1listers.BrokerLister]
+	// scaling is the scaling policy of the latest reconcile. The event handlers use it to trigger reconciles only,
+	// when the scale targets change.
+	scaling atomic.Pointer[v1alpha1.EventMeshSpecScaling]
+
+	rules []*Rule
+	// inUse are the objects, which block the deletion of an EventMesh
+	inUse []*InUseObjects
+	// informers are the informers of the CRDs of the rules and of the objects in use by CRD name
+	informers map[string]ObjectInformer
+	// scaleDowns delays scaling down the components
+	scaleDowns *scaleDowns
 }
 
 func New(ctx context.Context) *Scaler {
+	return newScaler(ctx, Rules, InUse)
+}
+
+func newScaler(ctx context.Context, rules []*Rule, inUse []*InUseObjects) *Scaler {
 	logger := logging.FromContext(ctx).With(zap.String("component", "scaler"))
 
-	dynamicIMCInformer := dynamicinformer.New(imcCRDName, func(ctx context.Context) (dynamicinformer.SharedInformerFactory, dynamicinformer.Informer[messagingv1listers.InMemoryChannelLister]) {
-		factory := eventinginformers.NewSharedInformerFactory(eventingclient.Get(ctx), controller.GetResyncPeriod(ctx))
+	informers := make(map[string]ObjectInformer, len(rules)+len(inUse))
+	for _, rule := range rules {
+		if _, ok := informers[rule.CRDName]; !ok {
+			informers[rule.CRDName] = rule.Informer(rule.CRDName)
+		}
+	}
+	for _, objects := range inUse {
+		if _, ok := informers[objects.CRDName]; !ok {
+			informers[objects.CRDName] = objects.Informer(objects.CRDName)
+		}
+	}
 
-		return factory, factory.Messaging().V1().InMemoryChannels()
-	})
+	return &Scaler{
+		logger:     logger,
+		rules:      rules,
+		inUse:      inUse,
+		informers:  informers,
+		scaleDowns: newScaleDowns(),
+	}
+}
 
-	dynamicBrokerInformer := dynamicinformer.New(brokerCRDName, func(ctx context.Context) (dynamicinformer.SharedInformerFactory, dynamicinformer.Informer[eventingv1listers.BrokerLister]) {
-		factory := eventinginformers.NewSharedInformerFactory(eventingclient.Get(ctx), controller.GetResyncPeriod(ctx))
+// ScaleTarget returns the scale target for the component of the given rule. Components of proportional rules are scaled
+// according to the scaling policy of the EventMesh. Scaling down is delayed by the scale down delay of the policy. In
+// this case, the remaining delay is returned as well. current returns the replicas of the workloads of the component,
+// from which scaling down is delayed, until a scale target was applied via ScaleApplied. Only scaling to zero is delayed
+// for components of ScaleToZeroOnly rules. ErrNotSynced is returned, while the objects can't be counted.
+func (s *Scaler) ScaleTarget(em *v1alpha1.EventMesh, rule *Rule, current func() (int64, bool)) (int64, time.Duration, error) {
+	scaling := em.Spec.Scaling
+	s.scaling.Store(scaling)
+
+	objs, err := s.list(rule.CRDName)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count %s: %w", rule.Objects, err)
+	}
 
-		return factory, factory.Eventing().V1().Brokers()
-	})
+	objects := rule.count(objs)
+	s.logger.Debugf("Found %d %s (%d objects in total)", objects, rule.Objects, len(objs))
 
-	return &Scaler{
-		logger:               logger,
-		dynamicIMCInformer:   dynamicIMCInformer,
-		dynamicBrokerInfomer: dynamicBrokerInformer,
+	key := scaleDownKey{eventMesh: types.NamespacedName{Namespace: em.Namespace, Name: em.Name}, component: rule.Component}
+	scaleTarget, scaleDownIn := s.scaleDowns.delay(key, rule.ScaleTargetFor(scaling, objects), scaling.GetScaleDownDelay(), rule.ScaleToZeroOnly, current)
+	if scaleDownIn > 0 {
+		s.logger.Debugf("Delaying to scale down %s components for %s", rule.Component, scaleDownIn)
 	}
-}
+	scalerMetrics.recordScaleTarget(rule.Component, scaleTarget)
 
-func (s *Scaler) IMCScaleTarget() (int64, error) {
-	var imcScaleTarget int64
+	return scaleTarget, scaleDownIn, nil
+}
 
-	imcLister := s.dynamicIMCInformer.Lister().Load()
+// ScaleApplied records the scale targets, which were returned for the EventMesh, as applied. It is called, once the
+// manifests were applied successfully.
+func (s *Scaler) ScaleApplied(em *v1alpha1.EventMesh) {
+	s.scaleDowns.applied(types.NamespacedName{Namespace: em.Namespace, Name: em.Name})
+}
 
-	if imcLister == nil || *imcLister == nil {
-		// no imc lister registered so far (probably because IMC CRD is installed yet)
-		imcScaleTarget = 0
-	} else {
-		imcs, err := (*imcLister).List(labels.Everything())
+// ObjectsInUse returns a description of the objects, which still exist in the cluster and block the deletion of an
+// EventMesh. ErrNotSynced is returned, while the objects can't be counted.
+func (s *Scaler) ObjectsInUse() ([]string, error) {
+	var inUse []string
+	for _, objects := range s.inUse {
+		objs, err := s.list(objects.CRDName)
 		if err != nil {
-			return 0, fmt.Errorf("failed to list all InMemoryChannels: %w", err)
+			return nil, fmt.Errorf("failed to count %s: %w", objects.Objects, err)
+		}
+		if len(objs) > 0 {
+			inUse = append(inUse, fmt.Sprintf("%d %s", len(objs), objects.Objects))
 		}
+	}
 
-		s.logger.Debugf("Found %d in-memory channels", len(imcs))
+	return inUse, nil
+}
 
-		if len(imcs) == 0 {
-			imcScaleTarget = 0
-		} else {
-			imcScaleTarget = 1
-		}
+// list returns the objects of the informer of the given CRD. ErrNotSynced is returned, while the informer is starting
+// or failed to start.
+func (s *Scaler) list(crdName string) ([]interface{}, error) {
+	informer, ok := s.informers[crdName]
+	if !ok {
+		return nil, fmt.Errorf("no informer for %s registered", crdName)
+	}
+
+	switch state, err := informer.state(); state {
+	case dynamicinformer.StateStarting:
+		return nil, ErrNotSynced
+	case dynamicinformer.StateFailed:
+		return nil, fmt.Errorf("%w: %w", ErrNotSynced, err)
 	}
 
-	return imcScaleTarget, nil
+	// no objects are listed, as long as the informer is not started (probably because the CRD is not installed yet)
+	return informer.list()
 }
 
-func (s *Scaler) MTBrokerScaleTarget() (int64, error) {
-	var mtBrokerScaleTarget int64
-	brokerLister := s.dynamicBrokerInfomer.Lister().Load()
+// ScaleTargetFor returns the scale target for components, which handle the given number of objects, according to the
+// scaling policy. Components are scaled to zero, when no objects exist, and to one replica otherwise, if no policy is
+// given.
+func ScaleTargetFor(scaling *v1alpha1.EventMeshSpecScaling, objects int) int64 {
+	if objects == 0 {
+		return 0
+	}
+	if scaling == nil {
+		return 1
+	}
 
-	if brokerLister == nil || *brokerLister == nil {
-		// no broker lister registered so far (probably because Broker CRD is installed yet)
-		mtBrokerScaleTarget = 0
-	} else {
-		brokers, err := (*brokerLister).List(labels.Everything())
-		if err != nil {
-			return 0, fmt.Errorf("failed to list all Brokers: %w", err)
-		}
+	scaleTarget := int64(1)
+	if scaling.MinReplicas != nil {
+		scaleTarget = int64(*scaling.MinReplicas)
+	}
+	if scaling.ObjectsPerReplica != nil && *scaling.ObjectsPerReplica > 0 {
+		perReplica := int64(*scaling.ObjectsPerReplica)
+		scaleTarget = max(scaleTarget, (int64(objects)+perReplica-1)/perReplica)
+	}
+	if scaling.MaxReplicas != nil {
+		scaleTarget = min(scaleTarget, int64(*scaling.MaxReplicas))
+	}
 
-		mtBrokers := make([]*v1.Broker, 0, len(brokers))
-		for _, broker := range brokers {
-			if broker.Annotations[v1.BrokerClassAnnotationKey] == eventing.MTChannelBrokerClassValue {
-				mtBrokers = append(mtBrokers, broker)
-			}
-		}
+	return scaleTarget
+}
 
-		s.logger.Debugf("Found %d mt brokers (%d brokers in total)", len(mtBrokers), len(brokers))
+// scaleTargetFor returns the scale target for the component of the rule according to the scaling policy of the latest
+// reconcile
+func (s *Scaler) scaleTargetFor(rule *Rule, objects int) int64 {
+	return rule.ScaleTargetFor(s.scaling.Load(), objects)
+}
 
-		if len(mtBrokers) == 0 {
-			mtBrokerScaleTarget = 0
-		} else {
-			mtBrokerScaleTarget = 1
+// rulesFor returns the rules, which count the objects of the given CRD
+func (s *Scaler) rulesFor(crdName string) []*Rule {
+	var rules []*Rule
+	for _, rule := range s.rules {
+		if rule.CRDName == crdName {
+			rules = append(rules, rule)
 		}
 	}
 
-	return mtBrokerScaleTarget, nil
+	return rules
 }
 
+// CRDEventHandler starts the informers of the CRDs of the rules, when the CRDs get created, restarts them, when the
+// served or stored versions of the CRDs change, and stops them, when the CRDs get deleted. The informers are started in
+// the background, so the handler doesn't block the CRD informer.
 func (s *Scaler) CRDEventHandler(ctx context.Context, globalResync func(interface{})) cache.ResourceEventHandler {
+	// the components are scaled by the counted objects, once the informer is synced
+	onSynced := func() {
+		globalResync(nil)
+	}
+
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			s.logger.Debug("OnAdd in CRDEventHandler")
@@ -124,121 +198,158 @@ func (s *Scaler) CRDEventHandler(ctx context.Context, globalResync func(interfac
 				return
 			}
 
-			// setup dynamic informer depending on CRD
-			var err error
-			switch crd.Name {
-			case brokerCRDName:
-				err = s.dynamicBrokerInfomer.SetupInformerAndRegisterEventHandler(ctx, handleOnlyOnScaleToZeroOrOneItemsHandler[v1.Broker, eventingv1listers.BrokerLister](globalResync, brokerClassFilter()))
-			case imcCRDName:
-				err = s.dynamicIMCInformer.SetupInformerAndRegisterEventHandler(ctx, handleOnlyOnScaleToZeroOrOneItemsHandler[messagingv1.InMemoryChannel, messagingv1listers.InMemoryChannelLister](globalResync, nil))
-			default:
+			informer, ok := s.informers[crd.Name]
+			if !ok {
+				// unrelated CRD
+				return
+			}
+
+			// start dynamic informer depending on CRD
+			informer.start(ctx, s.handlerFunc(ctx, globalResync, crd.Name), onSynced)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldCRD, ok := oldObj.(*apiextensionsv1.CustomResourceDefinition)
+			if !ok {
+				s.logger.Warn("Received unexpected object, ignoring update")
+				return
+			}
+			crd, ok := newObj.(*apiextensionsv1.CustomResourceDefinition)
+			if !ok {
+				s.logger.Warn("Received unexpected object, ignoring update")
+				return
+			}
+
+			informer, ok := s.informers[crd.Name]
+			if !ok {
 				// unrelated CRD
+				return
 			}
 
-			if err != nil {
-				logging.FromContext(ctx).Errorw("Failed to register dynamic informer for CRD", zap.String("crd", crd.Name), zap.Error(err))
+			if versionsChanged(oldCRD, crd) {
+				s.logger.Infow("Versions of CRD changed, restarting informer", zap.String("crd", crd.Name))
+				informer.restart(ctx, s.handlerFunc(ctx, globalResync, crd.Name), onSynced)
+				return
 			}
+
+			// retries to start an informer, which failed to start
+			informer.start(ctx, s.handlerFunc(ctx, globalResync, crd.Name), onSynced)
 		},
-		UpdateFunc: func(_, _ interface{}) {}, // ignore updates (we care only if the CRD was created or removed)
 		DeleteFunc: func(obj interface{}) {
 			s.logger.Debug("OnDelete in CRDEventHandler")
 
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+
 			crd, ok := obj.(*apiextensionsv1.CustomResourceDefinition)
 			if !ok {
 				s.logger.Warn("Received unexpected object, ignoring delete")
 				return
 			}
 
-			logging.FromContext(ctx).Debug("CRD is removed, stopping informer", zap.String("crd", crd.Name))
-
-			// stop dynamic informer depending on CRD
-			switch crd.Name {
-			case brokerCRDName:
-				s.dynamicBrokerInfomer.Stop(ctx)
-			case imcCRDName:
-				s.dynamicIMCInformer.Stop(ctx)
-			default:
+			informer, ok := s.informers[crd.Name]
+			if !ok {
 				// unrelated CRD
+				return
 			}
+
+			logging.FromContext(ctx).Debug("CRD is removed, stopping informer", zap.String("crd", crd.Name))
+			informer.stop(ctx)
 		},
 	}
 }
 
-// handleOnlyOnScaleToZeroOrOneItemsHandler only calls the resyncFunc, when the first resource gets created or
-// when the last resource gets deleted. This is helpful to trigger the resyncFunc only on meaningful updates for the
-// scaling (so not every time a resource (e.g. a Channel) gets created the whole EventMesh gets reconciled).
-// It also accepts an optional filter, which lets filter the objects. This can be helpful for example, when a resource
-// is handled by different controllers which need to scale up and down separately (for example the brokers where we
-// have the kafka or mt-channel broker controllers)
-// Simply said, it is an advanced FilteringResourceEventHandler.
-func handleOnlyOnScaleToZeroOrOneItemsHandler[T any, Lister dynamicinformer.SimpleLister[T]](resyncFunc func(obj interface{}), filterFunc func(changedObj *T, existingObj *T) bool) dynamicinformer.EventHandlerFunc[T, Lister] {
-	return func(ctx context.Context, informer dynamicinformer.Informer[Lister]) cache.ResourceEventHandler {
-		logger := logging.FromContext(ctx)
-		return cache.ResourceEventHandlerFuncs{
-			AddFunc: func(newObj interface{}) {
-				if informer.Informer() != nil && informer.Informer().HasSynced() {
-					objs, err := informer.Lister().List(labels.Everything())
-					if err != nil {
-						logger.Warn("Failed to list informer resources", zap.Error(err))
-						return
-					}
-
-					filteredObjs := objs
-					if filterFunc != nil {
-						filteredObjs = make([]*T, 0, len(objs))
-						for _, obj := range objs {
-							if filterFunc(newObj.(*T), obj) {
-								filteredObjs = append(filteredObjs, obj)
-							}
-						}
-					}
-
-					logger.Debugf("OnAdd with %d objects existing now (%d in total before filtering)", len(filteredObjs), len(objs))
-
-					if len(filteredObjs) == 1 {
-						// we only care, when the first object comes
-						resyncFunc(newObj)
-					} else {
-						logger.Debug("Skipping triggering a reconcile, as no meaningful change in number of occurrences")
-					}
-				}
-			},
-			UpdateFunc: func(old, new interface{}) {
-				// updates are ignored
-			},
-			DeleteFunc: func(deletedObj interface{}) {
-				objs, err := informer.Lister().List(labels.Everything())
-				if err != nil {
-					logger.Warn("Failed to list informer resources", zap.Error(err))
-					return
-				}
-
-				filteredObjs := objs
-				if filterFunc != nil {
-					filteredObjs = make([]*T, 0, len(objs))
-					for _, obj := range objs {
-						if filterFunc(deletedObj.(*T), obj) {
-							filteredObjs = append(filteredObjs, obj)
-						}
-					}
-				}
-
-				logger.Debugf("OnDelete with %d objects existing now (%d in total before filtering)", len(filteredObjs), len(objs))
-
-				if len(filteredObjs) == 0 {
-					// we only care, when the last one gets removed...
-					resyncFunc(deletedObj)
-				} else {
-					logger.Debug("Skipping triggering a reconcile, as no meaningful change in number of occurrences")
-				}
-			},
+// handlerFunc returns the function, which creates the event handler for the informer of the given CRD
+func (s *Scaler) handlerFunc(ctx context.Context, globalResync func(interface{}), crdName string) HandlerFunc {
+	rules := s.rulesFor(crdName)
+
+	return func(list func() ([]interface{}, error), hasSynced func() bool) cache.ResourceEventHandler {
+		return handleOnlyOnScaleTargetChangeHandler(ctx, globalResync, rules, list, hasSynced, s.scaleTargetFor)
+	}
+}
+
+// versionsChanged returns whether the served or the stored versions differ between the old and the new CRD. The
+// informer of a CRD watches one of its served versions, so it needs to be restarted then.
+func versionsChanged(oldCRD, newCRD *apiextensionsv1.CustomResourceDefinition) bool {
+	return !slices.Equal(servedVersions(oldCRD), servedVersions(newCRD)) ||
+		storageVersion(oldCRD) != storageVersion(newCRD) ||
+		!slices.Equal(oldCRD.Status.StoredVersions, newCRD.Status.StoredVersions)
+}
+
+func servedVersions(crd *apiextensionsv1.CustomResourceDefinition) []string {
+	var versions []string
+	for _, version := range crd.Spec.Versions {
+		if version.Served {
+			versions = append(versions, version.Name)
 		}
 	}
+
+	return versions
 }
 
-// brokerClassFilter returns a filter, which lets only objects pass, which have the same broker class as the changed broker
-func brokerClassFilter() func(*v1.Broker, *v1.Broker) bool {
-	return func(changedBroker, foundBroker *v1.Broker) bool {
-		return changedBroker.Annotations[v1.BrokerClassAnnotationKey] == foundBroker.Annotations[v1.BrokerClassAnnotationKey]
+func storageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			return version.Name
+		}
+	}
+
+	return ""
+}
+
+// handleOnlyOnScaleTargetChangeHandler only calls the resyncFunc, when the scale target of one of the rules, which count
+// the created or deleted object, changes. With the default scaling policy this is the case, when the first object gets
+// created or when the last object gets deleted. This is helpful to trigger the resyncFunc only on meaningful updates
+// for the scaling (so not every time a resource (e.g. a Channel) gets created the whole EventMesh gets reconciled).
+// The filters of the rules separate the objects, which are handled by different components and need to scale up and
+// down separately (for example the brokers where we have the kafka or mt-channel broker controllers).
+// Simply said, it is an advanced FilteringResourceEventHandler.
+func handleOnlyOnScaleTargetChangeHandler(ctx context.Context, resyncFunc func(obj interface{}), rules []*Rule, list func() ([]interface{}, error), hasSynced func() bool, scaleTargetFor func(rule *Rule, objects int) int64) cache.ResourceEventHandler {
+	logger := logging.FromContext(ctx)
+
+	// resyncOnScaleTargetChange calls the resyncFunc, if the scale target of a rule counting the changed object differs
+	// between the number of objects before the change and now
+	resyncOnScaleTargetChange := func(changedObj interface{}, change int) {
+		objs, err := list()
+		if err != nil {
+			logger.Warn("Failed to list informer resources", zap.Error(err))
+			return
+		}
+
+		for _, rule := range rules {
+			if rule.Filter != nil && !rule.Filter(changedObj) {
+				continue
+			}
+
+			objects := rule.count(objs)
+			logger.Debugf("%d %s existing now (%d objects in total)", objects, rule.Objects, len(objs))
+
+			if scaleTargetFor(rule, max(objects-change, 0)) != scaleTargetFor(rule, objects) {
+				resyncFunc(changedObj)
+				return
+			}
+		}
+
+		logger.Debug("Skipping triggering a reconcile, as no meaningful change in number of occurrences")
+	}
+
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(newObj interface{}) {
+			if hasSynced() {
+				logger.Debug("OnAdd")
+				resyncOnScaleTargetChange(newObj, 1)
+			}
+		},
+		UpdateFunc: func(old, new interface{}) {
+			// updates are ignored
+		},
+		DeleteFunc: func(deletedObj interface{}) {
+			if tombstone, ok := deletedObj.(cache.DeletedFinalStateUnknown); ok {
+				deletedObj = tombstone.Obj
+			}
+
+			logger.Debug("OnDelete")
+			resyncOnScaleTargetChange(deletedObj, -1)
+		},
 	}
 }
@@ -2,34 +2,42 @@ package scaler
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata/metadatalister"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
 	"knative.dev/eventing/pkg/apis/eventing"
 	v1 "knative.dev/eventing/pkg/apis/eventing/v1"
-	eventingfake "knative.dev/eventing/pkg/client/clientset/versioned/fake"
-	eventinginformers "knative.dev/eventing/pkg/client/informers/externalversions"
-	eventingv1listers "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/eventmesh-operator/pkg/dynamicinformer"
 	rtesting "knative.dev/pkg/reconciler/testing"
 )
 
-func TestHandleOnlyOnScaleToZeroOrOneItemsHandler(t *testing.T) {
+var brokerGVR = schema.GroupVersionResource{Group: "eventing.knative.dev", Version: "v1", Resource: "brokers"}
+
+func TestHandleOnlyOnScaleTargetChangeHandler(t *testing.T) {
 	tests := []struct {
 		name           string
-		initialObjects []*v1.Broker
-		addObjects     []*v1.Broker
-		deleteObjects  []*v1.Broker
+		initialObjects []*metav1.PartialObjectMetadata
+		addObjects     []*metav1.PartialObjectMetadata
+		deleteObjects  []*metav1.PartialObjectMetadata
+		scaling        *v1alpha1.EventMeshSpecScaling
 		expectResync   int
 		description    string
 	}{
 		{
 			name:           "add first broker triggers resync (0->1)",
-			initialObjects: []*v1.Broker{},
-			addObjects: []*v1.Broker{
+			initialObjects: []*metav1.PartialObjectMetadata{},
+			addObjects: []*metav1.PartialObjectMetadata{
 				{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "broker-1",
@@ -45,7 +53,7 @@ func TestHandleOnlyOnScaleToZeroOrOneItemsHandler(t *testing.T) {
 		},
 		{
 			name: "add second broker does not trigger resync (1->2)",
-			initialObjects: []*v1.Broker{
+			initialObjects: []*metav1.PartialObjectMetadata{
 				{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "existing-broker",
@@ -56,7 +64,7 @@ func TestHandleOnlyOnScaleToZeroOrOneItemsHandler(t *testing.T) {
 					},
 				},
 			},
-			addObjects: []*v1.Broker{
+			addObjects: []*metav1.PartialObjectMetadata{
 				{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "broker-2",
@@ -72,7 +80,7 @@ func TestHandleOnlyOnScaleToZeroOrOneItemsHandler(t *testing.T) {
 		},
 		{
 			name: "delete last broker triggers resync (1->0)",
-			initialObjects: []*v1.Broker{
+			initialObjects: []*metav1.PartialObjectMetadata{
 				{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "broker-to-delete",
@@ -83,7 +91,7 @@ func TestHandleOnlyOnScaleToZeroOrOneItemsHandler(t *testing.T) {
 					},
 				},
 			},
-			deleteObjects: []*v1.Broker{
+			deleteObjects: []*metav1.PartialObjectMetadata{
 				{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "broker-to-delete",
@@ -99,7 +107,7 @@ func TestHandleOnlyOnScaleToZeroOrOneItemsHandler(t *testing.T) {
 		},
 		{
 			name: "add different broker class triggers resync for new class (0->1 for Kafka)",
-			initialObjects: []*v1.Broker{
+			initialObjects: []*metav1.PartialObjectMetadata{
 				{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "mt-broker",
@@ -110,7 +118,7 @@ func TestHandleOnlyOnScaleToZeroOrOneItemsHandler(t *testing.T) {
 					},
 				},
 			},
-			addObjects: []*v1.Broker{
+			addObjects: []*metav1.PartialObjectMetadata{
 				{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "kafka-broker",
@@ -124,6 +132,38 @@ func TestHandleOnlyOnScaleToZeroOrOneItemsHandler(t *testing.T) {
 			expectResync: 1,
 			description:  "Adding first Kafka broker should trigger resync (different class from existing MT broker)",
 		},
+		{
+			name:           "add broker crossing the threshold triggers resync (1->2 replicas)",
+			initialObjects: []*metav1.PartialObjectMetadata{mtBroker("broker-1"), mtBroker("broker-2")},
+			addObjects:     []*metav1.PartialObjectMetadata{mtBroker("broker-3")},
+			scaling:        &v1alpha1.EventMeshSpecScaling{ObjectsPerReplica: ptr.To[int32](2)},
+			expectResync:   1,
+			description:    "Adding the third broker with two brokers per replica should trigger resync for scale-up",
+		},
+		{
+			name:           "add broker below the threshold does not trigger resync",
+			initialObjects: []*metav1.PartialObjectMetadata{mtBroker("broker-1")},
+			addObjects:     []*metav1.PartialObjectMetadata{mtBroker("broker-2")},
+			scaling:        &v1alpha1.EventMeshSpecScaling{ObjectsPerReplica: ptr.To[int32](2)},
+			expectResync:   0,
+			description:    "Adding the second broker with two brokers per replica should not trigger resync",
+		},
+		{
+			name:           "delete broker crossing the threshold triggers resync (2->1 replicas)",
+			initialObjects: []*metav1.PartialObjectMetadata{mtBroker("broker-1"), mtBroker("broker-2"), mtBroker("broker-3")},
+			deleteObjects:  []*metav1.PartialObjectMetadata{mtBroker("broker-3")},
+			scaling:        &v1alpha1.EventMeshSpecScaling{ObjectsPerReplica: ptr.To[int32](2)},
+			expectResync:   1,
+			description:    "Deleting the third broker with two brokers per replica should trigger resync for scale-down",
+		},
+		{
+			name:           "add broker above max replicas does not trigger resync",
+			initialObjects: []*metav1.PartialObjectMetadata{mtBroker("broker-1"), mtBroker("broker-2")},
+			addObjects:     []*metav1.PartialObjectMetadata{mtBroker("broker-3")},
+			scaling:        &v1alpha1.EventMeshSpecScaling{ObjectsPerReplica: ptr.To[int32](2), MaxReplicas: ptr.To[int32](1)},
+			expectResync:   0,
+			description:    "Adding the third broker should not trigger resync, when the components are scaled to max replicas already",
+		},
 	}
 
 	for _, tt := range tests {
@@ -138,43 +178,30 @@ func TestHandleOnlyOnScaleToZeroOrOneItemsHandler(t *testing.T) {
 				resyncCalled++
 			}
 
-			// Create fake clientset with initial objects
-			var initialRuntimeObjects []runtime.Object
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
 			for _, broker := range tt.initialObjects {
-				initialRuntimeObjects = append(initialRuntimeObjects, broker)
-			}
-			fakeClient := eventingfake.NewSimpleClientset(initialRuntimeObjects...)
-
-			// Create informer factory
-			informerFactory := eventinginformers.NewSharedInformerFactory(fakeClient, 0)
-			brokerInformer := informerFactory.Eventing().V1().Brokers()
-
-			// Create fake informer that implements the dynamicinformer.Informer interface
-			fakeBrokerInformer := &fakeInformerWrapper[eventingv1listers.BrokerLister]{
-				informer: brokerInformer.Informer(),
-				lister:   brokerInformer.Lister(),
+				if err := indexer.Add(broker); err != nil {
+					t.Fatalf("Failed to add broker to informer cache: %v", err)
+				}
 			}
 
-			// Create the handler using the actual function
-			handler := handleOnlyOnScaleToZeroOrOneItemsHandler[v1.Broker, eventingv1listers.BrokerLister](
+			// Create the handler for the broker rules using the actual function
+			handler := handleOnlyOnScaleTargetChangeHandler(
+				ctx,
 				resyncFunc,
-				brokerClassFilter(),
-			)(ctx, fakeBrokerInformer)
-
-			// Start informers and wait for cache sync
-			informerFactory.Start(ctx.Done())
-			informerFactory.WaitForCacheSync(ctx.Done())
+				newScaler(ctx, Rules, nil).rulesFor(brokerCRDName),
+				func() ([]interface{}, error) {
+					return listObjects[metav1.PartialObjectMetadata](metadatalister.New(indexer, brokerGVR))
+				},
+				func() bool { return true },
+				func(rule *Rule, objects int) int64 {
+					return rule.ScaleTargetFor(tt.scaling, objects)
+				},
+			)
 
 			// Handle add operations
 			for _, broker := range tt.addObjects {
-				// Add to fake clientset
-				_, err := fakeClient.EventingV1().Brokers(broker.Namespace).Create(ctx, broker, metav1.CreateOptions{})
-				if err != nil {
-					t.Fatalf("Failed to create broker: %v", err)
-				}
-				// Add to informer cache
-				err = brokerInformer.Informer().GetStore().Add(broker)
-				if err != nil {
+				if err := indexer.Add(broker); err != nil {
 					t.Fatalf("Failed to add broker to informer cache: %v", err)
 				}
 				handler.OnAdd(broker, false)
@@ -182,14 +209,7 @@ func TestHandleOnlyOnScaleToZeroOrOneItemsHandler(t *testing.T) {
 
 			// Handle delete operations
 			for _, broker := range tt.deleteObjects {
-				// Remove from fake clientset
-				err := fakeClient.EventingV1().Brokers(broker.Namespace).Delete(ctx, broker.Name, metav1.DeleteOptions{})
-				if err != nil {
-					t.Fatalf("Failed to delete broker: %v", err)
-				}
-				// Remove from informer cache
-				err = brokerInformer.Informer().GetStore().Delete(broker)
-				if err != nil {
+				if err := indexer.Delete(broker); err != nil {
 					t.Fatalf("Failed to delete broker from informer cache: %v", err)
 				}
 				handler.OnDelete(broker)
@@ -216,18 +236,17 @@ func TestHandleOnlyOnScaleToZeroOrOneItemsHandler(t *testing.T) {
 func TestBrokerClassFilterFunction(t *testing.T) {
 	ctx := context.Background()
 	_ = ctx // Used to satisfy import requirements
-	filter := brokerClassFilter()
 
 	tests := []struct {
 		name           string
-		changedBroker  *v1.Broker
-		existingBroker *v1.Broker
+		changedBroker  *metav1.PartialObjectMetadata
+		existingBroker *metav1.PartialObjectMetadata
 		expectMatch    bool
 		description    string
 	}{
 		{
 			name: "same MT broker class should match",
-			changedBroker: &v1.Broker{
+			changedBroker: &metav1.PartialObjectMetadata{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "mt-broker-new",
 					Annotations: map[string]string{
@@ -235,7 +254,7 @@ func TestBrokerClassFilterFunction(t *testing.T) {
 					},
 				},
 			},
-			existingBroker: &v1.Broker{
+			existingBroker: &metav1.PartialObjectMetadata{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "mt-broker-existing",
 					Annotations: map[string]string{
@@ -248,7 +267,7 @@ func TestBrokerClassFilterFunction(t *testing.T) {
 		},
 		{
 			name: "different broker classes should not match",
-			changedBroker: &v1.Broker{
+			changedBroker: &metav1.PartialObjectMetadata{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "mt-broker",
 					Annotations: map[string]string{
@@ -256,7 +275,7 @@ func TestBrokerClassFilterFunction(t *testing.T) {
 					},
 				},
 			},
-			existingBroker: &v1.Broker{
+			existingBroker: &metav1.PartialObjectMetadata{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "kafka-broker",
 					Annotations: map[string]string{
@@ -269,7 +288,7 @@ func TestBrokerClassFilterFunction(t *testing.T) {
 		},
 		{
 			name: "same Kafka broker class should match",
-			changedBroker: &v1.Broker{
+			changedBroker: &metav1.PartialObjectMetadata{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "kafka-broker-new",
 					Annotations: map[string]string{
@@ -277,7 +296,7 @@ func TestBrokerClassFilterFunction(t *testing.T) {
 					},
 				},
 			},
-			existingBroker: &v1.Broker{
+			existingBroker: &metav1.PartialObjectMetadata{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "kafka-broker-existing",
 					Annotations: map[string]string{
@@ -288,9 +307,30 @@ func TestBrokerClassFilterFunction(t *testing.T) {
 			expectMatch: true,
 			description: "Kafka brokers should be grouped together for scaling decisions",
 		},
+		{
+			name: "namespaced Kafka broker class should not match",
+			changedBroker: &metav1.PartialObjectMetadata{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "kafka-broker",
+					Annotations: map[string]string{
+						v1.BrokerClassAnnotationKey: "Kafka",
+					},
+				},
+			},
+			existingBroker: &metav1.PartialObjectMetadata{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "kafka-namespaced-broker",
+					Annotations: map[string]string{
+						v1.BrokerClassAnnotationKey: "KafkaNamespaced",
+					},
+				},
+			},
+			expectMatch: false,
+			description: "KafkaNamespaced brokers run their own data plane and should not scale the shared one",
+		},
 		{
 			name: "missing annotation on existing broker should not match",
-			changedBroker: &v1.Broker{
+			changedBroker: &metav1.PartialObjectMetadata{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "mt-broker",
 					Annotations: map[string]string{
@@ -298,7 +338,7 @@ func TestBrokerClassFilterFunction(t *testing.T) {
 					},
 				},
 			},
-			existingBroker: &v1.Broker{
+			existingBroker: &metav1.PartialObjectMetadata{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        "no-class-broker",
 					Annotations: map[string]string{},
@@ -309,13 +349,13 @@ func TestBrokerClassFilterFunction(t *testing.T) {
 		},
 		{
 			name: "missing annotation on changed broker should not match",
-			changedBroker: &v1.Broker{
+			changedBroker: &metav1.PartialObjectMetadata{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        "no-class-broker",
 					Annotations: map[string]string{},
 				},
 			},
-			existingBroker: &v1.Broker{
+			existingBroker: &metav1.PartialObjectMetadata{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "mt-broker",
 					Annotations: map[string]string{
@@ -330,7 +370,8 @@ func TestBrokerClassFilterFunction(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := filter(tt.changedBroker, tt.existingBroker)
+			filter := brokerClassFilter(tt.changedBroker.Annotations[v1.BrokerClassAnnotationKey])
+			result := filter(tt.existingBroker)
 			if result != tt.expectMatch {
 				t.Errorf("Test: %s\nDescription: %s\nExpected match=%v, got match=%v",
 					tt.name, tt.description, tt.expectMatch, result)
@@ -341,16 +382,418 @@ func TestBrokerClassFilterFunction(t *testing.T) {
 	}
 }
 
-// fakeInformerWrapper wraps a informer to implement dynamicinformer.Informer interface
-type fakeInformerWrapper[L any] struct {
-	informer cache.SharedIndexInformer
-	lister   L
+func TestKafkaBrokerRuleCount(t *testing.T) {
+	broker := func(class string) interface{} {
+		return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{v1.BrokerClassAnnotationKey: class},
+		}}
+	}
+
+	idx := slices.IndexFunc(Rules, func(r *Rule) bool { return r.Component == ComponentKafkaBroker })
+	if idx < 0 {
+		t.Fatalf("no rule for %s", ComponentKafkaBroker)
+	}
+
+	// KafkaNamespaced brokers don't use the shared data plane, so only the Kafka broker is counted
+	objs := []interface{}{broker("Kafka"), broker("KafkaNamespaced"), broker("KafkaNamespaced"), broker(eventing.MTChannelBrokerClassValue)}
+	if got := Rules[idx].count(objs); got != 1 {
+		t.Errorf("count() = %d, want 1", got)
+	}
+	if got := Rules[idx].count(objs[1:3]); got != 0 {
+		t.Errorf("count() of KafkaNamespaced brokers = %d, want 0", got)
+	}
+}
+
+func TestScaleTargetFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		scaling *v1alpha1.EventMeshSpecScaling
+		objects int
+		want    int64
+	}{
+		{
+			name:    "no objects",
+			scaling: &v1alpha1.EventMeshSpecScaling{MinReplicas: ptr.To[int32](2)},
+			objects: 0,
+			want:    0,
+		},
+		{
+			name:    "default policy",
+			objects: 500,
+			want:    1,
+		},
+		{
+			name:    "min replicas",
+			scaling: &v1alpha1.EventMeshSpecScaling{MinReplicas: ptr.To[int32](2)},
+			objects: 1,
+			want:    2,
+		},
+		{
+			name:    "objects per replica",
+			scaling: &v1alpha1.EventMeshSpecScaling{ObjectsPerReplica: ptr.To[int32](100)},
+			objects: 201,
+			want:    3,
+		},
+		{
+			name:    "objects per replica below min replicas",
+			scaling: &v1alpha1.EventMeshSpecScaling{ObjectsPerReplica: ptr.To[int32](100), MinReplicas: ptr.To[int32](2)},
+			objects: 50,
+			want:    2,
+		},
+		{
+			name:    "objects per replica above max replicas",
+			scaling: &v1alpha1.EventMeshSpecScaling{ObjectsPerReplica: ptr.To[int32](100), MaxReplicas: ptr.To[int32](4)},
+			objects: 1000,
+			want:    4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScaleTargetFor(tt.scaling, tt.objects); got != tt.want {
+				t.Errorf("ScaleTargetFor() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func mtBroker(name string) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Annotations: map[string]string{
+				v1.BrokerClassAnnotationKey: eventing.MTChannelBrokerClassValue,
+			},
+		},
+	}
+}
+
+func TestScaleTarget(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+
+	var created int
+	informer := &fakeObjectInformer{objs: []interface{}{
+		mtBroker("broker-1"),
+		mtBroker("broker-2"),
+		mtBroker("broker-3"),
+		&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "kafka-broker", Annotations: map[string]string{v1.BrokerClassAnnotationKey: v1alpha1.BrokerClassKafka}}},
+	}}
+	newInformer := func(string) ObjectInformer {
+		created++
+		return informer
+	}
+
+	mtBrokerRule := &Rule{Component: ComponentMTBroker, CRDName: brokerCRDName, Informer: newInformer, Filter: ObjectFilter(brokerClassFilter(eventing.MTChannelBrokerClassValue)), Proportional: true}
+	kafkaBrokerRule := &Rule{Component: ComponentKafkaBroker, CRDName: brokerCRDName, Informer: newInformer, Filter: ObjectFilter(brokerClassFilter(v1alpha1.BrokerClassKafka))}
+	s := newScaler(ctx, []*Rule{mtBrokerRule, kafkaBrokerRule}, nil)
+
+	if created != 1 {
+		t.Errorf("created %d informers for rules of the same CRD, want 1", created)
+	}
+
+	em := &v1alpha1.EventMesh{Spec: v1alpha1.EventMeshSpec{Scaling: &v1alpha1.EventMeshSpecScaling{ObjectsPerReplica: ptr.To[int32](2)}}}
+	for _, tt := range []struct {
+		rule *Rule
+		want int64
+	}{
+		{rule: mtBrokerRule, want: 2},
+		// the scaling policy doesn't apply to rules, which aren't proportional
+		{rule: kafkaBrokerRule, want: 1},
+	} {
+		got, _, err := s.ScaleTarget(em, tt.rule, notInstalled)
+		if err != nil {
+			t.Fatalf("ScaleTarget(%s) = %v", tt.rule.Component, err)
+		}
+		if got != tt.want {
+			t.Errorf("ScaleTarget(%s) = %d, want %d", tt.rule.Component, got, tt.want)
+		}
+	}
+
+	// the objects can't be counted, while the informer is not synced
+	for _, state := range []dynamicinformer.State{dynamicinformer.StateStarting, dynamicinformer.StateFailed} {
+		informer.informerState, informer.informerErr = state, errors.New("forbidden")
+		if _, _, err := s.ScaleTarget(em, mtBrokerRule, notInstalled); !errors.Is(err, ErrNotSynced) {
+			t.Errorf("ScaleTarget() with %s informer = %v, want %v", state, err, ErrNotSynced)
+		}
+	}
+
+	// no objects are counted, as long as the informer is not started
+	informer.objs = nil
+	informer.informerState, informer.informerErr = dynamicinformer.StateStopped, nil
+	if got, _, err := s.ScaleTarget(em, mtBrokerRule, notInstalled); err != nil || got != 0 {
+		t.Errorf("ScaleTarget() without objects = %d, %v, want 0", got, err)
+	}
+}
+
+func TestObjectsInUse(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+
+	var created int
+	brokers := &fakeObjectInformer{objs: []interface{}{mtBroker("broker-1"), mtBroker("broker-2")}}
+	triggers := &fakeObjectInformer{}
+	channels := &fakeObjectInformer{objs: []interface{}{&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "channel"}}}}
+	informerFor := func(informer ObjectInformer) func(string) ObjectInformer {
+		return func(string) ObjectInformer {
+			created++
+			return informer
+		}
+	}
+
+	rule := &Rule{Component: ComponentMTBroker, CRDName: brokerCRDName, Informer: informerFor(brokers)}
+	s := newScaler(ctx, []*Rule{rule}, []*InUseObjects{
+		{Objects: "Brokers", CRDName: brokerCRDName, Informer: informerFor(brokers)},
+		{Objects: "Triggers", CRDName: triggerCRDName, Informer: informerFor(triggers)},
+		{Objects: "KafkaChannels", CRDName: kafkaChannelCRDName, Informer: informerFor(channels)},
+	})
+
+	if created != 3 {
+		t.Errorf("created %d informers, want 3 as the brokers share the informer of the rule", created)
+	}
+
+	got, err := s.ObjectsInUse()
+	if err != nil {
+		t.Fatalf("ObjectsInUse() = %v", err)
+	}
+	if diff := cmp.Diff([]string{"2 Brokers", "1 KafkaChannels"}, got); diff != "" {
+		t.Errorf("ObjectsInUse() (-want, +got) = %v", diff)
+	}
+
+	// the objects can't be counted, while an informer is not synced
+	triggers.informerState = dynamicinformer.StateStarting
+	if _, err := s.ObjectsInUse(); !errors.Is(err, ErrNotSynced) {
+		t.Errorf("ObjectsInUse() with starting informer = %v, want %v", err, ErrNotSynced)
+	}
+}
+
+func TestCRDEventHandler(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+
+	crd := func(storedVersions []string, versions ...apiextensionsv1.CustomResourceDefinitionVersion) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: brokerCRDName},
+			Spec:       apiextensionsv1.CustomResourceDefinitionSpec{Versions: versions},
+			Status:     apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: storedVersions},
+		}
+	}
+	v1Stored := apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true}
+	v1Served := apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1", Served: true}
+	v2Stored := apiextensionsv1.CustomResourceDefinitionVersion{Name: "v2", Served: true, Storage: true}
+	v2Unserved := apiextensionsv1.CustomResourceDefinitionVersion{Name: "v2"}
+
+	tests := []struct {
+		name         string
+		oldCRD       *apiextensionsv1.CustomResourceDefinition
+		newCRD       *apiextensionsv1.CustomResourceDefinition
+		wantStarts   int
+		wantRestarts int
+	}{
+		{
+			name:       "unchanged versions start a failed informer again",
+			oldCRD:     crd([]string{"v1"}, v1Stored),
+			newCRD:     crd([]string{"v1"}, v1Stored),
+			wantStarts: 1,
+		},
+		{
+			name:       "unserved version added",
+			oldCRD:     crd([]string{"v1"}, v1Stored),
+			newCRD:     crd([]string{"v1"}, v1Stored, v2Unserved),
+			wantStarts: 1,
+		},
+		{
+			name:         "served version added",
+			oldCRD:       crd([]string{"v1"}, v1Stored),
+			newCRD:       crd([]string{"v1"}, v1Served, v2Stored),
+			wantRestarts: 1,
+		},
+		{
+			name:         "stored versions changed",
+			oldCRD:       crd([]string{"v1"}, v1Served, v2Stored),
+			newCRD:       crd([]string{"v1", "v2"}, v1Served, v2Stored),
+			wantRestarts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			informer := &fakeObjectInformer{}
+			rule := &Rule{Component: ComponentMTBroker, CRDName: brokerCRDName, Informer: func(string) ObjectInformer { return informer }}
+			handler := newScaler(ctx, []*Rule{rule}, nil).CRDEventHandler(ctx, func(interface{}) {})
+
+			handler.OnUpdate(tt.oldCRD, tt.newCRD)
+
+			if informer.starts != tt.wantStarts || informer.restarts != tt.wantRestarts {
+				t.Errorf("got %d starts and %d restarts, want %d and %d", informer.starts, informer.restarts, tt.wantStarts, tt.wantRestarts)
+			}
+		})
+	}
+
+	t.Run("add and delete", func(t *testing.T) {
+		informer := &fakeObjectInformer{}
+		rule := &Rule{Component: ComponentMTBroker, CRDName: brokerCRDName, Informer: func(string) ObjectInformer { return informer }}
+		handler := newScaler(ctx, []*Rule{rule}, nil).CRDEventHandler(ctx, func(interface{}) {})
+
+		unrelated := &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "triggers.eventing.knative.dev"}}
+		handler.OnAdd(unrelated, false)
+		handler.OnAdd(crd(nil, v1Stored), false)
+		handler.OnDelete(unrelated)
+		handler.OnDelete(cache.DeletedFinalStateUnknown{Key: brokerCRDName, Obj: crd(nil, v1Stored)})
+
+		if informer.starts != 1 || informer.stops != 1 {
+			t.Errorf("got %d starts and %d stops, want 1 and 1", informer.starts, informer.stops)
+		}
+	})
+}
+
+func TestScaleTargetScaleDownDelay(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+
+	informer := &fakeObjectInformer{objs: []interface{}{mtBroker("broker-1"), mtBroker("broker-2"), mtBroker("broker-3")}}
+	rule := &Rule{Component: ComponentMTBroker, CRDName: brokerCRDName, Informer: func(string) ObjectInformer { return informer }, Proportional: true}
+	s := newScaler(ctx, []*Rule{rule}, nil)
+
+	now := time.Now()
+	s.scaleDowns.now = func() time.Time { return now }
+
+	em := &v1alpha1.EventMesh{
+		ObjectMeta: metav1.ObjectMeta{Name: "eventmesh"},
+		Spec: v1alpha1.EventMeshSpec{Scaling: &v1alpha1.EventMeshSpecScaling{
+			ObjectsPerReplica: ptr.To[int32](2),
+			ScaleDownDelay:    &metav1.Duration{Duration: time.Minute},
+		}},
+	}
+	assertScaleTarget := func(em *v1alpha1.EventMesh, current func() (int64, bool), wantTarget int64, wantScaleDownIn time.Duration) {
+		t.Helper()
+		target, scaleDownIn, err := s.ScaleTarget(em, rule, current)
+		if err != nil {
+			t.Fatalf("ScaleTarget() = %v", err)
+		}
+		if target != wantTarget || scaleDownIn != wantScaleDownIn {
+			t.Errorf("ScaleTarget() = %d, %s, want %d, %s", target, scaleDownIn, wantTarget, wantScaleDownIn)
+		}
+	}
+	reconcile := func(wantTarget int64, wantScaleDownIn time.Duration) {
+		t.Helper()
+		assertScaleTarget(em, notInstalled, wantTarget, wantScaleDownIn)
+		s.ScaleApplied(em)
+	}
+
+	reconcile(2, 0)
+
+	// scaling down is delayed
+	informer.objs = informer.objs[:1]
+	reconcile(2, time.Minute)
+	now = now.Add(40 * time.Second)
+	informer.objs = nil
+	reconcile(2, 20*time.Second)
+
+	// objects created again within the delay cancel the scale down
+	informer.objs = []interface{}{mtBroker("broker-1"), mtBroker("broker-2"), mtBroker("broker-3")}
+	reconcile(2, 0)
+
+	// the delay starts over and scales down, once it passed
+	informer.objs = nil
+	now = now.Add(30 * time.Second)
+	reconcile(2, time.Minute)
+	now = now.Add(time.Minute)
+
+	// the scale down is only recorded, once it got applied
+	assertScaleTarget(em, notInstalled, 0, 0)
+	assertScaleTarget(em, notInstalled, 0, 0)
+	s.ScaleApplied(em)
+
+	// scaling up is not delayed
+	informer.objs = []interface{}{mtBroker("broker-1")}
+	reconcile(1, 0)
+
+	// scaling a component of another EventMesh down is delayed from its current replicas, e.g. after a restart
+	other := em.DeepCopy()
+	other.Name = "restarted"
+	informer.objs = nil
+	assertScaleTarget(other, func() (int64, bool) { return 3, true }, 3, time.Minute)
+	s.ScaleApplied(other)
+	now = now.Add(time.Minute)
+	assertScaleTarget(other, func() (int64, bool) { return 3, true }, 0, 0)
+}
+
+func TestScaleTargetScaleDownDelayScaleToZeroOnly(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+
+	informer := &fakeObjectInformer{objs: []interface{}{mtBroker("broker-1")}}
+	rule := &Rule{Component: ComponentKafkaBroker, CRDName: brokerCRDName, Informer: func(string) ObjectInformer { return informer }, ScaleToZeroOnly: true}
+	s := newScaler(ctx, []*Rule{rule}, nil)
+
+	now := time.Now()
+	s.scaleDowns.now = func() time.Time { return now }
+
+	em := &v1alpha1.EventMesh{
+		ObjectMeta: metav1.ObjectMeta{Name: "eventmesh"},
+		Spec: v1alpha1.EventMeshSpec{Scaling: &v1alpha1.EventMeshSpecScaling{
+			ScaleDownDelay: &metav1.Duration{Duration: time.Minute},
+		}},
+	}
+	// the replicas of the data plane are owned by its own autoscaling
+	autoscaled := func() (int64, bool) { return 5, true }
+	reconcile := func(wantTarget int64, wantScaleDownIn time.Duration) {
+		t.Helper()
+		target, scaleDownIn, err := s.ScaleTarget(em, rule, autoscaled)
+		if err != nil {
+			t.Fatalf("ScaleTarget() = %v", err)
+		}
+		if target != wantTarget || scaleDownIn != wantScaleDownIn {
+			t.Errorf("ScaleTarget() = %d, %s, want %d, %s", target, scaleDownIn, wantTarget, wantScaleDownIn)
+		}
+		s.ScaleApplied(em)
+	}
+
+	// autoscaled replicas above the scale target are no scale down
+	reconcile(1, 0)
+
+	// only scaling to zero is delayed
+	informer.objs = nil
+	reconcile(1, time.Minute)
+	now = now.Add(time.Minute)
+	reconcile(0, 0)
+
+	// scaling to zero is delayed from autoscaled replicas, e.g. after a restart
+	other := em.DeepCopy()
+	other.Name = "restarted"
+	target, scaleDownIn, err := s.ScaleTarget(other, rule, autoscaled)
+	if err != nil || target != 1 || scaleDownIn != time.Minute {
+		t.Errorf("ScaleTarget() = %d, %s, %v, want 1, %s", target, scaleDownIn, err, time.Minute)
+	}
+}
+
+func notInstalled() (int64, bool) {
+	return 0, false
+}
+
+// fakeObjectInformer returns static objects
+type fakeObjectInformer struct {
+	objs          []interface{}
+	informerState dynamicinformer.State
+	informerErr   error
+	starts        int
+	restarts      int
+	stops         int
+}
+
+func (i *fakeObjectInformer) list() ([]interface{}, error) {
+	return i.objs, nil
+}
+
+func (i *fakeObjectInformer) state() (dynamicinformer.State, error) {
+	return i.informerState, i.informerErr
+}
+
+func (i *fakeObjectInformer) start(context.Context, HandlerFunc, func()) {
+	i.starts++
 }
 
-func (f *fakeInformerWrapper[L]) Informer() cache.SharedIndexInformer {
-	return f.informer
+func (i *fakeObjectInformer) restart(context.Context, HandlerFunc, func()) {
+	i.restarts++
 }
 
-func (f *fakeInformerWrapper[L]) Lister() L {
-	return f.lister
+func (i *fakeObjectInformer) stop(context.Context) {
+	i.stops++
 }
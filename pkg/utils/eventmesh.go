@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+)
+
+// PrimaryEventMesh returns the EventMesh which is responsible for the installation. Only one EventMesh is supported
+// per cluster. In case multiple exist, the oldest one (by creation timestamp and name) is the primary one. Returns
+// nil if the list contains no EventMesh.
+func PrimaryEventMesh(eventMeshes []*v1alpha1.EventMesh) *v1alpha1.EventMesh {
+	var primary *v1alpha1.EventMesh
+	for _, em := range eventMeshes {
+		if primary == nil || isOlder(em, primary) {
+			primary = em
+		}
+	}
+
+	return primary
+}
+
+func isOlder(em, other *v1alpha1.EventMesh) bool {
+	if !em.CreationTimestamp.Equal(&other.CreationTimestamp) {
+		return em.CreationTimestamp.Before(&other.CreationTimestamp)
+	}
+	return em.Name < other.Name
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+)
+
+func TestPrimaryEventMesh(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		eventMeshes []*v1alpha1.EventMesh
+		want        string
+	}{
+		{
+			name:        "no EventMesh",
+			eventMeshes: nil,
+			want:        "",
+		},
+		{
+			name: "single EventMesh",
+			eventMeshes: []*v1alpha1.EventMesh{
+				createEventMesh("first", now),
+			},
+			want: "first",
+		},
+		{
+			name: "oldest EventMesh is primary",
+			eventMeshes: []*v1alpha1.EventMesh{
+				createEventMesh("newer", now),
+				createEventMesh("older", now.Add(-time.Hour)),
+			},
+			want: "older",
+		},
+		{
+			name: "name decides on same creation timestamp",
+			eventMeshes: []*v1alpha1.EventMesh{
+				createEventMesh("b", now),
+				createEventMesh("a", now),
+			},
+			want: "a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PrimaryEventMesh(tt.eventMeshes)
+			if got == nil {
+				if tt.want != "" {
+					t.Errorf("PrimaryEventMesh() = nil, want %s", tt.want)
+				}
+				return
+			}
+
+			if got.Name != tt.want {
+				t.Errorf("PrimaryEventMesh() = %s, want %s", got.Name, tt.want)
+			}
+		})
+	}
+}
+
+func createEventMesh(name string, creationTimestamp time.Time) *v1alpha1.EventMesh {
+	return &v1alpha1.EventMesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(creationTimestamp),
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/reconciler"
+)
+
+// RecordEvent records the given reconciler event on the object. The event is dropped, if the context has no event
+// recorder (e.g. when the manifests are rendered offline).
+func RecordEvent(ctx context.Context, obj runtime.Object, event reconciler.Event) {
+	recorder := controller.GetEventRecorder(ctx)
+	if recorder == nil {
+		return
+	}
+
+	var e *reconciler.ReconcilerEvent
+	if reconciler.EventAs(event, &e) {
+		recorder.Eventf(obj, e.EventType, e.Reason, e.Format, e.Args...)
+	}
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	KafkaAuthProtocolKey      = "protocol"
+	KafkaAuthSASLMechanismKey = "sasl.mechanism"
+	KafkaAuthUserKey          = "user"
+	KafkaAuthPasswordKey      = "password"
+	KafkaAuthCACertKey        = "ca.crt"
+	KafkaAuthUserCertKey      = "user.crt"
+	KafkaAuthUserKeyKey       = "user.key"
+
+	KafkaProtocolPlaintext     = "PLAINTEXT"
+	KafkaProtocolSASLPlaintext = "SASL_PLAINTEXT"
+	KafkaProtocolSSL           = "SSL"
+	KafkaProtocolSASLSSL       = "SASL_SSL"
+
+	KafkaSASLMechanismPlain       = "PLAIN"
+	KafkaSASLMechanismScramSHA256 = "SCRAM-SHA-256"
+	KafkaSASLMechanismScramSHA512 = "SCRAM-SHA-512"
+)
+
+var (
+	KafkaProtocols = []string{
+		KafkaProtocolPlaintext,
+		KafkaProtocolSASLPlaintext,
+		KafkaProtocolSSL,
+		KafkaProtocolSASLSSL,
+	}
+
+	KafkaSASLMechanisms = []string{
+		KafkaSASLMechanismPlain,
+		KafkaSASLMechanismScramSHA256,
+		KafkaSASLMechanismScramSHA512,
+	}
+)
+
+// ValidateKafkaAuthSecret checks that the given secret holds a valid authentication configuration for the Kafka
+// components, following the format described in the eventing-kafka-broker documentation.
+func ValidateKafkaAuthSecret(secret *corev1.Secret) error {
+	var errs []error
+
+	protocol := string(secret.Data[KafkaAuthProtocolKey])
+	if protocol == "" {
+		return fmt.Errorf("key %q is missing", KafkaAuthProtocolKey)
+	}
+	if !slices.Contains(KafkaProtocols, protocol) {
+		return fmt.Errorf("unknown protocol %q, must be one of %q", protocol, strings.Join(KafkaProtocols, ", "))
+	}
+
+	if protocol == KafkaProtocolSASLPlaintext || protocol == KafkaProtocolSASLSSL {
+		if mechanism, ok := secret.Data[KafkaAuthSASLMechanismKey]; ok && !slices.Contains(KafkaSASLMechanisms, string(mechanism)) {
+			errs = append(errs, fmt.Errorf("unknown SASL mechanism %q, must be one of %q", mechanism, strings.Join(KafkaSASLMechanisms, ", ")))
+		}
+		if len(secret.Data[KafkaAuthUserKey]) == 0 {
+			errs = append(errs, fmt.Errorf("key %q is required for protocol %s", KafkaAuthUserKey, protocol))
+		}
+		if len(secret.Data[KafkaAuthPasswordKey]) == 0 {
+			errs = append(errs, fmt.Errorf("key %q is required for protocol %s", KafkaAuthPasswordKey, protocol))
+		}
+	}
+
+	if protocol == KafkaProtocolSSL || protocol == KafkaProtocolSASLSSL {
+		// ca.crt is optional, the system CAs are used when it is not set
+		if caCert, ok := secret.Data[KafkaAuthCACertKey]; ok {
+			if err := validateCertificate(caCert); err != nil {
+				errs = append(errs, fmt.Errorf("invalid %q: %w", KafkaAuthCACertKey, err))
+			}
+		}
+
+		userCert, hasUserCert := secret.Data[KafkaAuthUserCertKey]
+		_, hasUserKey := secret.Data[KafkaAuthUserKeyKey]
+		if hasUserCert != hasUserKey {
+			errs = append(errs, fmt.Errorf("keys %q and %q must be set together", KafkaAuthUserCertKey, KafkaAuthUserKeyKey))
+		}
+		if hasUserCert {
+			if err := validateCertificate(userCert); err != nil {
+				errs = append(errs, fmt.Errorf("invalid %q: %w", KafkaAuthUserCertKey, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateCertificate(data []byte) error {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return fmt.Errorf("no PEM encoded certificate found")
+	}
+
+	if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+		return fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidateKafkaAuthSecret(t *testing.T) {
+	cert := createCertificate(t)
+
+	tests := []struct {
+		name    string
+		data    map[string][]byte
+		wantErr bool
+	}{
+		{
+			name:    "missing protocol",
+			data:    map[string][]byte{},
+			wantErr: true,
+		},
+		{
+			name: "unknown protocol",
+			data: map[string][]byte{
+				KafkaAuthProtocolKey: []byte("FOO"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "plaintext",
+			data: map[string][]byte{
+				KafkaAuthProtocolKey: []byte(KafkaProtocolPlaintext),
+			},
+		},
+		{
+			name: "SASL plaintext",
+			data: map[string][]byte{
+				KafkaAuthProtocolKey:      []byte(KafkaProtocolSASLPlaintext),
+				KafkaAuthSASLMechanismKey: []byte(KafkaSASLMechanismScramSHA512),
+				KafkaAuthUserKey:          []byte("user"),
+				KafkaAuthPasswordKey:      []byte("password"),
+			},
+		},
+		{
+			name: "SASL plaintext without password",
+			data: map[string][]byte{
+				KafkaAuthProtocolKey: []byte(KafkaProtocolSASLPlaintext),
+				KafkaAuthUserKey:     []byte("user"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "SASL with unknown mechanism",
+			data: map[string][]byte{
+				KafkaAuthProtocolKey:      []byte(KafkaProtocolSASLPlaintext),
+				KafkaAuthSASLMechanismKey: []byte("GSSAPI"),
+				KafkaAuthUserKey:          []byte("user"),
+				KafkaAuthPasswordKey:      []byte("password"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "SSL with CA and client certificate",
+			data: map[string][]byte{
+				KafkaAuthProtocolKey: []byte(KafkaProtocolSSL),
+				KafkaAuthCACertKey:   cert,
+				KafkaAuthUserCertKey: cert,
+				KafkaAuthUserKeyKey:  []byte("key"),
+			},
+		},
+		{
+			name: "SSL with client certificate but without key",
+			data: map[string][]byte{
+				KafkaAuthProtocolKey: []byte(KafkaProtocolSSL),
+				KafkaAuthUserCertKey: cert,
+			},
+			wantErr: true,
+		},
+		{
+			name: "SSL with invalid CA",
+			data: map[string][]byte{
+				KafkaAuthProtocolKey: []byte(KafkaProtocolSSL),
+				KafkaAuthCACertKey:   []byte("not a certificate"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "SASL SSL without CA",
+			data: map[string][]byte{
+				KafkaAuthProtocolKey: []byte(KafkaProtocolSASLSSL),
+				KafkaAuthUserKey:     []byte("user"),
+				KafkaAuthPasswordKey: []byte("password"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateKafkaAuthSecret(&corev1.Secret{Data: tt.data})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateKafkaAuthSecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func createCertificate(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"fmt"
+
+	apiextensionsv1listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// kedaCRDs are the KEDA CRDs used by the Kafka controller to autoscale the consumers
+var kedaCRDs = []string{
+	"scaledobjects.keda.sh",
+	"triggerauthentications.keda.sh",
+}
+
+func IsKEDAInstalled(crdLister apiextensionsv1listers.CustomResourceDefinitionLister) (bool, error) {
+	for _, crd := range kedaCRDs {
+		_, err := crdLister.Get(crd)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to get %s CRD: %w", crd, err)
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestIsKEDAInstalled(t *testing.T) {
+	tests := []struct {
+		name string
+		crds []string
+		want bool
+	}{
+		{
+			name: "no KEDA CRDs",
+			crds: []string{"brokers.eventing.knative.dev"},
+			want: false,
+		},
+		{
+			name: "only the ScaledObject CRD",
+			crds: []string{"scaledobjects.keda.sh"},
+			want: false,
+		},
+		{
+			name: "all KEDA CRDs",
+			crds: []string{"scaledobjects.keda.sh", "triggerauthentications.keda.sh"},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, name := range tt.crds {
+				if err := indexer.Add(&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: name}}); err != nil {
+					t.Fatalf("failed to add CRD %s: %v", name, err)
+				}
+			}
+
+			got, err := IsKEDAInstalled(apiextensionsv1listers.NewCustomResourceDefinitionLister(indexer))
+			if err != nil {
+				t.Fatalf("IsKEDAInstalled() = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsKEDAInstalled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/eventmesh-operator/pkg/client/clientset/versioned"
+)
+
+// SingleEventMesh rejects the creation of an EventMesh, if another EventMesh exists already
+func SingleEventMesh(client versioned.Interface) func(context.Context, *unstructured.Unstructured) error {
+	return func(ctx context.Context, u *unstructured.Unstructured) error {
+		eventMeshes, err := client.OperatorV1alpha1().EventMeshes(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to list EventMeshes: %w", err)
+		}
+
+		for _, em := range eventMeshes.Items {
+			if em.Name != u.GetName() {
+				return fmt.Errorf("only one EventMesh is supported per cluster and EventMesh %s exists already", em.Name)
+			}
+		}
+
+		return nil
+	}
+}
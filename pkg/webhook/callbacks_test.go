@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/eventmesh-operator/pkg/apis/operator/v1alpha1"
+	"knative.dev/eventmesh-operator/pkg/client/clientset/versioned/fake"
+)
+
+func TestSingleEventMesh(t *testing.T) {
+	existing := &v1alpha1.EventMesh{ObjectMeta: metav1.ObjectMeta{Name: "eventmesh"}}
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		listErr error
+		created string
+		wantErr string
+	}{{
+		name:    "first EventMesh is allowed",
+		created: "eventmesh",
+	}, {
+		name:    "second EventMesh is rejected",
+		objects: []runtime.Object{existing},
+		created: "another",
+		wantErr: "only one EventMesh is supported per cluster and EventMesh eventmesh exists already",
+	}, {
+		// the API server rejects creating an existing EventMesh on its own
+		name:    "existing EventMesh is not rejected",
+		objects: []runtime.Object{existing},
+		created: "eventmesh",
+	}, {
+		name:    "list error",
+		listErr: errors.New("forbidden"),
+		created: "eventmesh",
+		wantErr: "failed to list EventMeshes: forbidden",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			for _, obj := range tt.objects {
+				// the object tracker guesses the resource of an EventMesh as eventmeshs
+				if err := client.Tracker().Create(v1alpha1.SchemeGroupVersion.WithResource("eventmeshes"), obj, ""); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.listErr != nil {
+				client.PrependReactor("list", "eventmeshes", func(clientgotesting.Action) (bool, runtime.Object, error) {
+					return true, nil, tt.listErr
+				})
+			}
+
+			u := &unstructured.Unstructured{}
+			u.SetName(tt.created)
+
+			err := SingleEventMesh(client)(context.Background(), u)
+			if (err == nil && tt.wantErr != "") || (err != nil && err.Error() != tt.wantErr) {
+				t.Errorf("SingleEventMesh() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}